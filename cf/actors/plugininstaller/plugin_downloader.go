@@ -36,45 +36,37 @@ func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string
 	return executablePath
 }
 
-func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, string) {
-	arch := runtime.GOARCH
+// DownloadFromPlugin downloads the binary of a repository plugin for this
+// platform, and returns its path along with the repository entry for it
+func (downloader *PluginDownloader) DownloadFromPlugin(plugin clipr.Plugin) (string, clipr.Binary) {
+	platform := binaryPlatform()
+	for _, binary := range plugin.Binaries {
+		if platform != "" && binary.Platform == platform {
+			return downloader.downloadFromPath(binary.Url), binary
+		}
+	}
 
+	downloader.binaryNotAvailable()
+	return "", clipr.Binary{}
+}
+
+func binaryPlatform() string {
 	switch runtime.GOOS {
 	case "darwin":
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "osx")), downloader.getBinaryChecksum(plugin, "osx")
+		return "osx"
 	case "linux":
-		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux32")), downloader.getBinaryChecksum(plugin, "linux32")
+		if runtime.GOARCH == "386" {
+			return "linux32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux64")), downloader.getBinaryChecksum(plugin, "linux64")
+		return "linux64"
 	case "windows":
-		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win32")), downloader.getBinaryChecksum(plugin, "win32")
+		if runtime.GOARCH == "386" {
+			return "win32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win64")), downloader.getBinaryChecksum(plugin, "win64")
+		return "win64"
 	default:
-		downloader.binaryNotAvailable()
-	}
-	return "", ""
-}
-
-func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
-	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Url
-		}
-	}
-	downloader.binaryNotAvailable()
-	return ""
-}
-
-func (downloader *PluginDownloader) getBinaryChecksum(plugin clipr.Plugin, os string) string {
-	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Checksum
-		}
+		return ""
 	}
-	return ""
 }
 
 func (downloader *PluginDownloader) binaryNotAvailable() {
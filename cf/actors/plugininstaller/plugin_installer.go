@@ -15,12 +15,15 @@ type PluginInstaller interface {
 }
 
 type Context struct {
-	Checksummer    utils.Sha1Checksum
-	FileDownloader downloader.Downloader
-	GetPluginRepos pluginReposFetcher
-	PluginRepo     pluginrepo.PluginRepo
-	RepoName       string
-	UI             terminal.UI
+	Checksummer         utils.Checksum
+	FileDownloader      downloader.Downloader
+	SignatureDownloader downloader.Downloader
+	GetPluginRepos      pluginReposFetcher
+	PluginRepo          pluginrepo.PluginRepo
+	RepoName            string
+	TrustedKeys         []models.PluginKey
+	TrustPolicy         string
+	UI                  terminal.UI
 }
 
 type pluginReposFetcher func() []models.PluginRepo
@@ -29,20 +32,25 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 	var installer PluginInstaller
 
 	pluginDownloader := &PluginDownloader{UI: context.UI, FileDownloader: context.FileDownloader}
+	verifier := &SignatureVerifier{UI: context.UI, TrustedKeys: context.TrustedKeys, Downloader: context.SignatureDownloader}
 	if context.RepoName == "" {
 		installer = &pluginInstallerWithoutRepo{
 			UI:               context.UI,
 			PluginDownloader: pluginDownloader,
 			RepoName:         context.RepoName,
+			Verifier:         verifier,
+			TrustPolicy:      context.TrustPolicy,
 		}
 	} else {
 		installer = &pluginInstallerWithRepo{
-			UI:               context.UI,
-			PluginDownloader: pluginDownloader,
-			RepoName:         context.RepoName,
-			Checksummer:      context.Checksummer,
-			PluginRepo:       context.PluginRepo,
-			GetPluginRepos:   context.GetPluginRepos,
+			UI:                 context.UI,
+			PluginDownloader:   pluginDownloader,
+			RepoName:           context.RepoName,
+			Checksummer:        context.Checksummer,
+			PluginRepo:         context.PluginRepo,
+			GetPluginRepos:     context.GetPluginRepos,
+			Verifier:           verifier,
+			DefaultTrustPolicy: context.TrustPolicy,
 		}
 	}
 	return installer
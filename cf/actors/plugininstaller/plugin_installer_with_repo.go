@@ -17,9 +17,12 @@ type pluginInstallerWithRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
-	Checksummer      utils.Sha1Checksum
+	Checksummer      utils.Checksum
 	PluginRepo       pluginrepo.PluginRepo
 	GetPluginRepos   pluginReposFetcher
+
+	Verifier           *SignatureVerifier
+	DefaultTrustPolicy string
 }
 
 func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) string {
@@ -40,16 +43,17 @@ func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) st
 	}
 
 	found := false
-	sha1 := ""
 	for _, plugin := range findRepoCaseInsensity(pluginList, installer.RepoName) {
 		if strings.ToLower(plugin.Name) == targetPluginName {
 			found = true
-			outputSourceFilepath, sha1 = installer.PluginDownloader.downloadFromPlugin(plugin)
+			var binary clipr.Binary
+			outputSourceFilepath, binary = installer.PluginDownloader.DownloadFromPlugin(plugin)
 
-			installer.Checksummer.SetFilePath(outputSourceFilepath)
-			if !installer.Checksummer.CheckSha1(sha1) {
+			if !ChecksumMatches(installer.UI, installer.Checksummer, outputSourceFilepath, binary.Checksum) {
 				installer.UI.Failed(T("Downloaded plugin binary's checksum does not match repo metadata"))
 			}
+
+			installer.Verifier.Verify(outputSourceFilepath, binary.Url, TrustPolicy(repoModel, installer.DefaultTrustPolicy))
 		}
 
 	}
@@ -3,7 +3,6 @@ package plugininstaller
 import (
 	"os"
 	"path/filepath"
-	"strings"
 
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/terminal"
@@ -14,6 +13,8 @@ type pluginInstallerWithoutRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
+	Verifier         *SignatureVerifier
+	TrustPolicy      string
 }
 
 func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (outputSourceFilepath string) {
@@ -24,14 +25,16 @@ func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string)
 	}
 
 	installer.UI.Say("")
-	if strings.HasPrefix(outputSourceFilepath, "https://") || strings.HasPrefix(outputSourceFilepath, "http://") ||
-		strings.HasPrefix(outputSourceFilepath, "ftp://") || strings.HasPrefix(outputSourceFilepath, "ftps://") {
+	if isRemoteLocation(outputSourceFilepath) {
 		installer.UI.Say(T("Attempting to download binary file from internet address..."))
-		return installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		downloadedFilepath := installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		installer.Verifier.Verify(downloadedFilepath, outputSourceFilepath, installer.TrustPolicy)
+		return downloadedFilepath
 	} else if !installer.ensureCandidatePluginBinaryExistsAtGivenPath(outputSourceFilepath) {
 		installer.UI.Failed(T("File not found locally, make sure the file exists at given path {{.filepath}}", map[string]interface{}{"filepath": outputSourceFilepath}))
 	}
 
+	installer.Verifier.Verify(outputSourceFilepath, outputSourceFilepath, installer.TrustPolicy)
 	return outputSourceFilepath
 }
 
@@ -0,0 +1,102 @@
+package plugininstaller
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/downloader"
+	"github.com/cloudfoundry/cli/utils"
+)
+
+// SignatureVerifier checks plugin binaries against the trusted plugin keys.
+// The signature of a binary is expected next to it, at its URL or path with
+// ".sig" appended.
+type SignatureVerifier struct {
+	UI          terminal.UI
+	TrustedKeys []models.PluginKey
+	Downloader  downloader.Downloader
+}
+
+// TrustPolicy is the policy of a repository, or the given default when the
+// repository has none; plugins only cause a warning when neither is set
+func TrustPolicy(repo models.PluginRepo, defaultPolicy string) string {
+	if repo.TrustPolicy != "" {
+		return repo.TrustPolicy
+	}
+	if defaultPolicy != "" {
+		return defaultPolicy
+	}
+	return models.PluginTrustWarn
+}
+
+// Verify applies a trust policy to the binary downloaded from source:
+// require-signed fails unless it is signed by a trusted key, warn only warns
+// and allow does not look for a signature at all.
+func (verifier *SignatureVerifier) Verify(binaryFilepath, source, policy string) {
+	if policy == models.PluginTrustAllow {
+		return
+	}
+
+	problem := ""
+	signature, err := verifier.fetchSignature(source + ".sig")
+	if err != nil {
+		problem = T("no signature was found at {{.Location}}", map[string]interface{}{"Location": source + ".sig"})
+	} else if len(verifier.TrustedKeys) == 0 {
+		problem = T("no trusted plugin keys have been added")
+	} else {
+		for _, key := range verifier.TrustedKeys {
+			if utils.VerifyFileSignature(binaryFilepath, signature, key.PublicKey) == nil {
+				verifier.UI.Say(T("Plugin binary is signed by trusted key {{.KeyName}}", map[string]interface{}{"KeyName": terminal.EntityNameColor(key.Name)}))
+				return
+			}
+		}
+		problem = T("the signature is not from a trusted key")
+	}
+
+	if policy == models.PluginTrustRequireSigned {
+		verifier.UI.Failed(T("Plugin binary rejected: {{.Problem}}\nThe trust policy requires plugins signed by a trusted key.\nTip: use 'add-plugin-key' to trust the key of the plugin author", map[string]interface{}{"Problem": problem}))
+		return
+	}
+
+	verifier.UI.Warn(T("Warning: the plugin binary could not be verified, {{.Problem}}", map[string]interface{}{"Problem": problem}))
+}
+
+func (verifier *SignatureVerifier) fetchSignature(location string) ([]byte, error) {
+	if !isRemoteLocation(location) {
+		return ioutil.ReadFile(location)
+	}
+
+	_, filename, err := verifier.Downloader.DownloadFile(location)
+	if err != nil {
+		return nil, err
+	}
+	defer verifier.Downloader.RemoveFile()
+
+	return ioutil.ReadFile(filepath.Join(verifier.Downloader.SavePath(), filename))
+}
+
+// ChecksumMatches compares a downloaded binary with the checksum listed by a
+// repository, which is SHA-256 unless the repository is older and only lists
+// SHA-1 checksums
+func ChecksumMatches(ui terminal.UI, checksummer utils.Checksum, binaryFilepath, checksum string) bool {
+	checksummer.SetFilePath(binaryFilepath)
+
+	if len(checksum) == 40 {
+		ui.Warn(T("Warning: the repository only lists a SHA-1 checksum for this plugin"))
+		return checksummer.CheckSha1(checksum)
+	}
+	return checksummer.CheckSha256(checksum)
+}
+
+func isRemoteLocation(location string) bool {
+	for _, scheme := range []string{"https://", "http://", "ftp://", "ftps://", "file://"} {
+		if strings.HasPrefix(location, scheme) {
+			return true
+		}
+	}
+	return false
+}
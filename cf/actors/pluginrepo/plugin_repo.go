@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
@@ -19,10 +20,14 @@ type PluginRepo interface {
 	GetPlugins([]models.PluginRepo) (map[string][]clipr.Plugin, []string)
 }
 
-type pluginRepo struct{}
+type pluginRepo struct {
+	proxy func(*http.Request) (*url.URL, error)
+}
 
-func NewPluginRepo() PluginRepo {
-	return pluginRepo{}
+func NewPluginRepo(proxy func(*http.Request) (*url.URL, error)) PluginRepo {
+	return pluginRepo{
+		proxy: proxy,
+	}
 }
 
 func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Plugin, []string) {
@@ -31,13 +36,21 @@ func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Pl
 	repoPlugins := make(map[string][]clipr.Plugin)
 
 	for _, repo := range repos {
-		resp, err := http.Get(getListEndpoint(repo.URL))
+		client := &http.Client{Transport: NewTransport(repo, r.proxy)}
+		listEndpoint := getListEndpoint(repo.URL)
+
+		resp, err := client.Get(listEndpoint)
 		if err != nil {
 			repoError = append(repoError, fmt.Sprintf(T("Error requesting from")+" '%s' - %s", repo.Name, err.Error()))
 			continue
 		} else {
 			defer resp.Body.Close()
 
+			if resp.StatusCode != http.StatusOK {
+				repoError = append(repoError, fmt.Sprintf(T("Error requesting from")+" '%s' - %s", repo.Name, resp.Status))
+				continue
+			}
+
 			body, err := ioutil.ReadAll(resp.Body)
 			if err != nil {
 				repoError = append(repoError, fmt.Sprintf(T("Error reading response from")+" '%s' - %s ", repo.Name, err.Error()))
@@ -54,6 +67,7 @@ func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Pl
 				continue
 			}
 
+			resolveBinaryURLs(listEndpoint, pluginList.Plugins)
 		}
 
 		repoPlugins[repo.Name] = pluginList.Plugins
@@ -62,6 +76,35 @@ func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Pl
 	return repoPlugins, repoError
 }
 
+// FindRepo returns the repository with the given name, ignoring case
+func FindRepo(repos []models.PluginRepo, name string) (models.PluginRepo, bool) {
+	for _, repo := range repos {
+		if strings.ToLower(repo.Name) == strings.ToLower(name) {
+			return repo, true
+		}
+	}
+	return models.PluginRepo{}, false
+}
+
+// resolveBinaryURLs makes the binary URLs of the plugins absolute. Relative
+// URLs, like the ones written by mirror-plugin-repo, are relative to the list
+// endpoint of the repository.
+func resolveBinaryURLs(listEndpoint string, plugins []clipr.Plugin) {
+	base, err := url.Parse(listEndpoint)
+	if err != nil {
+		return
+	}
+
+	for i := range plugins {
+		for j, binary := range plugins[i].Binaries {
+			binaryURL, err := url.Parse(binary.Url)
+			if err == nil && !binaryURL.IsAbs() {
+				plugins[i].Binaries[j].Url = base.ResolveReference(binaryURL).String()
+			}
+		}
+	}
+}
+
 func getListEndpoint(url string) string {
 	if strings.HasSuffix(url, "/") {
 		return url + "list"
@@ -2,8 +2,11 @@ package pluginrepo_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 
 	. "github.com/cloudfoundry/cli/cf/actors/pluginrepo"
 	"github.com/cloudfoundry/cli/cf/models"
@@ -23,7 +26,7 @@ var _ = Describe("PluginRepo", func() {
 	)
 
 	BeforeEach(func() {
-		repoActor = NewPluginRepo()
+		repoActor = NewPluginRepo(http.ProxyFromEnvironment)
 	})
 
 	Context("request data from all repos", func() {
@@ -199,4 +202,88 @@ var _ = Describe("PluginRepo", func() {
 
 		})
 	})
+
+	Context("When the repo requires authentication", func() {
+		BeforeEach(func() {
+			h1 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer my-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprintln(w, `{"plugins":[{"name":"plugin1","binaries":[{"platform":"osx","url":"binaries/plugin1/osx/echo"}]}]}`)
+			})
+			testServer1 = httptest.NewServer(h1)
+		})
+
+		AfterEach(func() {
+			testServer1.Close()
+		})
+
+		It("sends the credentials of the repo", func() {
+			list, errs := repoActor.GetPlugins([]models.PluginRepo{
+				{
+					Name:  "repo1",
+					URL:   testServer1.URL,
+					Token: "my-token",
+				},
+			})
+
+			Expect(errs).To(BeEmpty())
+			Expect(list["repo1"][0].Name).To(Equal("plugin1"))
+		})
+
+		It("resolves relative binary urls against the repo url", func() {
+			list, _ := repoActor.GetPlugins([]models.PluginRepo{
+				{
+					Name:  "repo1",
+					URL:   testServer1.URL + "/",
+					Token: "my-token",
+				},
+			})
+
+			Expect(list["repo1"][0].Binaries[0].Url).To(Equal(testServer1.URL + "/binaries/plugin1/osx/echo"))
+		})
+
+		It("informs user of the status of the response without the credentials", func() {
+			_, errs := repoActor.GetPlugins([]models.PluginRepo{
+				{
+					Name: "repo1",
+					URL:  testServer1.URL,
+				},
+			})
+
+			Expect(errs).To(ContainSubstrings(
+				[]string{"Error requesting from", "'repo1'", "401 Unauthorized"},
+			))
+		})
+	})
+
+	Context("When the repo is a local directory", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "plugin-repo")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(dir, "list"), []byte(`{"plugins":[{"name":"plugin1","binaries":[{"platform":"osx","url":"binaries/echo"}]}]}`), 0600)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads the list from a file url", func() {
+			list, errs := repoActor.GetPlugins([]models.PluginRepo{
+				{
+					Name: "mirror",
+					URL:  "file://" + filepath.ToSlash(dir),
+				},
+			})
+
+			Expect(errs).To(BeEmpty())
+			Expect(list["mirror"][0].Binaries[0].Url).To(Equal("file://" + filepath.ToSlash(dir) + "/binaries/echo"))
+		})
+	})
 })
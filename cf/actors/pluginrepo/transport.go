@@ -0,0 +1,86 @@
+package pluginrepo
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/downloader"
+)
+
+// NewTransport returns the transport for requests to a plugin repository.
+// It adds the credentials of the repository to requests for the scheme and
+// host of its URL only, so that they are not sent on to binaries hosted
+// elsewhere. Only a repository with a file:// URL can read local files, so
+// that a remote one cannot redirect to them.
+func NewTransport(repo models.PluginRepo, proxy func(*http.Request) (*url.URL, error)) http.RoundTripper {
+	base := downloader.NewTransport(proxy)
+
+	transport := repoTransport{repo: repo, base: base}
+	if repoURL, err := url.Parse(repo.URL); err == nil {
+		transport.scheme = repoURL.Scheme
+		transport.host = repoURL.Host
+
+		if strings.EqualFold(repoURL.Scheme, "file") {
+			base.RegisterProtocol("file", http.NewFileTransport(localFileSystem{}))
+		}
+	}
+	return transport
+}
+
+// FileURL returns the file:// URL of a local directory
+func FileURL(dir string) string {
+	path := filepath.ToSlash(dir)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// localFileSystem opens the paths of file:// URLs, which on Windows have a
+// slash in front of the drive letter
+type localFileSystem struct{}
+
+func (localFileSystem) Open(name string) (http.File, error) {
+	if runtime.GOOS == "windows" {
+		name = strings.TrimPrefix(name, "/")
+	}
+	return os.Open(filepath.FromSlash(name))
+}
+
+type repoTransport struct {
+	repo   models.PluginRepo
+	scheme string
+	host   string
+	base   http.RoundTripper
+}
+
+func (t repoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !HasCredentials(t.repo) || req.URL.Scheme != t.scheme || req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
+
+	authenticated := new(http.Request)
+	*authenticated = *req
+	authenticated.Header = make(http.Header, len(req.Header)+1)
+	for key, values := range req.Header {
+		authenticated.Header[key] = values
+	}
+
+	if t.repo.Token != "" {
+		authenticated.Header.Set("Authorization", "Bearer "+t.repo.Token)
+	} else {
+		authenticated.SetBasicAuth(t.repo.Username, t.repo.Password)
+	}
+
+	return t.base.RoundTrip(authenticated)
+}
+
+// HasCredentials returns whether requests to the repository are authenticated
+func HasCredentials(repo models.PluginRepo) bool {
+	return repo.Token != "" || repo.Username != ""
+}
@@ -0,0 +1,98 @@
+package pluginrepo_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Transport", func() {
+	var (
+		repoServer    *httptest.Server
+		otherServer   *httptest.Server
+		authorization chan string
+	)
+
+	BeforeEach(func() {
+		authorization = make(chan string, 1)
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization <- r.Header.Get("Authorization")
+		})
+		repoServer = httptest.NewServer(handler)
+		otherServer = httptest.NewServer(handler)
+	})
+
+	AfterEach(func() {
+		repoServer.Close()
+		otherServer.Close()
+	})
+
+	get := func(repo models.PluginRepo, url string) string {
+		client := &http.Client{Transport: NewTransport(repo, http.ProxyFromEnvironment)}
+		resp, err := client.Get(url)
+		Expect(err).ToNot(HaveOccurred())
+		resp.Body.Close()
+		return <-authorization
+	}
+
+	It("sends basic authentication to the repo", func() {
+		repo := models.PluginRepo{URL: repoServer.URL, Username: "admin", Password: "secret"}
+		Expect(get(repo, repoServer.URL+"/list")).To(Equal("Basic YWRtaW46c2VjcmV0"))
+	})
+
+	It("sends the token to the repo", func() {
+		repo := models.PluginRepo{URL: repoServer.URL, Token: "my-token"}
+		Expect(get(repo, repoServer.URL+"/list")).To(Equal("Bearer my-token"))
+	})
+
+	It("does not send the credentials to other hosts", func() {
+		repo := models.PluginRepo{URL: repoServer.URL, Token: "my-token"}
+		Expect(get(repo, otherServer.URL+"/plugin")).To(BeEmpty())
+	})
+
+	Context("with a local repo", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "plugin-repo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "list"), []byte(`{"plugins":[]}`), 0644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("reads the files of the repo", func() {
+			repo := models.PluginRepo{URL: FileURL(dir)}
+			client := &http.Client{Transport: NewTransport(repo, http.ProxyFromEnvironment)}
+
+			resp, err := client.Get(FileURL(dir) + "/list")
+			Expect(err).ToNot(HaveOccurred())
+			defer resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(ioutil.ReadAll(resp.Body)).To(MatchJSON(`{"plugins":[]}`))
+		})
+
+		It("does not read local files for a remote repo", func() {
+			redirectServer := httptest.NewServer(http.RedirectHandler(FileURL(dir)+"/list", http.StatusFound))
+			defer redirectServer.Close()
+
+			repo := models.PluginRepo{URL: redirectServer.URL}
+			client := &http.Client{Transport: NewTransport(repo, http.ProxyFromEnvironment)}
+
+			_, err := client.Get(redirectServer.URL + "/list")
+			Expect(err).To(MatchError(ContainSubstring("unsupported protocol scheme")))
+		})
+	})
+})
@@ -0,0 +1,100 @@
+package pluginrepo
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// Update is a newer release of an installed plugin found in a repository
+type Update struct {
+	Name             string
+	InstalledVersion plugin.VersionType
+	Version          plugin.VersionType
+	RepoName         string
+	Plugin           clipr.Plugin
+}
+
+type updatesByName []Update
+
+func (u updatesByName) Len() int           { return len(u) }
+func (u updatesByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u updatesByName) Less(i, j int) bool { return u[i].Name < u[j].Name }
+
+// FindUpdates compares the installed plugins with the plugins listed by the
+// repositories and returns the newest release of each plugin that is newer
+// than the installed one, sorted by name. When several repositories have the
+// same newest version, the repository first in alphabetical order wins.
+func FindUpdates(installed map[string]pluginconfig.PluginMetadata, repoPlugins map[string][]clipr.Plugin) []Update {
+	repoNames := []string{}
+	for repoName := range repoPlugins {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Strings(repoNames)
+
+	updates := []Update{}
+	for name, metadata := range installed {
+		found := false
+		update := Update{Name: name, InstalledVersion: metadata.Version, Version: metadata.Version}
+
+		for _, repoName := range repoNames {
+			for _, repoPlugin := range repoPlugins[repoName] {
+				if !strings.EqualFold(repoPlugin.Name, name) {
+					continue
+				}
+
+				version, ok := ParseVersion(repoPlugin.Version)
+				if !ok || !VersionLess(update.Version, version) {
+					continue
+				}
+
+				found = true
+				update.Version = version
+				update.RepoName = repoName
+				update.Plugin = repoPlugin
+			}
+		}
+
+		if found {
+			updates = append(updates, update)
+		}
+	}
+
+	sort.Sort(updatesByName(updates))
+	return updates
+}
+
+// ParseVersion reads versions such as "1.2.3", "v1.2" or "2"; missing
+// parts are zero
+func ParseVersion(version string) (plugin.VersionType, bool) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".")
+	if len(parts) > 3 {
+		return plugin.VersionType{}, false
+	}
+
+	numbers := []int{0, 0, 0}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return plugin.VersionType{}, false
+		}
+		numbers[i] = number
+	}
+
+	return plugin.VersionType{Major: numbers[0], Minor: numbers[1], Build: numbers[2]}, true
+}
+
+// VersionLess reports whether a is older than b
+func VersionLess(a, b plugin.VersionType) bool {
+	if a.Major != b.Major {
+		return a.Major < b.Major
+	}
+	if a.Minor != b.Minor {
+		return a.Minor < b.Minor
+	}
+	return a.Build < b.Build
+}
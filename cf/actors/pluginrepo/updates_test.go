@@ -0,0 +1,88 @@
+package pluginrepo_test
+
+import (
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+	. "github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/plugin"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Updates", func() {
+	Describe("ParseVersion", func() {
+		It("reads full and partial versions", func() {
+			expected := map[string]plugin.VersionType{
+				"1.2.3": {Major: 1, Minor: 2, Build: 3},
+				"v2.10": {Major: 2, Minor: 10},
+				"3":     {Major: 3},
+			}
+			for version, parsed := range expected {
+				actual, ok := ParseVersion(version)
+				Expect(ok).To(BeTrue(), version)
+				Expect(actual).To(Equal(parsed))
+			}
+		})
+
+		It("rejects versions it cannot read", func() {
+			for _, version := range []string{"", "1.2.3.4", "one", "1.-2"} {
+				_, ok := ParseVersion(version)
+				Expect(ok).To(BeFalse(), version)
+			}
+		})
+	})
+
+	Describe("FindUpdates", func() {
+		var installed map[string]pluginconfig.PluginMetadata
+
+		BeforeEach(func() {
+			installed = map[string]pluginconfig.PluginMetadata{
+				"current":  {Version: plugin.VersionType{Major: 1}},
+				"outdated": {Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+				"unlisted": {Version: plugin.VersionType{Major: 1}},
+			}
+		})
+
+		It("returns the newest release of each outdated plugin across all repos", func() {
+			updates := FindUpdates(installed, map[string][]clipr.Plugin{
+				"repo-b": {
+					{Name: "Outdated", Version: "1.10.0"},
+					{Name: "current", Version: "1.0.0"},
+				},
+				"repo-a": {
+					{Name: "outdated", Version: "1.9.9"},
+					{Name: "current", Version: "not-a-version"},
+				},
+			})
+
+			Expect(updates).To(HaveLen(1))
+			Expect(updates[0].Name).To(Equal("outdated"))
+			Expect(updates[0].InstalledVersion).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 3}))
+			Expect(updates[0].Version).To(Equal(plugin.VersionType{Major: 1, Minor: 10}))
+			Expect(updates[0].RepoName).To(Equal("repo-b"))
+			Expect(updates[0].Plugin.Name).To(Equal("Outdated"))
+		})
+
+		It("prefers the first repo in alphabetical order for the same version", func() {
+			updates := FindUpdates(installed, map[string][]clipr.Plugin{
+				"repo-b": {{Name: "outdated", Version: "2.0.0"}},
+				"repo-a": {{Name: "outdated", Version: "2.0.0"}},
+			})
+
+			Expect(updates).To(HaveLen(1))
+			Expect(updates[0].RepoName).To(Equal("repo-a"))
+		})
+
+		It("sorts updates by name", func() {
+			installed["another"] = pluginconfig.PluginMetadata{}
+			updates := FindUpdates(installed, map[string][]clipr.Plugin{
+				"repo": {{Name: "outdated", Version: "2.0.0"}, {Name: "another", Version: "0.1"}},
+			})
+
+			Expect(updates).To(HaveLen(2))
+			Expect(updates[0].Name).To(Equal("another"))
+			Expect(updates[1].Name).To(Equal("outdated"))
+		})
+	})
+})
@@ -36,7 +36,7 @@ type FakeSpaceRepository struct {
 		result1 models.Space
 		result2 error
 	}
-	CreateStub        func(name string, orgGUID string, spaceQuotaGUID string) (space models.Space, apiErr error)
+	CreateStub        func(name, orgGUID, spaceQuotaGUID string) (space models.Space, apiErr error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
 		name           string
@@ -73,6 +73,15 @@ type FakeSpaceRepository struct {
 	deleteReturns struct {
 		result1 error
 	}
+	DeleteWithoutWaitingStub        func(spaceGUID string) (jobURL string, apiErr error)
+	deleteWithoutWaitingMutex       sync.RWMutex
+	deleteWithoutWaitingArgsForCall []struct {
+		spaceGUID string
+	}
+	deleteWithoutWaitingReturns struct {
+		result1 string
+		result2 error
+	}
 }
 
 func (fake *FakeSpaceRepository) ListSpaces(arg1 func(models.Space) bool) error {
@@ -307,4 +316,37 @@ func (fake *FakeSpaceRepository) DeleteReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSpaceRepository) DeleteWithoutWaiting(spaceGUID string) (jobURL string, apiErr error) {
+	fake.deleteWithoutWaitingMutex.Lock()
+	fake.deleteWithoutWaitingArgsForCall = append(fake.deleteWithoutWaitingArgsForCall, struct {
+		spaceGUID string
+	}{spaceGUID})
+	fake.deleteWithoutWaitingMutex.Unlock()
+	if fake.DeleteWithoutWaitingStub != nil {
+		return fake.DeleteWithoutWaitingStub(spaceGUID)
+	} else {
+		return fake.deleteWithoutWaitingReturns.result1, fake.deleteWithoutWaitingReturns.result2
+	}
+}
+
+func (fake *FakeSpaceRepository) DeleteWithoutWaitingCallCount() int {
+	fake.deleteWithoutWaitingMutex.RLock()
+	defer fake.deleteWithoutWaitingMutex.RUnlock()
+	return len(fake.deleteWithoutWaitingArgsForCall)
+}
+
+func (fake *FakeSpaceRepository) DeleteWithoutWaitingArgsForCall(i int) string {
+	fake.deleteWithoutWaitingMutex.RLock()
+	defer fake.deleteWithoutWaitingMutex.RUnlock()
+	return fake.deleteWithoutWaitingArgsForCall[i].spaceGUID
+}
+
+func (fake *FakeSpaceRepository) DeleteWithoutWaitingReturns(result1 string, result2 error) {
+	fake.DeleteWithoutWaitingStub = nil
+	fake.deleteWithoutWaitingReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 var _ spaces.SpaceRepository = new(FakeSpaceRepository)
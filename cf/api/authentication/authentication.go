@@ -1,7 +1,6 @@
 package authentication
 
 import (
-	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -27,7 +26,9 @@ type Repository interface {
 	net.RequestDumperInterface
 
 	RefreshAuthToken() (updatedToken string, apiErr error)
+	RefreshTokens(refreshToken string) (accessToken, newRefreshToken string, apiErr error)
 	Authenticate(credentials map[string]string) (apiErr error)
+	AuthenticateClientCredentials(clientID, clientSecret string) (apiErr error)
 	Authorize(token string) (string, error)
 	GetLoginPromptsAndSaveUAAServerURL() (map[string]coreconfig.AuthPrompt, error)
 }
@@ -49,18 +50,22 @@ func NewUAARepository(gateway net.Gateway, config coreconfig.ReadWriter, dumper
 }
 
 func (uaa UAARepository) Authorize(token string) (string, error) {
+	tlsConfig, err := net.NewTLSConfigFromConfig(uaa.config, nil)
+	if err != nil {
+		return "", err
+	}
+
 	httpClient := &http.Client{
 		CheckRedirect: func(req *http.Request, _ []*http.Request) error {
 			uaa.DumpRequest(req)
+			uaa.dumper.DumpFailure(req, ErrPreventRedirect)
 			return ErrPreventRedirect
 		},
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
-			DisableKeepAlives: true,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: uaa.config.IsSSLDisabled(),
-			},
-			Proxy:               http.ProxyFromEnvironment,
+			DisableKeepAlives:   true,
+			TLSClientConfig:     tlsConfig,
+			Proxy:               net.ProxyFromConfig(uaa.config),
 			TLSHandshakeTimeout: 10 * time.Second,
 		},
 	}
@@ -121,22 +126,43 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 
 	err := uaa.getAuthToken(data)
 	if err != nil {
-		httpError, ok := err.(errors.HTTPError)
-		if ok {
-			switch {
-			case httpError.StatusCode() == http.StatusUnauthorized:
-				return errors.New(T("Credentials were rejected, please try again."))
-			case httpError.StatusCode() >= http.StatusInternalServerError:
-				return errors.New(T("The targeted API endpoint could not be reached."))
-			}
-		}
+		return translateAuthError(err)
+	}
 
-		return err
+	return nil
+}
+
+func (uaa UAARepository) AuthenticateClientCredentials(clientID, clientSecret string) error {
+	data := url.Values{
+		"grant_type": {coreconfig.ClientCredentialsGrantType},
+	}
+
+	err := uaa.getAuthTokenForClient(data, clientID, clientSecret)
+	if err != nil {
+		return translateAuthError(err)
 	}
 
+	uaa.config.SetUAAOAuthClient(clientID)
+	uaa.config.SetUAAOAuthClientSecret(clientSecret)
+	uaa.config.SetUAAGrantType(coreconfig.ClientCredentialsGrantType)
+
 	return nil
 }
 
+func translateAuthError(err error) error {
+	httpError, ok := err.(errors.HTTPError)
+	if ok {
+		switch {
+		case httpError.StatusCode() == http.StatusUnauthorized:
+			return errors.New(T("Credentials were rejected, please try again."))
+		case httpError.StatusCode() >= http.StatusInternalServerError:
+			return errors.New(T("The targeted API endpoint could not be reached."))
+		}
+	}
+
+	return err
+}
+
 func (uaa UAARepository) DumpRequest(req *http.Request) {
 	uaa.dumper.DumpRequest(req)
 }
@@ -187,13 +213,59 @@ func (uaa UAARepository) RefreshAuthToken() (string, error) {
 		"scope":         {""},
 	}
 
+	// client_credentials grants are not issued refresh tokens, so a new token
+	// is requested with the stored client credentials instead
+	if uaa.config.UAAGrantType() == coreconfig.ClientCredentialsGrantType {
+		data = url.Values{
+			"grant_type": {coreconfig.ClientCredentialsGrantType},
+		}
+	}
+
 	apiErr := uaa.getAuthToken(data)
 	updatedToken := uaa.config.AccessToken()
 
 	return updatedToken, apiErr
 }
 
+// RefreshTokens exchanges refreshToken for new tokens with the default client
+// and returns them without changing the config
+func (uaa UAARepository) RefreshTokens(refreshToken string) (string, string, error) {
+	data := url.Values{
+		"refresh_token": {refreshToken},
+		"grant_type":    {"refresh_token"},
+		"scope":         {""},
+	}
+
+	accessToken, newRefreshToken, err := uaa.requestTokens(data, coreconfig.DefaultUAAOAuthClient, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	if newRefreshToken == "" {
+		newRefreshToken = refreshToken
+	}
+	return accessToken, newRefreshToken, nil
+}
+
 func (uaa UAARepository) getAuthToken(data url.Values) error {
+	return uaa.getAuthTokenForClient(data, uaa.config.UAAOAuthClient(), uaa.config.UAAOAuthClientSecret())
+}
+
+func (uaa UAARepository) getAuthTokenForClient(data url.Values, clientID, clientSecret string) error {
+	accessToken, refreshToken, err := uaa.requestTokens(data, clientID, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	uaa.config.SetAccessToken(accessToken)
+	uaa.config.SetRefreshToken(refreshToken)
+
+	return nil
+}
+
+// requestTokens requests tokens from UAA and returns the access token with
+// its type and the refresh token
+func (uaa UAARepository) requestTokens(data url.Values, clientID, clientSecret string) (string, string, error) {
 	type uaaErrorResponse struct {
 		Code        string `json:"error"`
 		Description string `json:"error_description"`
@@ -207,9 +279,9 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 	}
 
 	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthenticationEndpoint())
-	request, err := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte("cf:")), strings.NewReader(data.Encode()))
+	request, err := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte(clientID+":"+clientSecret)), strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
+		return "", "", fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
 	}
 	request.HTTPReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
@@ -219,20 +291,17 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 	switch err.(type) {
 	case nil:
 	case errors.HTTPError:
-		return err
+		return "", "", err
 	case *errors.InvalidTokenError:
-		return errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
+		return "", "", errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
 	default:
-		return fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
+		return "", "", fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
 	}
 
 	// TODO: get the actual status code
 	if response.Error.Code != "" {
-		return errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
+		return "", "", errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
 	}
 
-	uaa.config.SetAccessToken(fmt.Sprintf("%s %s", response.TokenType, response.AccessToken))
-	uaa.config.SetRefreshToken(response.RefreshToken)
-
-	return nil
+	return fmt.Sprintf("%s %s", response.TokenType, response.AccessToken), response.RefreshToken, nil
 }
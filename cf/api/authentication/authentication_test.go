@@ -136,6 +136,44 @@ var _ = Describe("AuthenticationRepository", func() {
 			})
 		})
 
+		Describe("authenticating with client credentials", func() {
+			var err error
+
+			JustBeforeEach(func() {
+				err = auth.AuthenticateClientCredentials("my-client", "my-secret")
+			})
+
+			Context("when the client credentials are accepted", func() {
+				BeforeEach(func() {
+					setupTestServer(successfulClientCredentialsRequest)
+				})
+
+				It("stores the access token and client credentials in the config", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+					Expect(config.UAAOAuthClient()).To(Equal("my-client"))
+					Expect(config.UAAOAuthClientSecret()).To(Equal("my-secret"))
+					Expect(config.UAAGrantType()).To(Equal("client_credentials"))
+				})
+			})
+
+			Context("when the client credentials are rejected", func() {
+				BeforeEach(func() {
+					setupTestServer(unsuccessfulLoginRequest)
+				})
+
+				It("returns an error and does not store the client credentials", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(Equal("Credentials were rejected, please try again."))
+					Expect(config.AccessToken()).To(BeEmpty())
+					Expect(config.UAAOAuthClient()).To(Equal("cf"))
+					Expect(config.UAAOAuthClientSecret()).To(BeEmpty())
+				})
+			})
+		})
+
 		Describe("getting login info", func() {
 			var (
 				apiErr  error
@@ -220,6 +258,50 @@ var _ = Describe("AuthenticationRepository", func() {
 					Expect(apiErr).NotTo(BeNil())
 				})
 			})
+
+			Context("when the session was authenticated with client credentials", func() {
+				BeforeEach(func() {
+					config.SetUAAOAuthClient("my-client")
+					config.SetUAAOAuthClientSecret("my-secret")
+					config.SetUAAGrantType("client_credentials")
+					setupTestServer(successfulClientCredentialsRequest)
+				})
+
+				It("requests a new token with the client credentials grant", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(apiErr).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+				})
+			})
+		})
+
+		Describe("refreshing tokens without the config", func() {
+			BeforeEach(func() {
+				config.SetAccessToken("bearer previous-access-token")
+				config.SetRefreshToken("previous-refresh-token")
+				setupTestServer(testnet.TestRequest{
+					Method: "POST",
+					Path:   "/oauth/token",
+					Header: authHeaders,
+					Matcher: func(request *http.Request) {
+						Expect(request.ParseForm()).To(Succeed())
+						Expect(request.Form.Get("grant_type")).To(Equal("refresh_token"))
+						Expect(request.Form.Get("refresh_token")).To(Equal("my-refresh-token"))
+					},
+					Response: successfulLoginRequest.Response,
+				})
+			})
+
+			It("returns the new tokens and leaves the config alone", func() {
+				accessToken, refreshToken, err := auth.RefreshTokens("my-refresh-token")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(handler).To(HaveAllRequestsCalled())
+				Expect(accessToken).To(Equal("BEARER my_access_token"))
+				Expect(refreshToken).To(Equal("my_refresh_token"))
+
+				Expect(config.AccessToken()).To(Equal("bearer previous-access-token"))
+				Expect(config.RefreshToken()).To(Equal("previous-refresh-token"))
+			})
 		})
 	})
 
@@ -360,6 +442,35 @@ var successfulLoginMatcher = func(request *http.Request) {
 	Expect(request.Form.Get("scope")).To(Equal(""))
 }
 
+var successfulClientCredentialsRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Header: http.Header{
+		"accept":        {"application/json"},
+		"content-type":  {"application/x-www-form-urlencoded"},
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("my-client:my-secret"))},
+	},
+	Matcher: func(request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			Fail(fmt.Sprintf("Failed to parse form: %s", err))
+			return
+		}
+
+		Expect(request.Form.Get("grant_type")).To(Equal("client_credentials"))
+		Expect(request.Form.Get("refresh_token")).To(BeEmpty())
+	},
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "access_token": "my_access_token",
+  "token_type": "BEARER",
+  "scope": "cloud_controller.admin",
+  "expires_in": 43199
+} `},
+}
+
 var unsuccessfulLoginRequest = testnet.TestRequest{
 	Method: "POST",
 	Path:   "/oauth/token",
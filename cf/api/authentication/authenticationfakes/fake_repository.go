@@ -27,6 +27,16 @@ type FakeRepository struct {
 		result1 string
 		result2 error
 	}
+	RefreshTokensStub        func(refreshToken string) (accessToken, newRefreshToken string, apiErr error)
+	refreshTokensMutex       sync.RWMutex
+	refreshTokensArgsForCall []struct {
+		refreshToken string
+	}
+	refreshTokensReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	AuthenticateStub        func(credentials map[string]string) (apiErr error)
 	authenticateMutex       sync.RWMutex
 	authenticateArgsForCall []struct {
@@ -35,6 +45,15 @@ type FakeRepository struct {
 	authenticateReturns struct {
 		result1 error
 	}
+	AuthenticateClientCredentialsStub        func(clientID, clientSecret string) (apiErr error)
+	authenticateClientCredentialsMutex       sync.RWMutex
+	authenticateClientCredentialsArgsForCall []struct {
+		clientID     string
+		clientSecret string
+	}
+	authenticateClientCredentialsReturns struct {
+		result1 error
+	}
 	AuthorizeStub        func(token string) (string, error)
 	authorizeMutex       sync.RWMutex
 	authorizeArgsForCall []struct {
@@ -124,6 +143,40 @@ func (fake *FakeRepository) RefreshAuthTokenReturns(result1 string, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) RefreshTokens(refreshToken string) (accessToken string, newRefreshToken string, apiErr error) {
+	fake.refreshTokensMutex.Lock()
+	fake.refreshTokensArgsForCall = append(fake.refreshTokensArgsForCall, struct {
+		refreshToken string
+	}{refreshToken})
+	fake.refreshTokensMutex.Unlock()
+	if fake.RefreshTokensStub != nil {
+		return fake.RefreshTokensStub(refreshToken)
+	} else {
+		return fake.refreshTokensReturns.result1, fake.refreshTokensReturns.result2, fake.refreshTokensReturns.result3
+	}
+}
+
+func (fake *FakeRepository) RefreshTokensCallCount() int {
+	fake.refreshTokensMutex.RLock()
+	defer fake.refreshTokensMutex.RUnlock()
+	return len(fake.refreshTokensArgsForCall)
+}
+
+func (fake *FakeRepository) RefreshTokensArgsForCall(i int) string {
+	fake.refreshTokensMutex.RLock()
+	defer fake.refreshTokensMutex.RUnlock()
+	return fake.refreshTokensArgsForCall[i].refreshToken
+}
+
+func (fake *FakeRepository) RefreshTokensReturns(result1 string, result2 string, result3 error) {
+	fake.RefreshTokensStub = nil
+	fake.refreshTokensReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeRepository) Authenticate(credentials map[string]string) (apiErr error) {
 	fake.authenticateMutex.Lock()
 	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
@@ -156,6 +209,39 @@ func (fake *FakeRepository) AuthenticateReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) AuthenticateClientCredentials(clientID string, clientSecret string) (apiErr error) {
+	fake.authenticateClientCredentialsMutex.Lock()
+	fake.authenticateClientCredentialsArgsForCall = append(fake.authenticateClientCredentialsArgsForCall, struct {
+		clientID     string
+		clientSecret string
+	}{clientID, clientSecret})
+	fake.authenticateClientCredentialsMutex.Unlock()
+	if fake.AuthenticateClientCredentialsStub != nil {
+		return fake.AuthenticateClientCredentialsStub(clientID, clientSecret)
+	} else {
+		return fake.authenticateClientCredentialsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsCallCount() int {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return len(fake.authenticateClientCredentialsArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsArgsForCall(i int) (string, string) {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return fake.authenticateClientCredentialsArgsForCall[i].clientID, fake.authenticateClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsReturns(result1 error) {
+	fake.AuthenticateClientCredentialsStub = nil
+	fake.authenticateClientCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Authorize(token string) (string, error) {
 	fake.authorizeMutex.Lock()
 	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
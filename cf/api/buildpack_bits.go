@@ -208,7 +208,7 @@ func (repo CloudControllerBuildpackBitsRepository) downloadBuildpack(url string,
 			Transport: &http.Transport{
 				Dial:            (&gonet.Dialer{Timeout: 5 * time.Second}).Dial,
 				TLSClientConfig: &tls.Config{RootCAs: certPool},
-				Proxy:           http.ProxyFromEnvironment,
+				Proxy:           net.ProxyFromConfig(repo.config),
 			},
 		}
 
@@ -33,13 +33,47 @@ func NewCloudControllerCurlRepository(config coreconfig.Reader, gateway net.Gate
 }
 
 func (repo CloudControllerCurlRepository) Request(method, path, headerString, body string) (resHeaders, resBody string, err error) {
-	url := fmt.Sprintf("%s/%s", repo.config.APIEndpoint(), strings.TrimLeft(path, "/"))
+	return curlRequest(repo.gateway, repo.config.APIEndpoint(), repo.config.AccessToken(), method, path, headerString, body)
+}
+
+type UAACurlRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewUAACurlRepository(config coreconfig.Reader, gateway net.Gateway) (repo UAACurlRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo UAACurlRepository) Request(method, path, headerString, body string) (resHeaders, resBody string, err error) {
+	return curlRequest(repo.gateway, repo.config.UaaEndpoint(), repo.config.AccessToken(), method, path, headerString, body)
+}
+
+type RoutingAPICurlRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewRoutingAPICurlRepository(config coreconfig.Reader, gateway net.Gateway) (repo RoutingAPICurlRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo RoutingAPICurlRepository) Request(method, path, headerString, body string) (resHeaders, resBody string, err error) {
+	return curlRequest(repo.gateway, repo.config.RoutingAPIEndpoint(), repo.config.AccessToken(), method, path, headerString, body)
+}
+
+func curlRequest(gateway net.Gateway, endpoint, accessToken, method, path, headerString, body string) (resHeaders, resBody string, err error) {
+	url := fmt.Sprintf("%s/%s", endpoint, strings.TrimLeft(path, "/"))
 
 	if method == "" && body != "" {
 		method = "POST"
 	}
 
-	req, err := repo.gateway.NewRequest(method, url, repo.config.AccessToken(), strings.NewReader(body))
+	req, err := gateway.NewRequest(method, url, accessToken, strings.NewReader(body))
 	if err != nil {
 		return
 	}
@@ -50,7 +84,7 @@ func (repo CloudControllerCurlRepository) Request(method, path, headerString, bo
 		return
 	}
 
-	res, err := repo.gateway.PerformRequest(req)
+	res, err := gateway.PerformRequest(req)
 
 	if _, ok := err.(errors.HTTPError); ok {
 		err = nil
@@ -189,6 +189,55 @@ var _ = Describe("CloudControllerCurlRepository ", func() {
 	})
 })
 
+var _ = Describe("UAACurlRepository", func() {
+	It("sends the request to the UAA endpoint with the access token", func() {
+		uaaServer := ghttp.NewServer()
+		defer uaaServer.Close()
+		uaaServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/Users"),
+				ghttp.VerifyHeaderKV("Authorization", "BEARER my_access_token"),
+				ghttp.RespondWith(http.StatusOK, `{"resources":[]}`),
+			),
+		)
+
+		deps := newCurlDependencies()
+		deps.config.SetUaaEndpoint(uaaServer.URL())
+
+		repo := NewUAACurlRepository(deps.config, deps.gateway)
+		headers, body, err := repo.Request("GET", "/Users", "", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		Expect(headers).To(ContainSubstring("200"))
+		Expect(body).To(Equal(`{"resources":[]}`))
+	})
+})
+
+var _ = Describe("RoutingAPICurlRepository", func() {
+	It("sends the request to the routing API endpoint with the access token", func() {
+		routingAPIServer := ghttp.NewServer()
+		defer routingAPIServer.Close()
+		routingAPIServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/router_groups"),
+				ghttp.VerifyHeaderKV("Authorization", "BEARER my_access_token"),
+				ghttp.RespondWith(http.StatusOK, `[]`),
+			),
+		)
+
+		deps := newCurlDependencies()
+		deps.config.SetRoutingAPIEndpoint(routingAPIServer.URL())
+
+		repo := NewRoutingAPICurlRepository(deps.config, deps.gateway)
+		_, body, err := repo.Request("GET", "/v1/router_groups", "", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(routingAPIServer.ReceivedRequests()).To(HaveLen(1))
+		Expect(body).To(Equal(`[]`))
+	})
+})
+
 const expectedJSONResponse = `
 	{"resources": [
 		{
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/api/resources"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+)
+
+//go:generate counterfeiter . JobRepository
+
+type JobRepository interface {
+	Get(guid string) (models.Job, error)
+	Wait(guid string) error
+}
+
+type CloudControllerJobRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerJobRepository(config coreconfig.Reader, gateway net.Gateway) (repo CloudControllerJobRepository) {
+	repo.config = config
+	repo.gateway = gateway
+	return
+}
+
+func (repo CloudControllerJobRepository) Get(guid string) (models.Job, error) {
+	resource := resources.JobResource{}
+	err := repo.gateway.GetResource(repo.jobURL(guid), &resource)
+	if err != nil {
+		return models.Job{}, err
+	}
+
+	return resource.ToFields(), nil
+}
+
+func (repo CloudControllerJobRepository) Wait(guid string) error {
+	return repo.gateway.WaitForJob(repo.jobURL(guid))
+}
+
+func (repo CloudControllerJobRepository) jobURL(guid string) string {
+	return fmt.Sprintf("%s/v2/jobs/%s", repo.config.APIEndpoint(), guid)
+}
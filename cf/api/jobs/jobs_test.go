@@ -0,0 +1,102 @@
+package jobs_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/cloudfoundry/cli/cf/api/jobs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobRepository", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       JobRepository
+	)
+
+	jobResponse := func(status string) string {
+		return `{
+			"metadata": {
+				"guid": "the-job-guid",
+				"created_at": "2016-06-08T16:41:22Z",
+				"url": "/v2/jobs/the-job-guid"
+			},
+			"entity": {
+				"guid": "the-job-guid",
+				"status": "` + status + `",
+				"error_details": { "description": "he's dead, Jim" }
+			}
+		}`
+	}
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+
+		gateway := cloudcontrollergateway.NewTestCloudControllerGateway(configRepo)
+		gateway.PollingThrottle = time.Millisecond
+		repo = NewCloudControllerJobRepository(configRepo, gateway)
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+	})
+
+	Describe("Get", func() {
+		It("returns the job", func() {
+			testServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v2/jobs/the-job-guid"),
+				ghttp.RespondWith(http.StatusOK, jobResponse("failed")),
+			))
+
+			job, err := repo.Get("the-job-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(job).To(Equal(models.Job{
+				GUID:      "the-job-guid",
+				Status:    "failed",
+				CreatedAt: "2016-06-08T16:41:22Z",
+				Error:     "he's dead, Jim",
+			}))
+		})
+
+		It("returns an error when the job cannot be found", func() {
+			testServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"code":10000,"description":"Unknown request","error_code":"CF-NotFound"}`))
+
+			_, err := repo.Get("the-job-guid")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Wait", func() {
+		It("polls the job until it finishes", func() {
+			testServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, jobResponse("queued")),
+				ghttp.RespondWith(http.StatusOK, jobResponse("running")),
+				ghttp.RespondWith(http.StatusOK, jobResponse("finished")),
+			)
+
+			Expect(repo.Wait("the-job-guid")).To(Succeed())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("returns the error of a failed job", func() {
+			testServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, jobResponse("failed")))
+
+			err := repo.Wait("the-job-guid")
+			Expect(err).To(MatchError("he's dead, Jim"))
+		})
+	})
+})
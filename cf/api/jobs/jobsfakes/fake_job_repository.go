@@ -0,0 +1,96 @@
+// This file was generated by counterfeiter
+package jobsfakes
+
+import (
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/api/jobs"
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+type FakeJobRepository struct {
+	GetStub        func(guid string) (models.Job, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		guid string
+	}
+	getReturns struct {
+		result1 models.Job
+		result2 error
+	}
+	WaitStub        func(guid string) error
+	waitMutex       sync.RWMutex
+	waitArgsForCall []struct {
+		guid string
+	}
+	waitReturns struct {
+		result1 error
+	}
+}
+
+func (fake *FakeJobRepository) Get(guid string) (models.Job, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(guid)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeJobRepository) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeJobRepository) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].guid
+}
+
+func (fake *FakeJobRepository) GetReturns(result1 models.Job, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 models.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJobRepository) Wait(guid string) error {
+	fake.waitMutex.Lock()
+	fake.waitArgsForCall = append(fake.waitArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.waitMutex.Unlock()
+	if fake.WaitStub != nil {
+		return fake.WaitStub(guid)
+	} else {
+		return fake.waitReturns.result1
+	}
+}
+
+func (fake *FakeJobRepository) WaitCallCount() int {
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
+	return len(fake.waitArgsForCall)
+}
+
+func (fake *FakeJobRepository) WaitArgsForCall(i int) string {
+	fake.waitMutex.RLock()
+	defer fake.waitMutex.RUnlock()
+	return fake.waitArgsForCall[i].guid
+}
+
+func (fake *FakeJobRepository) WaitReturns(result1 error) {
+	fake.WaitStub = nil
+	fake.waitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+var _ jobs.JobRepository = new(FakeJobRepository)
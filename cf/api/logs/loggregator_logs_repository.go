@@ -8,6 +8,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/api/authentication"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/trace"
 	consumer "github.com/cloudfoundry/loggregator_consumer"
 	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	noaa_errors "github.com/cloudfoundry/noaa/errors"
@@ -46,7 +47,13 @@ func loggableMessagesFromLoggregatorMessages(messages []*logmessage.LogMessage)
 }
 
 func (repo *LoggregatorLogsRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	stopTimer := trace.TimeCall(logsEndpointName("GET", repo.config.LoggregatorEndpoint(), "/recent"))
 	messages, err := repo.consumer.Recent(appGUID, repo.config.AccessToken())
+	var size int64
+	for _, message := range messages {
+		size += int64(len(message.GetMessage()))
+	}
+	stopTimer(size)
 
 	switch err.(type) {
 	case nil: // do nothing
@@ -70,7 +77,7 @@ func (repo *LoggregatorLogsRepository) TailLogsFor(appGUID string, onConnect fun
 		return
 	}
 
-	repo.consumer.SetOnConnectCallback(onConnect)
+	repo.consumer.SetOnConnectCallback(timedOnConnect(logsEndpointName("GET", endpoint, "/tail"), onConnect))
 	c, err := repo.consumer.Tail(appGUID, repo.config.AccessToken())
 
 	switch err.(type) {
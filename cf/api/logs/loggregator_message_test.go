@@ -43,4 +43,17 @@ var _ = Describe("loggregatorMessage", func() {
 			Expect(terminal.Decolorize(msg.ToLog(time.FixedZone("the-zone", 3*60*60)))).To(Equal("2014-04-04T14:39:20.00+0300 [DEA/4]      ERR Hello World!"))
 		})
 	})
+
+	Describe("structured fields", func() {
+		It("returns the source, message type and time of the message", func() {
+			date := time.Date(2014, 4, 4, 11, 39, 20, 5, time.UTC)
+			msg := testlogs.NewLogMessage("Hello World!\n", "", "DEA", "4", logmessage.LogMessage_ERR, date)
+
+			Expect(msg.GetSourceName()).To(Equal("DEA"))
+			Expect(msg.GetSourceInstance()).To(Equal("4"))
+			Expect(msg.GetMessageType()).To(Equal("ERR"))
+			Expect(msg.GetTimestamp().Equal(date)).To(BeTrue())
+			Expect(msg.ToSimpleLog()).To(Equal("Hello World!"))
+		})
+	})
 })
@@ -1,11 +1,20 @@
 package logs
 
-import "time"
+import (
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/trace"
+)
 
 type Loggable interface {
 	ToLog(loc *time.Location) string
 	ToSimpleLog() string
 	GetSourceName() string
+	GetSourceInstance() string
+	GetMessageType() string
+	GetTimestamp() time.Time
 }
 
 //go:generate counterfeiter . Repository
@@ -18,6 +27,33 @@ type Repository interface {
 
 const defaultBufferTime time.Duration = 25 * time.Millisecond
 
+// logsEndpointName names a call to the log server at endpoint for the
+// `cf --timings` summary
+func logsEndpointName(method, endpoint, path string) string {
+	host := endpoint
+	if endpointURL, err := url.Parse(endpoint); err == nil && endpointURL.Host != "" {
+		host = endpointURL.Host
+	}
+	return method + " " + host + path
+}
+
+// timedOnConnect wraps onConnect to record how long connecting to the log
+// stream took, when timings are being collected
+func timedOnConnect(endpoint string, onConnect func()) func() {
+	if !trace.TimingsEnabled() {
+		return onConnect
+	}
+
+	stopTimer := trace.TimeCall(endpoint)
+	var once sync.Once
+	return func() {
+		once.Do(func() { stopTimer(0) })
+		if onConnect != nil {
+			onConnect()
+		}
+	}
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
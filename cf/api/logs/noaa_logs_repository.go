@@ -8,6 +8,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/api/authentication"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/trace"
 
 	"github.com/cloudfoundry/noaa"
 	noaa_errors "github.com/cloudfoundry/noaa/errors"
@@ -47,7 +48,13 @@ func loggableMessagesFromNoaaMessages(messages []*events.LogMessage) []Loggable
 }
 
 func (repo *NoaaLogsRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	stopTimer := trace.TimeCall(logsEndpointName("GET", repo.config.DopplerEndpoint(), "/apps/:guid/recentlogs"))
 	logs, err := repo.consumer.RecentLogs(appGUID, repo.config.AccessToken())
+	var size int64
+	for _, log := range logs {
+		size += int64(len(log.GetMessage()))
+	}
+	stopTimer(size)
 
 	switch err.(type) {
 	case nil: // do nothing
@@ -69,7 +76,7 @@ func (repo *NoaaLogsRepository) TailLogsFor(appGUID string, onConnect func(), lo
 		return
 	}
 
-	repo.consumer.SetOnConnectCallback(onConnect)
+	repo.consumer.SetOnConnectCallback(timedOnConnect(logsEndpointName("GET", endpoint, "/apps/:guid/stream"), onConnect))
 	c, e := repo.consumer.TailingLogs(appGUID, repo.config.AccessToken())
 
 	go func() {
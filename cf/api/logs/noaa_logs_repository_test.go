@@ -1,6 +1,7 @@
 package logs_test
 
 import (
+	"bytes"
 	"errors"
 	"reflect"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/api/authentication/authenticationfakes"
 	testapi "github.com/cloudfoundry/cli/cf/api/logs/logsfakes"
+	"github.com/cloudfoundry/cli/cf/trace"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
 
 	"sync"
@@ -99,6 +101,27 @@ var _ = Describe("logs with noaa repository", func() {
 					logs.NewNoaaLogMessage(msg3),
 				}))
 			})
+
+			Context("when timings are being collected", func() {
+				var profile *trace.Profile
+
+				BeforeEach(func() {
+					profile = trace.NewProfile()
+					trace.SetProfile(profile)
+				})
+
+				AfterEach(func() {
+					trace.SetProfile(nil)
+				})
+
+				It("records the call and the size of the messages", func() {
+					repo.RecentLogsFor("app-guid")
+
+					summary := new(bytes.Buffer)
+					profile.WriteSummary(summary)
+					Expect(summary.String()).To(MatchRegexp(`GET doppler\.test\.com/apps/:guid/recentlogs\s+1\s+.*\s+27\n`))
+				})
+			})
 		})
 	})
 
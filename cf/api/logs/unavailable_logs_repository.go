@@ -0,0 +1,22 @@
+package logs
+
+// UnavailableLogsRepository is used when no connection to the log server can
+// be configured, e.g. because the CA bundle or client certificate of the
+// config cannot be loaded. Every call returns the error.
+type UnavailableLogsRepository struct {
+	err error
+}
+
+func NewUnavailableLogsRepository(err error) *UnavailableLogsRepository {
+	return &UnavailableLogsRepository{err: err}
+}
+
+func (repo *UnavailableLogsRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	return nil, repo.err
+}
+
+func (repo *UnavailableLogsRepository) TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	errChan <- repo.err
+}
+
+func (repo *UnavailableLogsRepository) Close() {}
@@ -0,0 +1,28 @@
+package logs_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UnavailableLogsRepository", func() {
+	var repo *logs.UnavailableLogsRepository
+
+	BeforeEach(func() {
+		repo = logs.NewUnavailableLogsRepository(errors.New("open /path/to/ca.pem: permission denied"))
+	})
+
+	It("returns the error for recent logs", func() {
+		_, err := repo.RecentLogsFor("app-guid")
+		Expect(err).To(MatchError("open /path/to/ca.pem: permission denied"))
+	})
+
+	It("sends the error when tailing logs", func() {
+		errChan := make(chan error, 1)
+		repo.TailLogsFor("app-guid", func() {}, make(chan logs.Loggable), errChan)
+		Expect(errChan).To(Receive(MatchError("open /path/to/ca.pem: permission denied")))
+	})
+})
@@ -21,6 +21,7 @@ type OrganizationRepository interface {
 	Create(org models.Organization) (apiErr error)
 	Rename(orgGUID string, name string) (apiErr error)
 	Delete(orgGUID string) (apiErr error)
+	DeleteWithoutWaiting(orgGUID string) (jobURL string, apiErr error)
 	SharePrivateDomain(orgGUID string, domainGUID string) (apiErr error)
 	UnsharePrivateDomain(orgGUID string, domainGUID string) (apiErr error)
 }
@@ -105,6 +106,11 @@ func (repo CloudControllerOrganizationRepository) Delete(orgGUID string) (apiErr
 	return repo.gateway.DeleteResource(repo.config.APIEndpoint(), url)
 }
 
+func (repo CloudControllerOrganizationRepository) DeleteWithoutWaiting(orgGUID string) (string, error) {
+	url := fmt.Sprintf("/v2/organizations/%s?recursive=true", orgGUID)
+	return repo.gateway.DeleteResourceWithoutWaiting(repo.config.APIEndpoint(), url)
+}
+
 func (repo CloudControllerOrganizationRepository) SharePrivateDomain(orgGUID string, domainGUID string) error {
 	url := fmt.Sprintf("/v2/organizations/%s/private_domains/%s", orgGUID, domainGUID)
 	return repo.gateway.UpdateResource(repo.config.APIEndpoint(), url, nil)
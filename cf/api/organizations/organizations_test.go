@@ -350,6 +350,25 @@ var _ = Describe("Organization Repository", func() {
 			Expect(handler).To(HaveAllRequestsCalled())
 			Expect(apiErr).NotTo(HaveOccurred())
 		})
+
+		It("returns the deletion job without waiting for it", func() {
+			req := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "DELETE",
+				Path:   "/v2/organizations/my-org-guid?async=true&recursive=true",
+				Response: testnet.TestResponse{
+					Status: http.StatusAccepted,
+					Body:   `{"metadata":{"guid":"my-job-guid","url":"/v2/jobs/my-job-guid"},"entity":{"status":"queued"}}`,
+				},
+			})
+
+			testserver, handler, repo := createOrganizationRepo(req)
+			defer testserver.Close()
+
+			jobURL, apiErr := repo.DeleteWithoutWaiting("my-org-guid")
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(jobURL).To(Equal("/v2/jobs/my-job-guid"))
+		})
 	})
 
 	Describe("SharePrivateDomain", func() {
@@ -44,7 +44,7 @@ type FakeOrganizationRepository struct {
 	createReturns struct {
 		result1 error
 	}
-	RenameStub        func(orgGUID string, name string) (apiErr error)
+	RenameStub        func(orgGUID, name string) (apiErr error)
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
 		orgGUID string
@@ -61,7 +61,16 @@ type FakeOrganizationRepository struct {
 	deleteReturns struct {
 		result1 error
 	}
-	SharePrivateDomainStub        func(orgGUID string, domainGUID string) (apiErr error)
+	DeleteWithoutWaitingStub        func(orgGUID string) (jobURL string, apiErr error)
+	deleteWithoutWaitingMutex       sync.RWMutex
+	deleteWithoutWaitingArgsForCall []struct {
+		orgGUID string
+	}
+	deleteWithoutWaitingReturns struct {
+		result1 string
+		result2 error
+	}
+	SharePrivateDomainStub        func(orgGUID, domainGUID string) (apiErr error)
 	sharePrivateDomainMutex       sync.RWMutex
 	sharePrivateDomainArgsForCall []struct {
 		orgGUID    string
@@ -70,7 +79,7 @@ type FakeOrganizationRepository struct {
 	sharePrivateDomainReturns struct {
 		result1 error
 	}
-	UnsharePrivateDomainStub        func(orgGUID string, domainGUID string) (apiErr error)
+	UnsharePrivateDomainStub        func(orgGUID, domainGUID string) (apiErr error)
 	unsharePrivateDomainMutex       sync.RWMutex
 	unsharePrivateDomainArgsForCall []struct {
 		orgGUID    string
@@ -282,6 +291,39 @@ func (fake *FakeOrganizationRepository) DeleteReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeOrganizationRepository) DeleteWithoutWaiting(orgGUID string) (jobURL string, apiErr error) {
+	fake.deleteWithoutWaitingMutex.Lock()
+	fake.deleteWithoutWaitingArgsForCall = append(fake.deleteWithoutWaitingArgsForCall, struct {
+		orgGUID string
+	}{orgGUID})
+	fake.deleteWithoutWaitingMutex.Unlock()
+	if fake.DeleteWithoutWaitingStub != nil {
+		return fake.DeleteWithoutWaitingStub(orgGUID)
+	} else {
+		return fake.deleteWithoutWaitingReturns.result1, fake.deleteWithoutWaitingReturns.result2
+	}
+}
+
+func (fake *FakeOrganizationRepository) DeleteWithoutWaitingCallCount() int {
+	fake.deleteWithoutWaitingMutex.RLock()
+	defer fake.deleteWithoutWaitingMutex.RUnlock()
+	return len(fake.deleteWithoutWaitingArgsForCall)
+}
+
+func (fake *FakeOrganizationRepository) DeleteWithoutWaitingArgsForCall(i int) string {
+	fake.deleteWithoutWaitingMutex.RLock()
+	defer fake.deleteWithoutWaitingMutex.RUnlock()
+	return fake.deleteWithoutWaitingArgsForCall[i].orgGUID
+}
+
+func (fake *FakeOrganizationRepository) DeleteWithoutWaitingReturns(result1 string, result2 error) {
+	fake.DeleteWithoutWaitingStub = nil
+	fake.deleteWithoutWaitingReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeOrganizationRepository) SharePrivateDomain(orgGUID string, domainGUID string) (apiErr error) {
 	fake.sharePrivateDomainMutex.Lock()
 	fake.sharePrivateDomainArgsForCall = append(fake.sharePrivateDomainArgsForCall, struct {
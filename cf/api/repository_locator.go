@@ -2,7 +2,6 @@ package api
 
 import (
 	"crypto/tls"
-	"net/http"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf"
@@ -15,6 +14,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/api/copyapplicationsource"
 	"github.com/cloudfoundry/cli/cf/api/environmentvariablegroups"
 	"github.com/cloudfoundry/cli/cf/api/featureflags"
+	"github.com/cloudfoundry/cli/cf/api/jobs"
 	"github.com/cloudfoundry/cli/cf/api/logs"
 	"github.com/cloudfoundry/cli/cf/api/organizations"
 	"github.com/cloudfoundry/cli/cf/api/password"
@@ -41,6 +41,8 @@ import (
 type RepositoryLocator struct {
 	authRepo                        authentication.Repository
 	curlRepo                        CurlRepository
+	uaaCurlRepo                     CurlRepository
+	routingAPICurlRepo              CurlRepository
 	endpointRepo                    coreconfig.EndpointRepository
 	organizationRepo                organizations.OrganizationRepository
 	quotaRepo                       quotas.QuotaRepository
@@ -55,6 +57,7 @@ type RepositoryLocator struct {
 	routeRepo                       RouteRepository
 	routingAPIRepo                  RoutingAPIRepository
 	stackRepo                       stacks.StackRepository
+	jobRepo                         jobs.JobRepository
 	serviceRepo                     ServiceRepository
 	serviceKeyRepo                  ServiceKeyRepository
 	serviceBindingRepo              ServiceBindingRepository
@@ -102,21 +105,27 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.appInstancesRepo = appinstances.NewCloudControllerAppInstancesRepository(config, cloudControllerGateway)
 	loc.authTokenRepo = NewCloudControllerServiceAuthTokenRepository(config, cloudControllerGateway)
 	loc.curlRepo = NewCloudControllerCurlRepository(config, cloudControllerGateway)
+	loc.uaaCurlRepo = NewUAACurlRepository(config, uaaGateway)
+	loc.routingAPICurlRepo = NewRoutingAPICurlRepository(config, routingAPIGateway)
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway, strategy)
 	loc.endpointRepo = NewEndpointRepository(cloudControllerGateway)
 
-	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
-
 	apiVersion, _ := semver.Make(config.APIVersion())
 
-	if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
-		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+	// the gateways report the same error on their first request
+	tlsConfig, err := net.NewTLSConfigFromConfig(config, []tls.Certificate{})
+	if err != nil {
+		loc.logsRepo = logs.NewUnavailableLogsRepository(err)
+	} else if apiVersion.GTE(cf.NoaaMinimumAPIVersion) {
+		websocketProxy := net.NewWebsocketProxy(config, config.DopplerEndpoint())
+		consumer := consumer.New(config.DopplerEndpoint(), tlsConfig, websocketProxy.Proxy)
 		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewNoaaLogsRepository(config, consumer, loc.authRepo)
+		loc.logsRepo = logs.NewNoaaLogsRepository(config, noaaConsumer{consumer, websocketProxy}, loc.authRepo)
 	} else {
-		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, http.ProxyFromEnvironment)
+		websocketProxy := net.NewWebsocketProxy(config, config.LoggregatorEndpoint())
+		consumer := loggregator_consumer.New(config.LoggregatorEndpoint(), tlsConfig, websocketProxy.Proxy)
 		consumer.SetDebugPrinter(terminal.DebugPrinter{Logger: logger})
-		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, consumer, loc.authRepo)
+		loc.logsRepo = logs.NewLoggregatorLogsRepository(config, loggregatorConsumer{consumer, websocketProxy}, loc.authRepo)
 	}
 
 	loc.organizationRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudControllerGateway)
@@ -126,6 +135,7 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.routeServiceBindingRepo = NewCloudControllerRouteServiceBindingRepository(config, cloudControllerGateway)
 	loc.routingAPIRepo = NewRoutingAPIRepository(config, routingAPIGateway)
 	loc.stackRepo = stacks.NewCloudControllerStackRepository(config, cloudControllerGateway)
+	loc.jobRepo = jobs.NewCloudControllerJobRepository(config, cloudControllerGateway)
 	loc.serviceRepo = NewCloudControllerServiceRepository(config, cloudControllerGateway)
 	loc.serviceKeyRepo = NewCloudControllerServiceKeyRepository(config, cloudControllerGateway)
 	loc.serviceBindingRepo = NewCloudControllerServiceBindingRepository(config, cloudControllerGateway)
@@ -171,6 +181,24 @@ func (locator RepositoryLocator) GetCurlRepository() CurlRepository {
 	return locator.curlRepo
 }
 
+func (locator RepositoryLocator) SetUAACurlRepository(repo CurlRepository) RepositoryLocator {
+	locator.uaaCurlRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetUAACurlRepository() CurlRepository {
+	return locator.uaaCurlRepo
+}
+
+func (locator RepositoryLocator) SetRoutingAPICurlRepository(repo CurlRepository) RepositoryLocator {
+	locator.routingAPICurlRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetRoutingAPICurlRepository() CurlRepository {
+	return locator.routingAPICurlRepo
+}
+
 func (locator RepositoryLocator) GetEndpointRepository() coreconfig.EndpointRepository {
 	return locator.endpointRepo
 }
@@ -297,6 +325,15 @@ func (locator RepositoryLocator) GetStackRepository() stacks.StackRepository {
 	return locator.stackRepo
 }
 
+func (locator RepositoryLocator) SetJobRepository(repo jobs.JobRepository) RepositoryLocator {
+	locator.jobRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetJobRepository() jobs.JobRepository {
+	return locator.jobRepo
+}
+
 func (locator RepositoryLocator) SetServiceRepository(repo ServiceRepository) RepositoryLocator {
 	locator.serviceRepo = repo
 	return locator
@@ -496,3 +533,25 @@ func (locator RepositoryLocator) SetV3Repository(r repository.Repository) Reposi
 	locator.v3Repository = r
 	return locator
 }
+
+// noaaConsumer and loggregatorConsumer stop the proxy relay of a consumer
+// when it is closed
+type noaaConsumer struct {
+	*consumer.Consumer
+	proxy *net.WebsocketProxy
+}
+
+func (c noaaConsumer) Close() error {
+	defer c.proxy.Close()
+	return c.Consumer.Close()
+}
+
+type loggregatorConsumer struct {
+	loggregator_consumer.LoggregatorConsumer
+	proxy *net.WebsocketProxy
+}
+
+func (c loggregatorConsumer) Close() error {
+	defer c.proxy.Close()
+	return c.LoggregatorConsumer.Close()
+}
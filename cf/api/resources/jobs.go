@@ -0,0 +1,29 @@
+package resources
+
+import "github.com/cloudfoundry/cli/cf/models"
+
+type JobResource struct {
+	Metadata JobMetadata
+	Entity   JobEntity
+}
+
+type JobMetadata struct {
+	GUID      string `json:"guid"`
+	CreatedAt string `json:"created_at"`
+}
+
+type JobEntity struct {
+	Status       string
+	ErrorDetails struct {
+		Description string
+	} `json:"error_details"`
+}
+
+func (resource JobResource) ToFields() models.Job {
+	return models.Job{
+		GUID:      resource.Metadata.GUID,
+		Status:    resource.Entity.Status,
+		CreatedAt: resource.Metadata.CreatedAt,
+		Error:     resource.Entity.ErrorDetails.Description,
+	}
+}
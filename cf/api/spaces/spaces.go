@@ -23,6 +23,7 @@ type SpaceRepository interface {
 	Rename(spaceGUID, newName string) (apiErr error)
 	SetAllowSSH(spaceGUID string, allow bool) (apiErr error)
 	Delete(spaceGUID string) (apiErr error)
+	DeleteWithoutWaiting(spaceGUID string) (jobURL string, apiErr error)
 }
 
 type CloudControllerSpaceRepository struct {
@@ -108,3 +109,8 @@ func (repo CloudControllerSpaceRepository) Delete(spaceGUID string) (apiErr erro
 	path := fmt.Sprintf("/v2/spaces/%s?recursive=true", spaceGUID)
 	return repo.gateway.DeleteResource(repo.config.APIEndpoint(), path)
 }
+
+func (repo CloudControllerSpaceRepository) DeleteWithoutWaiting(spaceGUID string) (string, error) {
+	path := fmt.Sprintf("/v2/spaces/%s?recursive=true", spaceGUID)
+	return repo.gateway.DeleteResourceWithoutWaiting(repo.config.APIEndpoint(), path)
+}
@@ -9,7 +9,9 @@ import (
 	"path/filepath"
 	"runtime"
 
+	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/gofileutils/fileutils"
 )
 
@@ -27,6 +29,8 @@ type AppFiles interface {
 type ApplicationFiles struct{}
 
 func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
+	defer trace.TimeOperation(T("hash app files"))()
+
 	appFiles := []models.AppFileFields{}
 
 	fullDirPath, toplevelErr := filepath.Abs(dir)
@@ -10,6 +10,8 @@ import (
 	"runtime"
 
 	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/gofileutils/fileutils"
 )
 
@@ -25,6 +27,8 @@ type Zipper interface {
 type ApplicationZipper struct{}
 
 func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File) error {
+	defer trace.TimeOperation(T("zip app files"))()
+
 	if zipper.IsZipFile(dirOrZipFilePath) {
 		zipFile, err := os.Open(dirOrZipFilePath)
 		if err != nil {
@@ -24,4 +24,5 @@ type CommandMetadata struct {
 	TotalArgs       int //Optional: number of required arguments to skip for flag verification
 	Hidden          bool
 	Examples        []string
+	SensitiveArgs   []int //Optional: positions, counting from 0, of the arguments that hold passwords, tokens or credentials
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf/actors"
@@ -45,7 +46,7 @@ type Dependency struct {
 	AppZipper          appfiles.Zipper
 	AppFiles           appfiles.AppFiles
 	PushActor          actors.PushActor
-	ChecksumUtil       utils.Sha1Checksum
+	ChecksumUtil       utils.Checksum
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
 }
@@ -88,8 +89,11 @@ func NewDependency(writer io.Writer, logger trace.Printer) Dependency {
 	terminal.UserAskedForColors = deps.Config.ColorEnabled()
 	terminal.InitColorSupport()
 
+	ccGateway := net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger)
+	ccGateway.ResponseCache = net.NewResponseCache(filepath.Join(filepath.Dir(configPath), "cache"), deps.Config)
+
 	deps.Gateways = map[string]net.Gateway{
-		"cloud-controller": net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger),
+		"cloud-controller": ccGateway,
 		"uaa":              net.NewUAAGateway(deps.Config, deps.UI, logger),
 		"routing-api":      net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger),
 	}
@@ -113,7 +117,7 @@ func NewDependency(writer io.Writer, logger trace.Printer) Dependency {
 		deps.ServiceBuilder,
 	)
 
-	deps.PluginRepo = pluginrepo.NewPluginRepo()
+	deps.PluginRepo = pluginrepo.NewPluginRepo(net.ProxyFromConfig(deps.Config))
 
 	deps.ServiceHandler = actors.NewServiceHandler(
 		deps.RepoLocator.GetOrganizationRepository(),
@@ -136,7 +140,7 @@ func NewDependency(writer io.Writer, logger trace.Printer) Dependency {
 
 	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles)
 
-	deps.ChecksumUtil = utils.NewSha1Checksum("")
+	deps.ChecksumUtil = utils.NewChecksum("")
 
 	deps.Logger = logger
 
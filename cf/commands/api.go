@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -9,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -28,12 +30,15 @@ func (cmd API) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["unset"] = &flags.BoolFlag{Name: "unset", Usage: T("Remove all api endpoint targeting")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
+	fs["ca-cert"] = &flags.StringFlag{Name: "ca-cert", Usage: T("Path to a PEM file of CA certificates to trust for the API endpoint")}
+	fs["client-cert"] = &flags.StringFlag{Name: "client-cert", Usage: T("Path to a PEM client certificate for endpoints that require mutual TLS")}
+	fs["client-key"] = &flags.StringFlag{Name: "client-key", Usage: T("Path to the PEM private key of the client certificate")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "api",
 		Description: T("Set or view target api url"),
 		Usage: []string{
-			T("CF_NAME api [URL]"),
+			T("CF_NAME api [URL] [--ca-cert CA_CERT_FILE] [--client-cert CERT_FILE --client-key KEY_FILE]"),
 		},
 		Flags: fs,
 	}
@@ -73,7 +78,11 @@ func (cmd API) Execute(c flags.FlagContext) error {
 
 		cmd.ui.Say(T("Setting api endpoint to {{.Endpoint}}...",
 			map[string]interface{}{"Endpoint": terminal.EntityNameColor(endpoint)}))
-		err := cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
+		err := cmd.setTLSFiles(c.String("ca-cert"), c.String("client-cert"), c.String("client-key"))
+		if err != nil {
+			return err
+		}
+		err = cmd.setAPIEndpoint(endpoint, c.Bool("skip-ssl-validation"), cmd.MetaData().Name)
 		if err != nil {
 			return err
 		}
@@ -102,6 +111,9 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	if err != nil {
 		cmd.config.SetAPIEndpoint("")
 		cmd.config.SetSSLDisabled(false)
+		cmd.config.SetCACertFile("")
+		cmd.config.SetClientCertFile("")
+		cmd.config.SetClientKeyFile("")
 
 		switch typedErr := err.(type) {
 		case *errors.InvalidSSLCert:
@@ -120,3 +132,47 @@ func (cmd API) setAPIEndpoint(endpoint string, skipSSL bool, cmdName string) err
 	}
 	return nil
 }
+
+// setTLSFiles checks that the CA bundle and client certificate can be loaded
+// and stores their absolute paths for connections to the endpoint
+func (cmd API) setTLSFiles(caCertFile, clientCertFile, clientKeyFile string) error {
+	var err error
+
+	if (clientCertFile == "") != (clientKeyFile == "") {
+		return errors.New(T("--client-cert and --client-key must be used together"))
+	}
+
+	if caCertFile != "" {
+		caCertFile, err = filepath.Abs(caCertFile)
+		if err != nil {
+			return err
+		}
+
+		_, err = net.LoadCACertPool(caCertFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	if clientCertFile != "" {
+		clientCertFile, err = filepath.Abs(clientCertFile)
+		if err != nil {
+			return err
+		}
+
+		clientKeyFile, err = filepath.Abs(clientKeyFile)
+		if err != nil {
+			return err
+		}
+
+		_, err = net.LoadClientCertificate(clientCertFile, clientKeyFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.config.SetCACertFile(caCertFile)
+	cmd.config.SetClientCertFile(clientCertFile)
+	cmd.config.SetClientKeyFile(clientKeyFile)
+	return nil
+}
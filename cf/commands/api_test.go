@@ -2,6 +2,9 @@ package commands_test
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
@@ -9,6 +12,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/errors"
 	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -213,6 +217,78 @@ var _ = Describe("Api", func() {
 			})
 		})
 
+		Context("when the user provides certificate files", func() {
+			var (
+				tmpDir         string
+				caCertFile     string
+				clientCertFile string
+				clientKeyFile  string
+			)
+
+			BeforeEach(func() {
+				var err error
+				tmpDir, err = ioutil.TempDir("", "api-certs")
+				Expect(err).NotTo(HaveOccurred())
+
+				caCertFile, _ = testnet.WriteTLSCertFiles(testnet.MakeValidTLSCert(), tmpDir)
+
+				clientDir := filepath.Join(tmpDir, "client")
+				Expect(os.Mkdir(clientDir, 0700)).To(Succeed())
+				clientCertFile, clientKeyFile = testnet.WriteTLSCertFiles(testnet.MakeValidTLSCert(), clientDir)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tmpDir)
+			})
+
+			It("stores the CA bundle and client certificate in the config", func() {
+				callApi([]string{"https://example.com", "--ca-cert", caCertFile, "--client-cert", clientCertFile, "--client-key", clientKeyFile})
+				Expect(runCLIErr).NotTo(HaveOccurred())
+
+				Expect(config.CACertFile()).To(Equal(caCertFile))
+				Expect(config.ClientCertFile()).To(Equal(clientCertFile))
+				Expect(config.ClientKeyFile()).To(Equal(clientKeyFile))
+			})
+
+			It("clears the certificate files when the endpoint is set without them", func() {
+				callApi([]string{"https://example.com", "--ca-cert", caCertFile})
+				Expect(runCLIErr).NotTo(HaveOccurred())
+
+				flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+				callApi([]string{"https://example.com"})
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(config.CACertFile()).To(BeEmpty())
+			})
+
+			It("fails when the CA bundle does not contain certificates", func() {
+				callApi([]string{"https://example.com", "--ca-cert", clientKeyFile})
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(runCLIErr.Error()).To(ContainSubstring("No certificates found in CA certificate bundle"))
+				Expect(endpointRepo.GetCCInfoCallCount()).To(BeZero())
+			})
+
+			It("fails when only one of the client certificate and key is given", func() {
+				callApi([]string{"https://example.com", "--client-cert", clientCertFile})
+				Expect(runCLIErr).To(MatchError("--client-cert and --client-key must be used together"))
+			})
+
+			It("fails when the client key does not match the certificate", func() {
+				_, otherKeyFile := testnet.WriteTLSCertFiles(testnet.MakeValidTLSCert(), tmpDir)
+
+				callApi([]string{"https://example.com", "--client-cert", clientCertFile, "--client-key", otherKeyFile})
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(runCLIErr.Error()).To(ContainSubstring("Error loading client certificate"))
+			})
+
+			It("clears the certificate files when the endpoint cannot be reached", func() {
+				endpointRepo.GetCCInfoReturns(nil, "", errors.New("no route to host"))
+
+				callApi([]string{"https://example.com", "--ca-cert", caCertFile})
+				Expect(runCLIErr).To(HaveOccurred())
+				Expect(config.CACertFile()).To(BeEmpty())
+			})
+		})
+
 		Describe("unencrypted http endpoints", func() {
 			It("warns the user", func() {
 				callApi([]string{"http://example.com"})
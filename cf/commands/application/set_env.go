@@ -32,6 +32,7 @@ func (cmd *SetEnv) MetaData() commandregistry.CommandMetadata {
 			T("CF_NAME set-env APP_NAME ENV_VAR_NAME ENV_VAR_VALUE"),
 		},
 		SkipFlagParsing: true,
+		SensitiveArgs:   []int{2},
 	}
 }
 
@@ -120,7 +120,7 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	//init secureShell if it is not already set by SetDependency() with fakes
 	if cmd.secureShell == nil {
 		cmd.secureShell = sshCmd.NewSecureShell(
-			sshCmd.DefaultSecureDialer(),
+			sshCmd.NewSecureDialer(net.NewProxyDialer(cmd.config)),
 			sshTerminal.DefaultHelper(),
 			sshCmd.DefaultListenerFactory(),
 			30*time.Second,
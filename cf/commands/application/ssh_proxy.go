@@ -0,0 +1,146 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	sshCmd "github.com/cloudfoundry/cli/cf/ssh"
+	"github.com/cloudfoundry/cli/cf/ssh/options"
+	"github.com/cloudfoundry/cli/cf/ssh/terminal"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+const defaultSSHProxyTargetAddress = "localhost:2222"
+
+type SSHProxy struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appReq        requirements.ApplicationRequirement
+	sshCodeGetter commands.SSHCodeGetter
+	opts          *options.SSHOptions
+	secureShell   sshCmd.SecureShell
+}
+
+func init() {
+	commandregistry.Register(&SSHProxy{})
+}
+
+func (cmd *SSHProxy) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+	fs["target-address"] = &flags.StringFlag{Name: "target-address", Usage: T("Address to connect to inside the application container (Default: localhost:2222)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "ssh-proxy",
+		Description: T("Bridge standard input and output to an application container instance, for use as an SSH ProxyCommand"),
+		Usage: []string{
+			T("CF_NAME ssh-proxy APP_NAME APP_INSTANCE_INDEX [--target-address ADDRESS] [--skip-host-validation]"),
+		},
+		Examples: []string{
+			"ssh -o ProxyCommand='CF_NAME ssh-proxy %h %p' -p 1 my-app # instance 1 of my-app",
+		},
+		Flags:  fs,
+		Hidden: true,
+	}
+}
+
+func (cmd *SSHProxy) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and APP_INSTANCE_INDEX as arguments") + "\n\n" + commandregistry.Commands.CommandUsage("ssh-proxy"))
+	}
+
+	index, err := strconv.ParseUint(fc.Args()[1], 10, 32)
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("APP_INSTANCE_INDEX must be a non-negative integer"), commandregistry.Commands.CommandUsage("ssh-proxy")))
+	}
+
+	cmd.opts = &options.SSHOptions{
+		AppName:            fc.Args()[0],
+		Index:              uint(index),
+		SkipHostValidation: fc.Bool("k"),
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(cmd.opts.AppName)
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs
+}
+
+func (cmd *SSHProxy) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	//get ssh-code for dependency
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SSHProxy) Execute(fc flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	info := sshInfo{}
+	err := cmd.gateway.GetResource(cmd.config.APIEndpoint()+"/v2/info", &info)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.NewSecureDialer(net.NewProxyDialer(cmd.config)),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+		)
+	}
+
+	err = cmd.secureShell.Connect(cmd.opts)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	targetAddress := defaultSSHProxyTargetAddress
+	if fc.IsSet("target-address") {
+		targetAddress = fc.String("target-address")
+	}
+
+	err = cmd.secureShell.ForwardStdio(targetAddress)
+	if err != nil {
+		return errors.New(T("Error forwarding to {{.Address}}: ", map[string]interface{}{"Address": targetAddress}) + err.Error())
+	}
+
+	return nil
+}
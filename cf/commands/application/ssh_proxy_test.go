@@ -0,0 +1,200 @@
+package application_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/commandsfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/requirements/requirementsfakes"
+	"github.com/cloudfoundry/cli/cf/ssh/sshfakes"
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ssh-proxy command", func() {
+	var (
+		ui *testterm.FakeUI
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+
+		requirementsFactory *requirementsfakes.FakeFactory
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+
+		fakeSecureShell *sshfakes.FakeSecureShell
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deps.Gateways = make(map[string]net.Gateway)
+		deps.WildcardDependency = nil
+
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalSSHCodeGetter)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("ssh-proxy").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("ssh-proxy", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("is hidden from help", func() {
+		Expect(commandregistry.Commands.FindCommand("ssh-proxy").MetaData().Hidden).To(BeTrue())
+	})
+
+	Describe("Requirements", func() {
+		It("fails with usage when not provided an app name and an index", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand("my-app")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires", "arguments"},
+			))
+		})
+
+		It("fails with usage when the index is not a non-negative integer", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand("my-app", "first")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "non-negative integer"},
+			))
+		})
+
+		It("fails requirements when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app", "0")).To(BeFalse())
+		})
+
+		It("fails if a space is not targeted", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeting space"})
+			Expect(runCommand("my-app", "0")).To(BeFalse())
+		})
+	})
+
+	Describe("proxying", func() {
+		var testServer *httptest.Server
+
+		BeforeEach(func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			currentApp := models.Application{}
+			currentApp.Name = "my-app"
+			currentApp.State = "started"
+			currentApp.GUID = "my-app-guid"
+			currentApp.Diego = true
+
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(currentApp)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			fakeSecureShell = new(sshfakes.FakeSecureShell)
+			deps.WildcardDependency = fakeSecureShell
+
+			getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/info",
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body:   getInfoResponseBody,
+				},
+			})
+
+			testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+			configRepo.SetAPIEndpoint(testServer.URL)
+			deps.Gateways["cloud-controller"] = cloudcontrollergateway.NewTestCloudControllerGateway(configRepo)
+		})
+
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("connects to the requested instance and forwards stdio to the container's ssh daemon", func() {
+			runCommand("my-app", "3", "-k")
+
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(1))
+			opts := fakeSecureShell.ConnectArgsForCall(0)
+			Expect(opts.AppName).To(Equal("my-app"))
+			Expect(opts.Index).To(Equal(uint(3)))
+			Expect(opts.SkipHostValidation).To(BeTrue())
+
+			Expect(fakeSecureShell.ForwardStdioCallCount()).To(Equal(1))
+			Expect(fakeSecureShell.ForwardStdioArgsForCall(0)).To(Equal("localhost:2222"))
+			Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+		})
+
+		It("forwards to --target-address when provided", func() {
+			runCommand("my-app", "0", "--target-address", "localhost:8080")
+
+			Expect(fakeSecureShell.ForwardStdioArgsForCall(0)).To(Equal("localhost:8080"))
+		})
+
+		It("notifies users when the one time auth code cannot be retrieved", func() {
+			sshCodeGetter.GetReturns("", errors.New("auth api error"))
+
+			runCommand("my-app", "0")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error getting one time auth code", "auth api error"},
+			))
+			Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+		})
+
+		It("notifies users when connecting fails", func() {
+			fakeSecureShell.ConnectReturns(errors.New("dial error"))
+
+			runCommand("my-app", "0")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error opening SSH connection", "dial error"},
+			))
+			Expect(fakeSecureShell.ForwardStdioCallCount()).To(Equal(0))
+		})
+
+		It("notifies users when forwarding fails", func() {
+			fakeSecureShell.ForwardStdioReturns(errors.New("channel rejected"))
+
+			runCommand("my-app", "0")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Error forwarding to localhost:2222", "channel rejected"},
+			))
+		})
+	})
+})
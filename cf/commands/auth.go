@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api/authentication"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
@@ -22,22 +25,42 @@ func init() {
 }
 
 func (cmd *Authenticate) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["client-credentials"] = &flags.BoolFlag{Name: "client-credentials", Usage: T("Use the UAA client credentials grant; the arguments are a client ID and client secret")}
+	fs["access-token"] = &flags.StringFlag{Name: "access-token", Usage: T("Authenticate with an access token obtained from UAA"), Sensitive: true}
+	fs["refresh-token"] = &flags.StringFlag{Name: "refresh-token", Usage: T("Refresh token used to renew the access token; when provided alone a new access token is requested with it"), Sensitive: true}
+
 	return commandregistry.CommandMetadata{
 		Name:        "auth",
 		Description: T("Authenticate user non-interactively"),
 		Usage: []string{
-			T("CF_NAME auth USERNAME PASSWORD\n\n"),
+			T("CF_NAME auth USERNAME PASSWORD\n"),
+			T("   CF_NAME auth --client-credentials CLIENT_ID CLIENT_SECRET\n"),
+			T("   CF_NAME auth [--access-token ACCESS_TOKEN] [--refresh-token REFRESH_TOKEN]\n\n"),
 			terminal.WarningColor(T("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history")),
 		},
 		Examples: []string{
 			T("CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)"),
+			T("CF_NAME auth --client-credentials my-ci-client \"my client secret\""),
+			T("CF_NAME auth --access-token \"$ACCESS_TOKEN\" --refresh-token \"$REFRESH_TOKEN\""),
 		},
+		Flags:         fs,
+		SensitiveArgs: []int{1},
 	}
 }
 
 func (cmd *Authenticate) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
-	if len(fc.Args()) != 2 {
+	usingTokens := fc.IsSet("access-token") || fc.IsSet("refresh-token")
+
+	switch {
+	case usingTokens && fc.Bool("client-credentials"):
+		cmd.ui.Failed(T("Incorrect Usage. '--client-credentials' cannot be combined with '--access-token' or '--refresh-token'\n\n") + commandregistry.Commands.CommandUsage("auth"))
+	case usingTokens && len(fc.Args()) != 0:
+		cmd.ui.Failed(T("Incorrect Usage. No arguments are accepted when authenticating with tokens\n\n") + commandregistry.Commands.CommandUsage("auth"))
+	case fc.Bool("client-credentials") && len(fc.Args()) != 2:
+		cmd.ui.Failed(T("Incorrect Usage. Requires 'client_id client_secret' as arguments\n\n") + commandregistry.Commands.CommandUsage("auth"))
+	case !usingTokens && len(fc.Args()) != 2:
 		cmd.ui.Failed(T("Incorrect Usage. Requires 'username password' as arguments\n\n") + commandregistry.Commands.CommandUsage("auth"))
 	}
 
@@ -56,14 +79,25 @@ func (cmd *Authenticate) SetDependency(deps commandregistry.Dependency, pluginCa
 }
 
 func (cmd *Authenticate) Execute(c flags.FlagContext) error {
-	cmd.config.ClearSession()
+	usingTokens := c.IsSet("access-token") || c.IsSet("refresh-token")
+	if !usingTokens {
+		cmd.config.ClearSession()
+	}
 	cmd.authenticator.GetLoginPromptsAndSaveUAAServerURL()
 
 	cmd.ui.Say(T("API endpoint: {{.APIEndpoint}}",
 		map[string]interface{}{"APIEndpoint": terminal.EntityNameColor(cmd.config.APIEndpoint())}))
 	cmd.ui.Say(T("Authenticating..."))
 
-	err := cmd.authenticator.Authenticate(map[string]string{"username": c.Args()[0], "password": c.Args()[1]})
+	var err error
+	switch {
+	case usingTokens:
+		err = cmd.authenticateWithTokens(c.String("access-token"), c.String("refresh-token"))
+	case c.Bool("client-credentials"):
+		err = cmd.authenticator.AuthenticateClientCredentials(c.Args()[0], c.Args()[1])
+	default:
+		err = cmd.authenticator.Authenticate(map[string]string{"username": c.Args()[0], "password": c.Args()[1]})
+	}
 	if err != nil {
 		return err
 	}
@@ -76,3 +110,51 @@ func (cmd *Authenticate) Execute(c flags.FlagContext) error {
 
 	return nil
 }
+
+// authenticateWithTokens checks the tokens, refreshing with the refresh token
+// when there is one, and replaces the session only when they are good
+func (cmd *Authenticate) authenticateWithTokens(accessToken, refreshToken string) error {
+	if accessToken == "" && refreshToken == "" {
+		return errors.New(T("An access token or refresh token must be provided"))
+	}
+
+	if accessToken != "" {
+		if !strings.HasPrefix(strings.ToLower(accessToken), "bearer ") {
+			accessToken = "bearer " + accessToken
+		}
+
+		err := checkAccessToken(accessToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		var err error
+		accessToken, refreshToken, err = cmd.authenticator.RefreshTokens(refreshToken)
+		if err != nil {
+			return err
+		}
+
+		err = checkAccessToken(accessToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.config.ClearSession()
+	cmd.config.SetAccessToken(accessToken)
+	cmd.config.SetRefreshToken(refreshToken)
+
+	return nil
+}
+
+// checkAccessToken returns an error unless the token decodes to the claims
+// of a UAA user or client
+func checkAccessToken(accessToken string) error {
+	info := coreconfig.NewTokenInfo(accessToken)
+	if info.UserGUID == "" && info.ClientID == "" {
+		return errors.New(T("The access token could not be decoded as a token for a UAA user or client"))
+	}
+	return nil
+}
@@ -61,6 +61,30 @@ var _ = Describe("auth command", func() {
 			))
 		})
 
+		It("fails with usage when --client-credentials is given too few arguments", func() {
+			testcmd.RunCLICommand("auth", []string{"--client-credentials", "my-client"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "client_id client_secret"},
+			))
+		})
+
+		It("fails with usage when tokens are given with arguments", func() {
+			testcmd.RunCLICommand("auth", []string{"--access-token", "my-token", "username"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "No arguments"},
+			))
+		})
+
+		It("fails with usage when tokens are combined with --client-credentials", func() {
+			testcmd.RunCLICommand("auth", []string{"--client-credentials", "--refresh-token", "my-token"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "cannot be combined"},
+			))
+		})
+
 		It("fails if the user has not set an api endpoint", func() {
 			Expect(testcmd.RunCLICommand("auth", []string{"username", "password"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeFalse())
 		})
@@ -107,6 +131,82 @@ var _ = Describe("auth command", func() {
 			Expect(authRepo.GetLoginPromptsAndSaveUAAServerURLCallCount()).To(Equal(1))
 		})
 
+		It("authenticates with the client credentials grant when --client-credentials is provided", func() {
+			testcmd.RunCLICommand("auth", []string{"--client-credentials", "my-client", "my-secret"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+			Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+			Expect(authRepo.AuthenticateClientCredentialsCallCount()).To(Equal(1))
+			clientID, clientSecret := authRepo.AuthenticateClientCredentialsArgsForCall(0)
+			Expect(clientID).To(Equal("my-client"))
+			Expect(clientSecret).To(Equal("my-secret"))
+		})
+
+		Context("with tokens", func() {
+			var accessToken string
+
+			BeforeEach(func() {
+				var err error
+				accessToken, err = testconfig.EncodeAccessToken(coreconfig.TokenInfo{UserGUID: "my-user-guid", Username: "my-user"})
+				Expect(err).NotTo(HaveOccurred())
+
+				config.SetAccessToken("bearer previous-access-token")
+				config.SetRefreshToken("previous-refresh-token")
+			})
+
+			It("stores a valid access token", func() {
+				testcmd.RunCLICommand("auth", []string{"--access-token", accessToken}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+				Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+				Expect(authRepo.RefreshTokensCallCount()).To(Equal(0))
+				Expect(config.AccessToken()).To(Equal(accessToken))
+				Expect(config.RefreshToken()).To(BeEmpty())
+			})
+
+			It("stores the tokens from refreshing with the provided refresh token", func() {
+				authRepo.RefreshTokensReturns(accessToken, "new-refresh-token", nil)
+
+				testcmd.RunCLICommand("auth", []string{"--access-token", accessToken, "--refresh-token", "my-refresh-token"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+				Expect(authRepo.RefreshTokensCallCount()).To(Equal(1))
+				Expect(authRepo.RefreshTokensArgsForCall(0)).To(Equal("my-refresh-token"))
+				Expect(config.AccessToken()).To(Equal(accessToken))
+				Expect(config.RefreshToken()).To(Equal("new-refresh-token"))
+			})
+
+			It("requests an access token when only a refresh token is provided", func() {
+				authRepo.RefreshTokensReturns(accessToken, "my-refresh-token", nil)
+
+				testcmd.RunCLICommand("auth", []string{"--refresh-token", "my-refresh-token"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+				Expect(authRepo.RefreshTokensCallCount()).To(Equal(1))
+				Expect(config.AccessToken()).To(Equal(accessToken))
+				Expect(config.RefreshToken()).To(Equal("my-refresh-token"))
+			})
+
+			It("leaves the config alone when the access token cannot be decoded", func() {
+				testcmd.RunCLICommand("auth", []string{"--access-token", "not-a-token", "--refresh-token", "my-refresh-token"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"could not be decoded"}))
+				Expect(authRepo.RefreshTokensCallCount()).To(Equal(0))
+				Expect(config.AccessToken()).To(Equal("bearer previous-access-token"))
+				Expect(config.RefreshToken()).To(Equal("previous-refresh-token"))
+			})
+
+			It("leaves the config alone when the refresh token is rejected", func() {
+				authRepo.RefreshTokensReturns("", "", errors.New("invalid_token"))
+
+				testcmd.RunCLICommand("auth", []string{"--refresh-token", "bad-refresh-token"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"invalid_token"}))
+				Expect(config.AccessToken()).To(Equal("bearer previous-access-token"))
+				Expect(config.RefreshToken()).To(Equal("previous-refresh-token"))
+			})
+		})
+
 		Describe("when authentication fails", func() {
 			BeforeEach(func() {
 				authRepo.AuthenticateReturns(errors.New("Error authenticating."))
@@ -0,0 +1,186 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type Completion struct {
+	ui           terminal.UI
+	pluginConfig pluginconfig.PluginConfiguration
+}
+
+func init() {
+	commandregistry.Register(&Completion{})
+}
+
+func (cmd *Completion) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "completion",
+		Description: T("Print a shell script that completes the commands and flags of the CLI and its plugins"),
+		Usage: []string{
+			T("CF_NAME completion SHELL\n\n"),
+			T("   SHELL is bash or zsh. Add 'source <(CF_NAME completion bash)' to ~/.bashrc, and run it again after installing plugins."),
+		},
+		Hidden: true,
+	}
+}
+
+func (cmd *Completion) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires SHELL as argument"),
+		func() bool {
+			return len(fc.Args()) != 1
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+
+	return reqs
+}
+
+func (cmd *Completion) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.pluginConfig = deps.PluginConfig
+	return cmd
+}
+
+func (cmd *Completion) Execute(c flags.FlagContext) error {
+	script := &bytes.Buffer{}
+
+	switch c.Args()[0] {
+	case "bash":
+	case "zsh":
+		fmt.Fprintln(script, "autoload -U +X bashcompinit && bashcompinit")
+	default:
+		return errors.New(T("Unsupported shell '{{.Shell}}'. Use bash or zsh.", map[string]interface{}{"Shell": c.Args()[0]}))
+	}
+
+	commands := cmd.completedCommands()
+	names := []string{}
+	for _, command := range commands {
+		names = append(names, command.names...)
+	}
+
+	function := "_" + strings.Replace(cf.Name, "-", "_", -1) + "_completion"
+	fmt.Fprintf(script, "%s() {\n", function)
+	fmt.Fprintln(script, `  local cur="${COMP_WORDS[COMP_CWORD]}"`)
+	fmt.Fprintln(script, `  if [ "$COMP_CWORD" -eq 1 ]; then`)
+	fmt.Fprintf(script, "    COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintln(script, "    return")
+	fmt.Fprintln(script, "  fi")
+	fmt.Fprintln(script, `  [[ "$cur" == -* ]] || return`)
+	fmt.Fprintln(script, `  case "${COMP_WORDS[1]}" in`)
+	for _, command := range commands {
+		if len(command.flags) == 0 {
+			continue
+		}
+		fmt.Fprintf(script, "    %s) COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", strings.Join(command.names, "|"), strings.Join(command.flags, " "))
+	}
+	fmt.Fprintln(script, "  esac")
+	fmt.Fprintln(script, "}")
+	fmt.Fprintf(script, "complete -o default -F %s %s", function, cf.Name)
+
+	cmd.ui.Say(script.String())
+	return nil
+}
+
+type completedCommand struct {
+	names []string
+	flags []string
+}
+
+// completionWord matches the names that can be put in the script as they are
+var completionWord = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]*$`)
+
+// completedCommands returns the visible core commands and the plugin
+// commands, with their names, aliases and flags
+func (cmd *Completion) completedCommands() []completedCommand {
+	commands := []completedCommand{}
+
+	for _, metadata := range commandregistry.Commands.Metadatas() {
+		if metadata.Hidden {
+			continue
+		}
+
+		command := completedCommand{names: completionWords(metadata.Name, metadata.ShortName)}
+		for _, flag := range metadata.Flags {
+			if flag.Visible() {
+				command.flags = append(command.flags, flagWords(flag.GetName(), flag.GetShortName())...)
+			}
+		}
+		commands = append(commands, command)
+	}
+
+	for _, metadata := range cmd.pluginConfig.Plugins() {
+		for _, pluginCommand := range metadata.Commands {
+			names := append([]string{pluginCommand.Name, pluginCommand.Alias}, pluginCommand.Aliases...)
+			command := completedCommand{names: completionWords(names...)}
+			for _, flag := range pluginCommand.Flags {
+				command.flags = append(command.flags, flagWords(flag.Name, flag.ShortName)...)
+			}
+			commands = append(commands, command)
+		}
+	}
+
+	completed := []completedCommand{}
+	for _, command := range commands {
+		if len(command.names) != 0 {
+			sort.Strings(command.flags)
+			completed = append(completed, command)
+		}
+	}
+	sort.Sort(completedCommandsByName(completed))
+
+	return completed
+}
+
+func completionWords(names ...string) []string {
+	words := []string{}
+	for _, name := range names {
+		if completionWord.MatchString(name) {
+			words = append(words, name)
+		}
+	}
+	return words
+}
+
+func flagWords(name, shortName string) []string {
+	words := []string{}
+	for _, word := range completionWords(name) {
+		words = append(words, "--"+word)
+	}
+	for _, word := range completionWords(shortName) {
+		words = append(words, "-"+word)
+	}
+	return words
+}
+
+type completedCommandsByName []completedCommand
+
+func (commands completedCommandsByName) Len() int {
+	return len(commands)
+}
+
+func (commands completedCommandsByName) Swap(i, j int) {
+	commands[i], commands[j] = commands[j], commands[i]
+}
+
+func (commands completedCommandsByName) Less(i, j int) bool {
+	return strings.Join(commands[i].names, "|") < strings.Join(commands[j].names, "|")
+}
@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Completion", func() {
+	var (
+		ui           *testterm.FakeUI
+		pluginConfig *pluginconfigfakes.FakePluginConfiguration
+		cmd          commandregistry.Command
+		flagContext  flags.FlagContext
+		originalName string
+	)
+
+	BeforeEach(func() {
+		originalName = cf.Name
+		cf.Name = "cf"
+
+		ui = &testterm.FakeUI{}
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Deployer": {
+				Commands: []plugin.Command{
+					{
+						Name:    "deploy",
+						Alias:   "d",
+						Aliases: []string{"ship"},
+						Flags: []plugin.Flag{
+							{Name: "ticket", ShortName: "t", Type: plugin.FlagTypeString},
+							{Name: "dry-run", Type: plugin.FlagTypeBool},
+						},
+					},
+					{Name: "$(rm -rf ~)"},
+				},
+			},
+		})
+
+		deps := commandregistry.Dependency{
+			UI:           ui,
+			PluginConfig: pluginConfig,
+		}
+
+		cmd = &commands.Completion{}
+		cmd.SetDependency(deps, false)
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+	})
+
+	AfterEach(func() {
+		cf.Name = originalName
+	})
+
+	var script = func(shell string) string {
+		Expect(flagContext.Parse(shell)).To(Succeed())
+		Expect(cmd.Execute(flagContext)).To(Succeed())
+		return strings.Join(ui.Outputs, "\n")
+	}
+
+	It("is hidden from help", func() {
+		Expect(cmd.MetaData().Hidden).To(BeTrue())
+	})
+
+	It("completes the names and flags of core commands", func() {
+		output := script("bash")
+
+		Expect(output).To(ContainSubstring("complete -o default -F _cf_completion cf"))
+		Expect(output).To(MatchRegexp(`compgen -W "[^"]*\blogin l\b`))
+		Expect(output).ToNot(ContainSubstring("v3apps"))
+		Expect(output).To(MatchRegexp(`\n    login\|l\) COMPREPLY=\(\$\(compgen -W "[^"]*-p[ "]`))
+	})
+
+	It("completes the names, aliases and flags of plugin commands", func() {
+		output := script("bash")
+
+		Expect(output).To(MatchRegexp(`compgen -W "[^"]*\bdeploy d ship\b`))
+		Expect(output).To(ContainSubstring(`    deploy|d|ship) COMPREPLY=($(compgen -W "--dry-run --ticket -t" -- "$cur")) ;;`))
+	})
+
+	It("leaves out names that are not safe in the script", func() {
+		Expect(script("bash")).ToNot(ContainSubstring("rm -rf"))
+	})
+
+	It("loads bash completion first in zsh", func() {
+		Expect(script("zsh")).To(HavePrefix("autoload -U +X bashcompinit && bashcompinit\n"))
+	})
+
+	It("returns an error for other shells", func() {
+		Expect(flagContext.Parse("fish")).To(Succeed())
+		err := cmd.Execute(flagContext)
+		Expect(err).To(MatchError("Unsupported shell 'fish'. Use bash or zsh."))
+	})
+})
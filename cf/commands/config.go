@@ -6,6 +6,8 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -25,15 +27,21 @@ func init() {
 func (cmd *ConfigCommands) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["async-timeout"] = &flags.IntFlag{Name: "async-timeout", Usage: T("Timeout for async HTTP requests")}
+	fs["retries"] = &flags.IntFlag{Name: "retries", Usage: T("Number of times to retry GET, PUT and DELETE requests that fail with a network error or a 5xx response")}
+	fs["rate-limit"] = &flags.IntFlag{Name: "rate-limit", Usage: T("Maximum number of requests to start each second, 0 for no limit")}
+	fs["max-concurrent-requests"] = &flags.IntFlag{Name: "max-concurrent-requests", Usage: T("Maximum number of requests in flight at once, 0 for no limit")}
+	fs["proxy"] = &flags.StringFlag{Name: "proxy", Usage: T("HTTP or SOCKS proxy for all connections, overriding https_proxy. Hosts in no_proxy are still reached directly. If PROXY_URL is 'CLEAR', the environment is used again."), Sensitive: true}
+	fs["cache-ttl"] = &flags.IntFlag{Name: "cache-ttl", Usage: T("Number of seconds to reuse app, service and other lists fetched by earlier commands, 0 to turn the cache off. Lists are revalidated with the API after that and dropped whenever a command changes anything.")}
 	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests")}
 	fs["color"] = &flags.StringFlag{Name: "color", Usage: T("Enable or disable color")}
 	fs["locale"] = &flags.StringFlag{Name: "locale", Usage: T("Set default locale. If LOCALE is 'CLEAR', previous locale is deleted.")}
+	fs["secret-backend"] = &flags.StringFlag{Name: "secret-backend", Usage: T("Where tokens are stored: plaintext in the config file, encrypted-file (passphrase from CF_SECRETS_PASSPHRASE), agent (socket from CF_SECRETS_AGENT_SOCK) or secret-service")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "config",
 		Description: T("Write default values to the config"),
 		Usage: []string{
-			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"),
+			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--retries RETRIES] [--rate-limit REQUESTS_PER_SECOND] [--max-concurrent-requests MAX_REQUESTS] [--proxy (PROXY_URL | CLEAR)] [--cache-ttl SECONDS] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)] [--secret-backend (plaintext | encrypted-file | agent | secret-service)]"),
 		},
 		Flags: fs,
 	}
@@ -51,7 +59,7 @@ func (cmd *ConfigCommands) SetDependency(deps commandregistry.Dependency, plugin
 }
 
 func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
-	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("color") && !context.IsSet("locale") {
+	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("retries") && !context.IsSet("rate-limit") && !context.IsSet("max-concurrent-requests") && !context.IsSet("proxy") && !context.IsSet("cache-ttl") && !context.IsSet("color") && !context.IsSet("locale") && !context.IsSet("secret-backend") {
 		return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
 	}
 
@@ -64,6 +72,53 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		cmd.config.SetAsyncTimeout(uint(asyncTimeout))
 	}
 
+	if context.IsSet("retries") {
+		retries := context.Int("retries")
+		if retries < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetRequestRetries(uint(retries))
+	}
+
+	if context.IsSet("rate-limit") {
+		rateLimit := context.Int("rate-limit")
+		if rateLimit < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetRequestRateLimit(uint(rateLimit))
+	}
+
+	if context.IsSet("max-concurrent-requests") {
+		maxRequests := context.Int("max-concurrent-requests")
+		if maxRequests < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetMaxConcurrentRequests(uint(maxRequests))
+	}
+
+	if context.IsSet("proxy") {
+		proxy := context.String("proxy")
+		if proxy == "CLEAR" {
+			proxy = ""
+		} else if _, err := net.ParseProxyURL(proxy); err != nil {
+			return err
+		}
+
+		cmd.config.SetProxy(proxy)
+	}
+
+	if context.IsSet("cache-ttl") {
+		cacheTTL := context.Int("cache-ttl")
+		if cacheTTL < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetCacheTTL(uint(cacheTTL))
+	}
+
 	if context.IsSet("trace") {
 		cmd.config.SetTrace(context.String("trace"))
 	}
@@ -80,6 +135,15 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		}
 	}
 
+	if context.IsSet("secret-backend") {
+		backend := context.String("secret-backend")
+		if !isSecretBackend(backend) {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetSecretBackend(backend)
+	}
+
 	if context.IsSet("locale") {
 		locale := context.String("locale")
 
@@ -106,3 +170,12 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 	}
 	return nil
 }
+
+func isSecretBackend(name string) bool {
+	for _, backend := range secrets.Backends {
+		if name == backend {
+			return true
+		}
+	}
+	return false
+}
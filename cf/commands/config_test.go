@@ -66,6 +66,90 @@ var _ = Describe("config command", func() {
 		})
 	})
 
+	Context("--retries flag", func() {
+		It("stores the number of retries", func() {
+			runCommand("--retries", "3")
+			Expect(configRepo.RequestRetries()).To(Equal(uint(3)))
+
+			runCommand("--retries", "0")
+			Expect(configRepo.RequestRetries()).To(Equal(uint(0)))
+		})
+
+		It("fails with usage when a negative number of retries is passed", func() {
+			runCommand("--retries", "-2")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.RequestRetries()).To(Equal(uint(0)))
+		})
+	})
+
+	Context("--rate-limit flag", func() {
+		It("stores the number of requests per second", func() {
+			runCommand("--rate-limit", "20")
+			Expect(configRepo.RequestRateLimit()).To(Equal(uint(20)))
+		})
+
+		It("fails with usage when a negative rate is passed", func() {
+			runCommand("--rate-limit", "-20")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.RequestRateLimit()).To(Equal(uint(0)))
+		})
+	})
+
+	Context("--max-concurrent-requests flag", func() {
+		It("stores the maximum number of requests in flight", func() {
+			runCommand("--max-concurrent-requests", "4")
+			Expect(configRepo.MaxConcurrentRequests()).To(Equal(uint(4)))
+		})
+
+		It("fails with usage when a negative maximum is passed", func() {
+			runCommand("--max-concurrent-requests", "-4")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.MaxConcurrentRequests()).To(Equal(uint(0)))
+		})
+	})
+
+	Context("--cache-ttl flag", func() {
+		It("stores the number of seconds lists are reused for", func() {
+			runCommand("--cache-ttl", "60")
+			Expect(configRepo.CacheTTL()).To(Equal(uint(60)))
+		})
+
+		It("fails with usage when a negative number of seconds is passed", func() {
+			runCommand("--cache-ttl", "-60")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.CacheTTL()).To(Equal(uint(0)))
+		})
+	})
+
+	Context("--proxy flag", func() {
+		It("stores the proxy URL", func() {
+			runCommand("--proxy", "socks5://proxy.example.com:1080")
+			Expect(configRepo.Proxy()).To(Equal("socks5://proxy.example.com:1080"))
+		})
+
+		It("clears the proxy when CLEAR is passed", func() {
+			configRepo.SetProxy("http://proxy.example.com:3128")
+			runCommand("--proxy", "CLEAR")
+			Expect(configRepo.Proxy()).To(BeEmpty())
+		})
+
+		It("fails when the proxy scheme is not supported", func() {
+			runCommand("--proxy", "ftp://proxy.example.com")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Unsupported proxy scheme 'ftp'"},
+			))
+			Expect(configRepo.Proxy()).To(BeEmpty())
+		})
+	})
+
 	Context("--trace flag", func() {
 		It("stores the trace value when --trace flag is provided", func() {
 			runCommand("--trace", "true")
@@ -96,6 +180,24 @@ var _ = Describe("config command", func() {
 		})
 	})
 
+	Context("--secret-backend flag", func() {
+		It("stores the secret backend when a known backend is provided", func() {
+			runCommand("--secret-backend", "secret-service")
+			Expect(configRepo.SecretBackend()).Should(Equal("secret-service"))
+
+			runCommand("--secret-backend", "plaintext")
+			Expect(configRepo.SecretBackend()).Should(Equal("plaintext"))
+		})
+
+		It("fails with usage when an unknown backend is provided", func() {
+			runCommand("--secret-backend", "vault")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.SecretBackend()).Should(Equal("plaintext"))
+		})
+	})
+
 	Context("--locale flag", func() {
 		It("stores the locale value when --locale [locale] is provided", func() {
 			runCommand("--locale", "zh-Hans")
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type ContextCommand struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&ContextCommand{})
+}
+
+func (cmd *ContextCommand) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "context",
+		Description: T("Save, switch between or list named login contexts"),
+		Usage: []string{
+			T("CF_NAME context save NAME\n"),
+			T("   CF_NAME context use NAME\n"),
+			T("   CF_NAME context list\n\n"),
+			T("   Any command can be run against a saved context without switching to it with:\n\n"),
+			T("   CF_NAME --context NAME COMMAND"),
+		},
+		Examples: []string{
+			"CF_NAME context save prod",
+			"CF_NAME context use dev",
+			"CF_NAME --context prod apps",
+		},
+	}
+}
+
+func (cmd *ContextCommand) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	args := fc.Args()
+
+	switch {
+	case len(args) == 1 && args[0] == "list":
+	case len(args) == 2 && (args[0] == "save" || args[0] == "use"):
+	default:
+		cmd.ui.Failed(T("Incorrect Usage. Requires 'save NAME', 'use NAME' or 'list' as arguments\n\n") + commandregistry.Commands.CommandUsage("context"))
+	}
+
+	reqs := []requirements.Requirement{}
+	if args[0] == "save" {
+		reqs = append(reqs, requirementsFactory.NewAPIEndpointRequirement())
+	}
+
+	return reqs
+}
+
+func (cmd *ContextCommand) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *ContextCommand) Execute(c flags.FlagContext) error {
+	switch c.Args()[0] {
+	case "save":
+		return cmd.save(c.Args()[1])
+	case "use":
+		return cmd.use(c.Args()[1])
+	default:
+		return cmd.list()
+	}
+}
+
+func (cmd *ContextCommand) save(name string) error {
+	cmd.ui.Say(T("Saving current login as context {{.ContextName}}...",
+		map[string]interface{}{"ContextName": terminal.EntityNameColor(name)}))
+
+	cmd.config.SaveContext(name)
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *ContextCommand) use(name string) error {
+	if _, ok := cmd.config.Contexts()[name]; !ok {
+		return errors.New(T("Context {{.ContextName}} not found. Use '{{.Command}}' to see the saved contexts.",
+			map[string]interface{}{
+				"ContextName": name,
+				"Command":     terminal.CommandColor(cf.Name + " context list"),
+			}))
+	}
+
+	err := cmd.config.UseContext(name)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.ShowConfiguration(cmd.config)
+	return nil
+}
+
+func (cmd *ContextCommand) list() error {
+	contexts := cmd.config.Contexts()
+	if len(contexts) == 0 {
+		cmd.ui.Say(T("No contexts saved. Use '{{.Command}}' to save the current login.",
+			map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " context save NAME")}))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{"", T("name"), T("api endpoint"), T("user"), T("org"), T("space")})
+	for _, name := range coreconfig.ContextNames(contexts) {
+		ctx := contexts[name]
+
+		current := ""
+		if name == cmd.config.CurrentContext() {
+			current = "*"
+		}
+
+		table.Add(current, name, ctx.Target, ctx.Username(), ctx.OrganizationFields.Name, ctx.SpaceFields.Name)
+	}
+
+	table.Print()
+	return nil
+}
@@ -0,0 +1,131 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("context command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		configRepo          coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("context").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = &testreq.FakeReqFactory{APIEndpointSuccess: true}
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("context", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not given a subcommand", func() {
+			runCommand()
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "save NAME", "use NAME", "list"},
+			))
+		})
+
+		It("fails with usage when given an unknown subcommand", func() {
+			runCommand("delete", "prod")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("fails with usage when save is not given a name", func() {
+			runCommand("save")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("requires an api endpoint to save a context", func() {
+			requirementsFactory.APIEndpointSuccess = false
+			Expect(runCommand("save", "prod")).To(BeFalse())
+		})
+
+		It("does not require an api endpoint to list contexts", func() {
+			requirementsFactory.APIEndpointSuccess = false
+			Expect(runCommand("list")).To(BeTrue())
+		})
+	})
+
+	Describe("save", func() {
+		It("saves the current login under the given name", func() {
+			runCommand("save", "prod")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Saving", "prod"},
+				[]string{"OK"},
+			))
+			Expect(configRepo.CurrentContext()).To(Equal("prod"))
+			Expect(configRepo.Contexts()["prod"].OrganizationFields.Name).To(Equal("my-org"))
+		})
+	})
+
+	Describe("use", func() {
+		BeforeEach(func() {
+			configRepo.SaveContext("prod")
+			configRepo.SetAPIEndpoint("https://api.dev.example.com")
+			configRepo.SetOrganizationFields(models.OrganizationFields{Name: "dev-org", GUID: "dev-org-guid"})
+			configRepo.SaveContext("dev")
+		})
+
+		It("switches to the named context and shows the new target", func() {
+			runCommand("use", "prod")
+
+			Expect(configRepo.CurrentContext()).To(Equal("prod"))
+			Expect(configRepo.OrganizationFields().Name).To(Equal("my-org"))
+			Expect(ui.ShowConfigurationCalled).To(BeTrue())
+		})
+
+		It("fails when the context does not exist", func() {
+			runCommand("use", "staging")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Context staging not found"},
+			))
+			Expect(configRepo.CurrentContext()).To(Equal("dev"))
+		})
+	})
+
+	Describe("list", func() {
+		It("tells the user when no contexts have been saved", func() {
+			runCommand("list")
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"No contexts saved"}))
+		})
+
+		It("lists the saved contexts and marks the current one", func() {
+			configRepo.SaveContext("prod")
+			configRepo.SetAPIEndpoint("https://api.dev.example.com")
+			configRepo.SetOrganizationFields(models.OrganizationFields{Name: "dev-org", GUID: "dev-org-guid"})
+			configRepo.SaveContext("dev")
+
+			runCommand("list")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"name", "api endpoint", "user", "org", "space"},
+				[]string{"*", "dev", "https://api.dev.example.com", "my-user", "dev-org", "my-space"},
+				[]string{"prod", "my-org", "my-space"},
+			))
+		})
+	})
+})
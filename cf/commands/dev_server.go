@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/devserver"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type DevServer struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(&DevServer{})
+}
+
+func (cmd *DevServer) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["port"] = &flags.IntFlag{Name: "port", Usage: T("Port to listen on (Default: a free port)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "dev-server",
+		Description: T("Run a local stand-in API that keeps orgs, spaces, apps and services in memory"),
+		Usage: []string{
+			T(`CF_NAME dev-server [--port PORT]
+
+   Point the CLI at the address printed with 'CF_NAME api' and log in with any
+   user name and password. The org dev-org and space dev-space, the shared
+   domain dev.local and the service dev-db are there to start with. Nothing
+   is kept once the server stops.`),
+		},
+		Examples: []string{
+			"CF_NAME dev-server --port 8080",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *DevServer) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 0 {
+		cmd.ui.Failed(T("Incorrect Usage. No argument required\n\n") + commandregistry.Commands.CommandUsage("dev-server"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *DevServer) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd *DevServer) Execute(c flags.FlagContext) error {
+	server := devserver.NewServer(func(request *http.Request, status int) {
+		if status < 400 {
+			cmd.ui.Say("%s %s %d", request.Method, request.URL.RequestURI(), status)
+		} else {
+			cmd.ui.Warn("%s %s %d", request.Method, request.URL.RequestURI(), status)
+		}
+	})
+
+	return serveLocally(cmd.ui, c.Int("port"), server, func(address string) (string, string) {
+		description := T("Serving a stand-in API on {{.Address}}", map[string]interface{}{
+			"Address": terminal.EntityNameColor(address),
+		})
+		return description, "cf api " + address + " && cf auth dev-user dev-password"
+	})
+}
+
+// serveLocally serves handler on 127.0.0.1 until the process is stopped.
+// Once the port is open, announce is given the server's address and returns
+// what is being served and the command that points the CLI at it.
+func serveLocally(ui terminal.UI, port int, handler http.Handler, announce func(address string) (description string, command string)) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	description, command := announce("http://" + listener.Addr().String())
+	ui.Say(description)
+	ui.Say(T("Run '{{.Command}}' in another terminal to use it, and press Ctrl-C to stop.", map[string]interface{}{
+		"Command": terminal.CommandColor(command),
+	}))
+	ui.Say("")
+
+	return http.Serve(listener, handler)
+}
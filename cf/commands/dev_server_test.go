@@ -0,0 +1,44 @@
+package commands_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("dev-server command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("dev-server").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+	})
+
+	It("fails with usage when given an argument", func() {
+		testcmd.RunCLICommand("dev-server", []string{"extra"}, requirementsFactory, updateCommandDependency, false, ui)
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "No argument required"},
+		))
+	})
+
+	It("fails when the port cannot be listened on", func() {
+		testcmd.RunCLICommand("dev-server", []string{"--port", "99999"}, requirementsFactory, updateCommandDependency, false, ui)
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+		))
+	})
+})
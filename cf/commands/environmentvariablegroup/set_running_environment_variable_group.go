@@ -31,6 +31,7 @@ func (cmd *SetRunningEnvironmentVariableGroup) MetaData() commandregistry.Comman
 		Usage: []string{
 			T(`CF_NAME set-running-environment-variable-group '{"name":"value","name":"value"}'`),
 		},
+		SensitiveArgs: []int{0},
 	}
 }
 
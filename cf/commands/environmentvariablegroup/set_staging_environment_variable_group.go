@@ -31,6 +31,7 @@ func (cmd *SetStagingEnvironmentVariableGroup) MetaData() commandregistry.Comman
 		Usage: []string{
 			T(`CF_NAME set-staging-environment-variable-group '{"name":"value","name":"value"}'`),
 		},
+		SensitiveArgs: []int{0},
 	}
 }
 
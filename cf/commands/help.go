@@ -2,7 +2,6 @@ package commands
 
 import (
 	"errors"
-	"strings"
 
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
@@ -57,35 +56,8 @@ func (cmd *Help) Execute(c flags.FlagContext) error {
 			found := false
 			for _, meta := range cmd.config.Plugins() {
 				for _, c := range meta.Commands {
-					if c.Name == cmdName || c.Alias == cmdName {
-						output := T("NAME:") + "\n"
-						output += "   " + c.Name + " - " + c.HelpText + "\n"
-
-						if c.Alias != "" {
-							output += "\n" + T("ALIAS:") + "\n"
-							output += "   " + c.Alias + "\n"
-						}
-
-						output += "\n" + T("USAGE:") + "\n"
-						output += "   " + c.UsageDetails.Usage + "\n"
-
-						if len(c.UsageDetails.Options) > 0 {
-							output += "\n" + T("OPTIONS:") + "\n"
-
-							//find longest name length
-							l := 0
-							for n := range c.UsageDetails.Options {
-								if len(n) > l {
-									l = len(n)
-								}
-							}
-
-							for n, f := range c.UsageDetails.Options {
-								output += "   -" + n + strings.Repeat(" ", 7+(l-len(n))) + f + "\n"
-							}
-						}
-
-						cmd.ui.Say(output)
+					if c.Matches(cmdName) {
+						cmd.ui.Say(help.PluginCommandUsage(c))
 
 						found = true
 					}
@@ -150,6 +150,19 @@ var _ = Describe("Help", func() {
 							},
 						},
 					},
+					{
+						Name:     "fakePluginCmd2",
+						Alias:    "fpc2",
+						Aliases:  []string{"fake2"},
+						HelpText: "help text for fpc2",
+						UsageDetails: plugin.Usage{
+							Usage: "Usage for fpc2",
+						},
+						Flags: []plugin.Flag{
+							{Name: "name", ShortName: "n", Type: plugin.FlagTypeString, Required: true, Usage: "Name of the thing"},
+							{Name: "count", Type: plugin.FlagTypeInt, Default: "1", Usage: "Number of things"},
+						},
+					},
 				},
 			}
 
@@ -196,5 +209,20 @@ var _ = Describe("Help", func() {
 			})
 		})
 
+		Context("command is an additional alias of a plugin command with typed flags", func() {
+			It("prints all aliases and the typed flags", func() {
+				flagContext.Parse("fake2")
+				err := cmd.Execute(flagContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeUI.SayCallCount()).To(Equal(1))
+				output, _ := fakeUI.SayArgsForCall(0)
+				Expect(output).To(ContainSubstring("fakePluginCmd2 - help text for fpc2"))
+				Expect(output).To(ContainSubstring("fpc2, fake2"))
+				Expect(output).To(ContainSubstring("OPTIONS"))
+				Expect(output).To(MatchRegexp(`--count\s+Number of things \(Default: 1\)`))
+				Expect(output).To(MatchRegexp(`--name, -n\s+Name of the thing \(Required\)`))
+			})
+		})
 	})
 })
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"github.com/cloudfoundry/cli/cf/api/jobs"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type ShowJob struct {
+	ui      terminal.UI
+	config  coreconfig.Reader
+	jobRepo jobs.JobRepository
+}
+
+func init() {
+	commandregistry.Register(&ShowJob{})
+}
+
+func (cmd *ShowJob) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait for the job to finish")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "job",
+		Description: T("Show the status of an asynchronous job, such as one started with --no-wait"),
+		Usage: []string{
+			T("CF_NAME job JOB_GUID [--wait]"),
+		},
+		Flags:     fs,
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *ShowJob) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("job"))
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+	}
+
+	return reqs
+}
+
+func (cmd *ShowJob) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.jobRepo = deps.RepoLocator.GetJobRepository()
+	return cmd
+}
+
+func (cmd *ShowJob) Execute(c flags.FlagContext) error {
+	jobGUID := c.Args()[0]
+
+	if c.Bool("wait") {
+		cmd.ui.Say(T("Waiting for job {{.JobGUID}} as {{.Username}}...",
+			map[string]interface{}{
+				"JobGUID":  terminal.EntityNameColor(jobGUID),
+				"Username": terminal.EntityNameColor(cmd.config.Username()),
+			}))
+
+		err := cmd.jobRepo.Wait(jobGUID)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.ui.Say(T("Getting job {{.JobGUID}} as {{.Username}}...",
+			map[string]interface{}{
+				"JobGUID":  terminal.EntityNameColor(jobGUID),
+				"Username": terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	}
+
+	job, err := cmd.jobRepo.Get(jobGUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{"", ""})
+	table.Add(T("guid:"), job.GUID)
+	table.Add(T("status:"), job.Status)
+	table.Add(T("created:"), job.CreatedAt)
+	if job.Error != "" {
+		table.Add(T("error:"), job.Error)
+	}
+	table.Print()
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/api/jobs/jobsfakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("job command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		jobRepo             *jobsfakes.FakeJobRepository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = testconfig.NewRepositoryWithDefaults()
+		deps.RepoLocator = deps.RepoLocator.SetJobRepository(jobRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("job").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		jobRepo = new(jobsfakes.FakeJobRepository)
+		requirementsFactory = &testreq.FakeReqFactory{LoginSuccess: true}
+
+		jobRepo.GetReturns(models.Job{
+			GUID:      "the-job-guid",
+			Status:    "running",
+			CreatedAt: "2016-06-08T16:41:22Z",
+		}, nil)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("job", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when no job is given", func() {
+		runCommand()
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("fails requirements when not logged in", func() {
+		requirementsFactory.LoginSuccess = false
+		Expect(runCommand("the-job-guid")).To(BeFalse())
+	})
+
+	It("shows the status of the job", func() {
+		runCommand("the-job-guid")
+
+		Expect(jobRepo.GetArgsForCall(0)).To(Equal("the-job-guid"))
+		Expect(jobRepo.WaitCallCount()).To(Equal(0))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Getting job", "the-job-guid", "my-user"},
+			[]string{"OK"},
+			[]string{"guid:", "the-job-guid"},
+			[]string{"status:", "running"},
+			[]string{"created:", "2016-06-08T16:41:22Z"},
+		))
+	})
+
+	It("shows why a job failed", func() {
+		jobRepo.GetReturns(models.Job{GUID: "the-job-guid", Status: "failed", Error: "he's dead, Jim"}, nil)
+		runCommand("the-job-guid")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"error:", "he's dead, Jim"},
+		))
+	})
+
+	It("waits for the job to finish when --wait is given", func() {
+		jobRepo.GetReturns(models.Job{GUID: "the-job-guid", Status: "finished"}, nil)
+		runCommand("--wait", "the-job-guid")
+
+		Expect(jobRepo.WaitArgsForCall(0)).To(Equal("the-job-guid"))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Waiting for job", "the-job-guid"},
+			[]string{"OK"},
+			[]string{"status:", "finished"},
+		))
+	})
+
+	It("fails when the job fails while waiting", func() {
+		jobRepo.WaitReturns(errors.New("he's dead, Jim"))
+		runCommand("--wait", "the-job-guid")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"he's dead, Jim"},
+		))
+	})
+})
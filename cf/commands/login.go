@@ -37,11 +37,14 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["a"] = &flags.StringFlag{ShortName: "a", Usage: T("API endpoint (e.g. https://api.example.com)")}
 	fs["u"] = &flags.StringFlag{ShortName: "u", Usage: T("Username")}
-	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Password")}
+	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Password"), Sensitive: true}
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space")}
 	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Use a one-time password to login")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
+	fs["ca-cert"] = &flags.StringFlag{Name: "ca-cert", Usage: T("Path to a PEM file of CA certificates to trust for the API endpoint")}
+	fs["client-cert"] = &flags.StringFlag{Name: "client-cert", Usage: T("Path to a PEM client certificate for endpoints that require mutual TLS")}
+	fs["client-key"] = &flags.StringFlag{Name: "client-key", Usage: T("Path to the PEM private key of the client certificate")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "login",
@@ -87,6 +90,14 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 		config:       cmd.config,
 		endpointRepo: cmd.endpointRepo,
 	}
+
+	if c.String("a") != "" || c.IsSet("ca-cert") || c.IsSet("client-cert") || c.IsSet("client-key") {
+		err := api.setTLSFiles(c.String("ca-cert"), c.String("client-cert"), c.String("client-key"))
+		if err != nil {
+			return err
+		}
+	}
+
 	err := api.setAPIEndpoint(endpoint, skipSSL, cmd.MetaData().Name)
 	if err != nil {
 		return err
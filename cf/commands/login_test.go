@@ -1,6 +1,9 @@
 package commands_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -14,6 +17,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/models"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testnet "github.com/cloudfoundry/cli/testhelpers/net"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -706,6 +710,37 @@ var _ = Describe("Login Command", func() {
 				})
 			})
 
+			Describe("when the --ca-cert flag is provided", func() {
+				var tmpDir string
+
+				BeforeEach(func() {
+					var err error
+					tmpDir, err = ioutil.TempDir("", "login-certs")
+					Expect(err).NotTo(HaveOccurred())
+
+					caCertFile, _ := testnet.WriteTLSCertFiles(testnet.MakeValidTLSCert(), tmpDir)
+					Flags = append(Flags, "--ca-cert", caCertFile)
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(tmpDir)
+				})
+
+				It("stores the CA bundle in the config", func() {
+					Expect(Config.CACertFile()).To(Equal(filepath.Join(tmpDir, "cert.pem")))
+				})
+			})
+
+			Describe("when the config has a CA bundle", func() {
+				BeforeEach(func() {
+					Config.SetCACertFile("/etc/cf/ca.pem")
+				})
+
+				It("keeps the CA bundle", func() {
+					Expect(Config.CACertFile()).To(Equal("/etc/cf/ca.pem"))
+				})
+			})
+
 			Describe("and the login fails authenticaton", func() {
 				BeforeEach(func() {
 					authRepo.AuthenticateReturns(errors.New("Error authenticating."))
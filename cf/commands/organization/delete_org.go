@@ -1,6 +1,9 @@
 package organization
 
 import (
+	"path"
+
+	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api/organizations"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
@@ -26,12 +29,13 @@ func init() {
 func (cmd *DeleteOrg) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["no-wait"] = &flags.BoolFlag{Name: "no-wait", Usage: T("Return as soon as the deletion has started, printing the job to follow with 'CF_NAME job'")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-org",
 		Description: T("Delete an org"),
 		Usage: []string{
-			T("CF_NAME delete-org ORG [-f]"),
+			T("CF_NAME delete-org ORG [-f] [--no-wait]"),
 		},
 		Flags: fs,
 	}
@@ -83,7 +87,12 @@ func (cmd *DeleteOrg) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	err = cmd.orgRepo.Delete(org.GUID)
+	jobURL := ""
+	if c.Bool("no-wait") {
+		jobURL, err = cmd.orgRepo.DeleteWithoutWaiting(org.GUID)
+	} else {
+		err = cmd.orgRepo.Delete(org.GUID)
+	}
 	if err != nil {
 		return err
 	}
@@ -94,5 +103,14 @@ func (cmd *DeleteOrg) Execute(c flags.FlagContext) error {
 	}
 
 	cmd.ui.Ok()
+
+	if jobURL != "" {
+		cmd.ui.Say(T("Deletion continues in job {{.JobURL}}\nUse '{{.Command}}' to follow it",
+			map[string]interface{}{
+				"JobURL":  jobURL,
+				"Command": terminal.CommandColor(cf.Name + " job " + path.Base(jobURL) + " --wait"),
+			}))
+	}
+
 	return nil
 }
@@ -125,6 +125,19 @@ var _ = Describe("delete-org command", func() {
 			Expect(orgRepo.DeleteArgsForCall(0)).To(Equal("org-to-delete-guid"))
 		})
 
+		It("returns the deletion job without waiting when --no-wait is given", func() {
+			orgRepo.DeleteWithoutWaitingReturns("/v2/jobs/the-job-guid", nil)
+			runCommand("-f", "--no-wait", "org-to-delete")
+
+			Expect(orgRepo.DeleteCallCount()).To(Equal(0))
+			Expect(orgRepo.DeleteWithoutWaitingArgsForCall(0)).To(Equal("org-to-delete-guid"))
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"OK"},
+				[]string{"Deletion continues in job", "/v2/jobs/the-job-guid"},
+				[]string{"job the-job-guid --wait"},
+			))
+		})
+
 		It("warns the user when the org does not exist", func() {
 			orgRepo.FindByNameReturns(models.Organization{}, errors.NewModelNotFoundError("Organization", "org org-to-delete does not exist"))
 
@@ -3,10 +3,10 @@ package plugin
 import (
 	"errors"
 	"fmt"
-	"net/rpc"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
 	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
@@ -14,6 +14,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/downloader"
@@ -30,7 +31,7 @@ type PluginInstall struct {
 	config       coreconfig.Reader
 	pluginConfig pluginconfig.PluginConfiguration
 	pluginRepo   pluginrepo.PluginRepo
-	checksum     utils.Sha1Checksum
+	checksum     utils.Checksum
 	rpcService   *pluginRPCService.CliRpcService
 }
 
@@ -77,10 +78,6 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.pluginRepo = deps.PluginRepo
 	cmd.checksum = deps.ChecksumUtil
 
-	//reset rpc registration in case there is other running instance,
-	//each service can only be registered once
-	rpc.DefaultServer = rpc.NewServer()
-
 	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer())
 	if err != nil {
 		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
@@ -99,7 +96,10 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		return errors.New(T("Plugin installation cancelled"))
 	}
 
-	fileDownloader := downloader.NewDownloader(os.TempDir())
+	// binaries of a repository are downloaded with its credentials
+	repo, _ := pluginrepo.FindRepo(cmd.config.PluginRepos(), c.String("r"))
+	transport := pluginrepo.NewTransport(repo, net.ProxyFromConfig(cmd.config))
+	fileDownloader := downloader.NewDownloaderWithTransport(os.TempDir(), transport)
 
 	removeTmpFile := func() {
 		err := fileDownloader.RemoveFile()
@@ -110,12 +110,15 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 	defer removeTmpFile()
 
 	deps := &plugininstaller.Context{
-		Checksummer:    cmd.checksum,
-		GetPluginRepos: cmd.config.PluginRepos,
-		FileDownloader: fileDownloader,
-		PluginRepo:     cmd.pluginRepo,
-		RepoName:       c.String("r"),
-		UI:             cmd.ui,
+		Checksummer:         cmd.checksum,
+		GetPluginRepos:      cmd.config.PluginRepos,
+		FileDownloader:      fileDownloader,
+		SignatureDownloader: downloader.NewDownloaderWithTransport(os.TempDir(), transport),
+		PluginRepo:          cmd.pluginRepo,
+		RepoName:            c.String("r"),
+		TrustedKeys:         cmd.config.TrustedPluginKeys(),
+		TrustPolicy:         cmd.config.PluginTrustPolicy(),
+		UI:                  cmd.ui,
 	}
 	installer := plugininstaller.NewPluginInstaller(deps)
 	pluginSourceFilepath := installer.Install(c.Args()[0])
@@ -131,7 +134,7 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	pluginMetadata, err := cmd.runBinaryAndObtainPluginMetadata(pluginSourceFilepath)
+	pluginMetadata, authenticatedRpc, err := cmd.runBinaryAndObtainPluginMetadata(pluginSourceFilepath)
 	if err != nil {
 		return err
 	}
@@ -141,7 +144,7 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	err = cmd.installPlugin(pluginMetadata, pluginDestinationFilepath, pluginSourceFilepath)
+	err = cmd.installPlugin(pluginMetadata, authenticatedRpc, pluginDestinationFilepath, pluginSourceFilepath)
 	if err != nil {
 		return err
 	}
@@ -182,7 +185,56 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 		return errors.New(fmt.Sprintf(T("Error getting command list from plugin {{.FilePath}}", map[string]interface{}{"FilePath": pluginSourceFilepath})))
 	}
 
-	for _, pluginCmd := range pluginMetadata.Commands {
+	err := ensureCommandsDoNotConflict(pluginMetadata.Commands, plugins)
+	if err != nil {
+		return err
+	}
+
+	err = ensureFlagsAreValid(pluginMetadata.Commands)
+	if err != nil {
+		return err
+	}
+
+	return ensureHooksAreValid(pluginMetadata.Hooks)
+}
+
+// ensureFlagsAreValid checks that the typed flags of every command of a
+// plugin can be parsed
+func ensureFlagsAreValid(commands []plugin.Command) error {
+	for _, pluginCmd := range commands {
+		_, err := pluginconfig.NewFlagContext(pluginCmd)
+		if err != nil {
+			return errors.New(T("Command `{{.Command}}` in the plugin being installed declares an invalid flag: {{.Error}}",
+				map[string]interface{}{"Command": pluginCmd.Name, "Error": err.Error()}))
+		}
+	}
+	return nil
+}
+
+// ensureHooksAreValid checks that every hook of a plugin is "pre-" or "post-"
+// followed by the full name of a native command
+func ensureHooksAreValid(hooks []plugin.Hook) error {
+	for _, hook := range hooks {
+		var command string
+		switch {
+		case strings.HasPrefix(hook.Name, "pre-"):
+			command = strings.TrimPrefix(hook.Name, "pre-")
+		case strings.HasPrefix(hook.Name, "post-"):
+			command = strings.TrimPrefix(hook.Name, "post-")
+		}
+
+		if cmd := commandregistry.Commands.FindCommand(command); cmd == nil || cmd.MetaData().Name != command {
+			return errors.New(T("Hook `{{.Hook}}` in the plugin being installed is not valid. Hooks are named pre- or post- followed by the name of a native CF command, e.g. pre-push.",
+				map[string]interface{}{"Hook": hook.Name}))
+		}
+	}
+	return nil
+}
+
+// ensureCommandsDoNotConflict checks that none of the commands or aliases of
+// a plugin are already taken by a native command or by the given plugins
+func ensureCommandsDoNotConflict(commands []plugin.Command, plugins map[string]pluginconfig.PluginMetadata) error {
+	for _, pluginCmd := range commands {
 
 		//check for command conflicting core commands/alias
 		if pluginCmd.Name == "help" || commandregistry.Commands.CommandExists(pluginCmd.Name) {
@@ -190,25 +242,29 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 				map[string]interface{}{"Command": pluginCmd.Name})))
 		}
 
-		//check for alias conflicting core command/alias
-		if pluginCmd.Alias == "help" || commandregistry.Commands.CommandExists(pluginCmd.Alias) {
-			return errors.New(fmt.Sprintf(T("Alias `{{.Command}}` in the plugin being installed is a native CF command/alias.  Rename the `{{.Command}}` command in the plugin being installed in order to enable its installation and use.",
-				map[string]interface{}{"Command": pluginCmd.Alias})))
+		//check for aliases conflicting core commands/alias
+		for _, alias := range pluginCmd.AllAliases() {
+			if alias == "help" || commandregistry.Commands.CommandExists(alias) {
+				return errors.New(fmt.Sprintf(T("Alias `{{.Command}}` in the plugin being installed is a native CF command/alias.  Rename the `{{.Command}}` command in the plugin being installed in order to enable its installation and use.",
+					map[string]interface{}{"Command": alias})))
+			}
 		}
 
 		for installedPluginName, installedPlugin := range plugins {
 			for _, installedPluginCmd := range installedPlugin.Commands {
 
 				//check for command conflicting other plugin commands/alias
-				if installedPluginCmd.Name == pluginCmd.Name || installedPluginCmd.Alias == pluginCmd.Name {
+				if installedPluginCmd.Matches(pluginCmd.Name) {
 					return errors.New(fmt.Sprintf(T("Command `{{.Command}}` is a command/alias in plugin '{{.PluginName}}'.  You could try uninstalling plugin '{{.PluginName}}' and then install this plugin in order to invoke the `{{.Command}}` command.  However, you should first fully understand the impact of uninstalling the existing '{{.PluginName}}' plugin.",
 						map[string]interface{}{"Command": pluginCmd.Name, "PluginName": installedPluginName})))
 				}
 
-				//check for alias conflicting other plugin commands/alias
-				if pluginCmd.Alias != "" && (installedPluginCmd.Name == pluginCmd.Alias || installedPluginCmd.Alias == pluginCmd.Alias) {
-					return errors.New(fmt.Sprintf(T("Alias `{{.Command}}` is a command/alias in plugin '{{.PluginName}}'.  You could try uninstalling plugin '{{.PluginName}}' and then install this plugin in order to invoke the `{{.Command}}` command.  However, you should first fully understand the impact of uninstalling the existing '{{.PluginName}}' plugin.",
-						map[string]interface{}{"Command": pluginCmd.Alias, "PluginName": installedPluginName})))
+				//check for aliases conflicting other plugin commands/alias
+				for _, alias := range pluginCmd.AllAliases() {
+					if installedPluginCmd.Matches(alias) {
+						return errors.New(fmt.Sprintf(T("Alias `{{.Command}}` is a command/alias in plugin '{{.PluginName}}'.  You could try uninstalling plugin '{{.PluginName}}' and then install this plugin in order to invoke the `{{.Command}}` command.  However, you should first fully understand the impact of uninstalling the existing '{{.PluginName}}' plugin.",
+							map[string]interface{}{"Command": alias, "PluginName": installedPluginName})))
+					}
 				}
 			}
 		}
@@ -216,39 +272,72 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 	return nil
 }
 
-func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, pluginDestinationFilepath, pluginSourceFilepath string) error {
+func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, authenticatedRpc bool, pluginDestinationFilepath, pluginSourceFilepath string) error {
 	err := fileutils.CopyPathToPath(pluginSourceFilepath, pluginDestinationFilepath)
 	if err != nil {
 		return errors.New(fmt.Sprintf(T("Could not copy plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()})))
 	}
 
 	configMetadata := pluginconfig.PluginMetadata{
-		Location: pluginDestinationFilepath,
-		Version:  pluginMetadata.Version,
-		Commands: pluginMetadata.Commands,
+		Location:         pluginDestinationFilepath,
+		Version:          pluginMetadata.Version,
+		Commands:         pluginMetadata.Commands,
+		Hooks:            pluginMetadata.Hooks,
+		AuthenticatedRpc: authenticatedRpc,
 	}
 
 	cmd.pluginConfig.SetPlugin(pluginMetadata.Name, configMetadata)
 	return nil
 }
 
-func (cmd *PluginInstall) runBinaryAndObtainPluginMetadata(pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
-	err := cmd.rpcService.Start()
+func (cmd *PluginInstall) runBinaryAndObtainPluginMetadata(pluginSourceFilepath string) (*plugin.PluginMetadata, bool, error) {
+	return obtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
+}
+
+// obtainPluginMetadata runs a plugin binary with SendMetadata and returns the
+// metadata it reports over RPC, and whether it sent the token of an
+// authenticated service. Plugins built before the token was introduced are
+// asked again with a service that does not require it.
+func obtainPluginMetadata(rpcService *pluginRPCService.CliRpcService, pluginFilepath string) (*plugin.PluginMetadata, bool, error) {
+	pluginMetadata, err := obtainAuthenticatedPluginMetadata(rpcService, pluginFilepath)
+	if err == nil {
+		return pluginMetadata, true, nil
+	}
+
+	err = rpcService.Start()
+	if err != nil {
+		return nil, false, err
+	}
+	defer rpcService.Stop()
+
+	rpcService.RpcCmd.PluginMetadata = &plugin.PluginMetadata{}
+	err = runPluginBinary(pluginFilepath, rpcService.Port(), os.Environ())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return rpcService.RpcCmd.PluginMetadata, false, nil
+}
+
+func obtainAuthenticatedPluginMetadata(rpcService *pluginRPCService.CliRpcService, pluginFilepath string) (*plugin.PluginMetadata, error) {
+	token, err := rpcService.StartAuthenticated()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.rpcService.Stop()
+	defer rpcService.Stop()
 
-	err = cmd.runPluginBinary(pluginSourceFilepath, cmd.rpcService.Port())
+	rpcService.RpcCmd.PluginMetadata = &plugin.PluginMetadata{}
+	err = runPluginBinary(pluginFilepath, rpcService.Port(), append(os.Environ(), plugin.RpcTokenEnvVar+"="+token))
 	if err != nil {
 		return nil, err
 	}
 
-	return cmd.rpcService.RpcCmd.PluginMetadata, nil
+	return rpcService.RpcCmd.PluginMetadata, nil
 }
 
-func (cmd *PluginInstall) runPluginBinary(location string, servicePort string) error {
+func runPluginBinary(location string, servicePort string, env []string) error {
 	pluginInvocation := exec.Command(location, servicePort, "SendMetadata")
+	pluginInvocation.Env = env
 
 	err := pluginInvocation.Run()
 	if err != nil {
@@ -1,13 +1,22 @@
 package plugin_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
@@ -39,7 +48,7 @@ var _ = Describe("Install", func() {
 		config              coreconfig.Repository
 		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
 		fakePluginRepo      *pluginrepofakes.FakePluginRepo
-		fakeChecksum        *utilsfakes.FakeSha1Checksum
+		fakeChecksum        *utilsfakes.FakeChecksum
 
 		pluginFile *os.File
 		homeDir    string
@@ -53,6 +62,9 @@ var _ = Describe("Install", func() {
 		test_with_orgs            string
 		test_with_orgs_short_name string
 		aliasConflicts            string
+		hooks                     string
+		invalidHook               string
+		invalidFlag               string
 		deps                      commandregistry.Dependency
 	)
 
@@ -71,7 +83,7 @@ var _ = Describe("Install", func() {
 		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
 		config = testconfig.NewRepositoryWithDefaults()
 		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
-		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeChecksum = new(utilsfakes.FakeChecksum)
 
 		dir, err := os.Getwd()
 		if err != nil {
@@ -84,6 +96,9 @@ var _ = Describe("Install", func() {
 		test_with_orgs = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "test_with_orgs.exe")
 		test_with_orgs_short_name = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "test_with_orgs_short_name.exe")
 		aliasConflicts = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "alias_conflicts.exe")
+		hooks = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "hooks.exe")
+		invalidHook = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "invalid_hook.exe")
+		invalidFlag = filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "invalid_flag.exe")
 
 		homeDir, err = ioutil.TempDir(os.TempDir(), "plugins")
 		Expect(err).ToNot(HaveOccurred())
@@ -198,17 +213,27 @@ var _ = Describe("Install", func() {
 
 				Context("when binary is available", func() {
 					var (
-						testServer *httptest.Server
+						testServer  *httptest.Server
+						signature   []byte
+						repoPlugins map[string][]clipr.Plugin
 					)
 
 					BeforeEach(func() {
+						signature = nil
 						h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							if strings.HasSuffix(r.URL.Path, ".sig") {
+								if signature == nil {
+									w.WriteHeader(http.StatusNotFound)
+								}
+								w.Write(signature)
+								return
+							}
 							fmt.Fprintln(w, "abc")
 						})
 
 						testServer = httptest.NewServer(h)
 
-						fakeChecksum.CheckSha1Returns(true)
+						fakeChecksum.CheckSha256Returns(true)
 
 						p := clipr.Plugin{
 							Name: "plugin1",
@@ -235,24 +260,37 @@ var _ = Describe("Install", func() {
 								},
 							},
 						}
-						result := make(map[string][]clipr.Plugin)
-						result["repo1"] = []clipr.Plugin{p}
+						repoPlugins = make(map[string][]clipr.Plugin)
+						repoPlugins["repo1"] = []clipr.Plugin{p}
 
 						config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: ""})
-						fakePluginRepo.GetPluginsReturns(result, nil)
+						fakePluginRepo.GetPluginsReturns(repoPlugins, nil)
 					})
 
 					AfterEach(func() {
 						testServer.Close()
 					})
 
-					It("performs sha1 checksum validation on the downloaded binary", func() {
+					It("performs sha256 checksum validation on the downloaded binary", func() {
+						runCommand("plugin1", "-r", "repo1", "-f")
+						Expect(fakeChecksum.CheckSha256CallCount()).To(Equal(1))
+						Expect(fakeChecksum.CheckSha1CallCount()).To(Equal(0))
+					})
+
+					It("falls back to sha1 for repositories that only list sha1 checksums", func() {
+						for i := range repoPlugins["repo1"][0].Binaries {
+							repoPlugins["repo1"][0].Binaries[i].Checksum = "a9993e364706816aba3e25717850c26c9cd0d89d"
+						}
+						fakeChecksum.CheckSha1Returns(true)
+
 						runCommand("plugin1", "-r", "repo1", "-f")
-						Expect(fakeChecksum.CheckSha1CallCount()).To(Equal(1))
+						Expect(fakeChecksum.CheckSha1ArgsForCall(0)).To(Equal("a9993e364706816aba3e25717850c26c9cd0d89d"))
+						Expect(ui.Outputs).To(ContainSubstrings([]string{"only lists a SHA-1 checksum"}))
+						Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"checksum does not match"}))
 					})
 
-					It("reports error downloaded file's sha1 does not match the sha1 in metadata", func() {
-						fakeChecksum.CheckSha1Returns(false)
+					It("reports error downloaded file's sha256 does not match the sha256 in metadata", func() {
+						fakeChecksum.CheckSha256Returns(false)
 
 						runCommand("plugin1", "-r", "repo1", "-f")
 						Expect(ui.Outputs).To(ContainSubstrings(
@@ -269,6 +307,80 @@ var _ = Describe("Install", func() {
 						Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}))
 						Expect(ui.Outputs).To(ContainSubstrings([]string{"Installing plugin"}))
 					})
+
+					Describe("signature verification", func() {
+						var privateKey *ecdsa.PrivateKey
+
+						BeforeEach(func() {
+							var err error
+							privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+							Expect(err).NotTo(HaveOccurred())
+
+							der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+							Expect(err).NotTo(HaveOccurred())
+							config.SetTrustedPluginKey(models.PluginKey{
+								Name:      "key1",
+								PublicKey: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+							})
+						})
+
+						sign := func(content string) []byte {
+							digest := sha256.Sum256([]byte(content))
+							r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+							Expect(err).NotTo(HaveOccurred())
+							signature, err := asn1.Marshal(struct{ R, S *big.Int }{r, s})
+							Expect(err).NotTo(HaveOccurred())
+							return signature
+						}
+
+						It("accepts a binary signed by a trusted key", func() {
+							signature = sign("abc\n")
+							config.SetPluginRepoTrustPolicy(0, models.PluginTrustRequireSigned)
+
+							runCommand("plugin1", "-r", "repo1", "-f")
+
+							Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin binary is signed by trusted key key1"}))
+							Expect(ui.Outputs).To(ContainSubstrings([]string{"Installing plugin"}))
+						})
+
+						It("warns about unsigned binaries by default", func() {
+							runCommand("plugin1", "-r", "repo1", "-f")
+
+							Expect(ui.Outputs).To(ContainSubstrings(
+								[]string{"could not be verified", "no signature was found at", "test.exe.sig"},
+								[]string{"Installing plugin"},
+							))
+						})
+
+						It("warns about binaries signed by another key", func() {
+							signature = sign("something else")
+
+							runCommand("plugin1", "-r", "repo1", "-f")
+
+							Expect(ui.Outputs).To(ContainSubstrings([]string{"could not be verified", "not from a trusted key"}))
+						})
+
+						It("rejects unsigned binaries when the repository requires signatures", func() {
+							config.SetPluginRepoTrustPolicy(0, models.PluginTrustRequireSigned)
+
+							runCommand("plugin1", "-r", "repo1", "-f")
+
+							Expect(ui.Outputs).To(ContainSubstrings(
+								[]string{"FAILED"},
+								[]string{"Plugin binary rejected", "no signature was found"},
+							))
+							Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Installing plugin"}))
+						})
+
+						It("does not look for a signature when the policy allows any binary", func() {
+							config.SetPluginTrustPolicy(models.PluginTrustAllow)
+
+							runCommand("plugin1", "-r", "repo1", "-f")
+
+							Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"could not be verified"}))
+							Expect(ui.Outputs).To(ContainSubstrings([]string{"Installing plugin"}))
+						})
+					})
 				})
 			})
 		})
@@ -338,6 +450,20 @@ var _ = Describe("Install", func() {
 
 	})
 
+	Describe("installing from a local path", func() {
+		It("rejects an unsigned binary when signatures are required", func() {
+			config.SetPluginTrustPolicy(models.PluginTrustRequireSigned)
+
+			runCommand(test_1, "-f")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Plugin binary rejected", "test_1.exe.sig"},
+			))
+			Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+		})
+	})
+
 	Describe("install failures", func() {
 		Context("when the plugin contains a 'help' command", func() {
 			It("fails", func() {
@@ -465,6 +591,51 @@ var _ = Describe("Install", func() {
 			})
 		})
 
+		It("fails if a command declares a flag of unknown type", func() {
+			runCommand(invalidFlag, "-f")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Command `invalid-flag` in the plugin being installed declares an invalid flag: Flag when of command invalid-flag has unknown type 'date'"},
+				[]string{"FAILED"},
+			))
+			Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+		})
+
+		It("fails if a hook is not named after a native command", func() {
+			runCommand(invalidHook, "-f")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Hook `pre-v` in the plugin being installed is not valid."},
+				[]string{"FAILED"},
+			))
+			Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+		})
+
+		Context("when the plugin's command conflicts with an additional alias of other installed plugin", func() {
+			It("fails", func() {
+				pluginsMap := make(map[string]pluginconfig.PluginMetadata)
+				pluginsMap["AliasesCollision"] = pluginconfig.PluginMetadata{
+					Location: "location/to/aliases.exe",
+					Commands: []plugin.Command{
+						{
+							Name:     "non-conflict-cmd",
+							Alias:    "ncc",
+							Aliases:  []string{"test_1_cmd1"},
+							HelpText: "Hi!",
+						},
+					},
+				}
+				pluginConfig.PluginsReturns(pluginsMap)
+
+				runCommand(test_1, "-f")
+
+				Expect(ui.Outputs).To(ContainSubstrings(
+					[]string{"Command `test_1_cmd1` is a command/alias in plugin 'AliasesCollision'."},
+					[]string{"FAILED"},
+				))
+			})
+		})
+
 		Context("when the plugin's command conflicts with other installed plugin", func() {
 			It("fails if it shares a command name", func() {
 				pluginsMap := make(map[string]pluginconfig.PluginMetadata)
@@ -602,6 +773,25 @@ var _ = Describe("Install", func() {
 			))
 		})
 
+		It("populates the configuration with the hooks of the plugin", func() {
+			runCommand(hooks, "-f")
+
+			pluginName, pluginMetadata := pluginConfig.SetPluginArgsForCall(0)
+
+			Expect(pluginName).To(Equal("Hooks"))
+			Expect(pluginMetadata.Hooks).To(Equal([]plugin.Hook{
+				{Name: "pre-plugins"},
+				{Name: "post-plugins"},
+			}))
+		})
+
+		It("records that the plugin authenticates its RPC connections", func() {
+			runCommand(test_1, "-f")
+
+			_, pluginMetadata := pluginConfig.SetPluginArgsForCall(0)
+			Expect(pluginMetadata.AuthenticatedRpc).To(BeTrue())
+		})
+
 		It("installs multiple plugins with no aliases", func() {
 			Expect(runCommand(test_1, "-f")).To(Equal(true))
 			Expect(runCommand(test_2, "-f")).To(Equal(true))
@@ -2,8 +2,12 @@ package plugin
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -13,8 +17,10 @@ import (
 )
 
 type Plugins struct {
-	ui     terminal.UI
-	config pluginconfig.PluginConfiguration
+	ui         terminal.UI
+	config     pluginconfig.PluginConfiguration
+	coreConfig coreconfig.Reader
+	pluginRepo pluginrepo.PluginRepo
 }
 
 func init() {
@@ -24,12 +30,13 @@ func init() {
 func (cmd *Plugins) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["checksum"] = &flags.BoolFlag{Name: "checksum", Usage: T("Compute and show the sha1 value of the plugin binary file")}
+	fs["outdated"] = &flags.BoolFlag{Name: "outdated", Usage: T("Search the registered plugin repositories for newer versions of the installed plugins")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "plugins",
 		Description: T("List all available plugin commands"),
 		Usage: []string{
-			T("CF_NAME plugins"),
+			T("CF_NAME plugins [--checksum | --outdated]"),
 		},
 		Flags: fs,
 	}
@@ -52,10 +59,16 @@ func (cmd *Plugins) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Plugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.PluginConfig
+	cmd.coreConfig = deps.Config
+	cmd.pluginRepo = deps.PluginRepo
 	return cmd
 }
 
 func (cmd *Plugins) Execute(c flags.FlagContext) error {
+	if c.Bool("outdated") {
+		return cmd.listOutdated()
+	}
+
 	var version string
 
 	cmd.ui.Say(T("Listing Installed Plugins..."))
@@ -80,14 +93,10 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 		for _, command := range metadata.Commands {
 			args := []string{pluginName, version}
 
-			if command.Alias != "" {
-				args = append(args, command.Name+", "+command.Alias)
-			} else {
-				args = append(args, command.Name)
-			}
+			args = append(args, strings.Join(append([]string{command.Name}, command.AllAliases()...), ", "))
 
 			if c.Bool("checksum") {
-				checksum := utils.NewSha1Checksum(metadata.Location)
+				checksum := utils.NewChecksum(metadata.Location)
 				sha1, err := checksum.ComputeFileSha1()
 				if err != nil {
 					args = append(args, "n/a")
@@ -107,3 +116,36 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 	table.Print()
 	return nil
 }
+
+func (cmd *Plugins) listOutdated() error {
+	cmd.ui.Say(T("Searching the plugin repositories for newer versions of installed plugins..."))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(cmd.coreConfig.PluginRepos())
+	updates := pluginrepo.FindUpdates(cmd.config.Plugins(), repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All plugins are up to date."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Latest Version"), T("Repository")})
+	for _, update := range updates {
+		table.Add(update.Name, versionString(update.InstalledVersion), versionString(update.Version), update.RepoName)
+	}
+	table.Print()
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Use '{{.Command}}' to update a plugin, or '{{.AllCommand}}' to update them all.",
+		map[string]interface{}{
+			"Command":    terminal.CommandColor(cf.Name + " update-plugin PLUGIN_NAME"),
+			"AllCommand": terminal.CommandColor(cf.Name + " update-plugin --all"),
+		}))
+	return nil
+}
@@ -1,8 +1,8 @@
 package plugin_test
 
 import (
-	"net/rpc"
-
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	plugincmd "github.com/cloudfoundry/cli/cf/commands/plugin"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/flags"
 	"github.com/cloudfoundry/cli/plugin"
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
 	. "github.com/cloudfoundry/cli/testhelpers/matchers"
 	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
@@ -23,12 +24,15 @@ var _ = Describe("Plugins", func() {
 		ui                  *testterm.FakeUI
 		requirementsFactory *testreq.FakeReqFactory
 		config              *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.PluginConfig = config
+		deps.Config = testconfig.NewRepositoryWithDefaults()
+		deps.PluginRepo = fakePluginRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("plugins").SetDependency(deps, pluginCall))
 	}
 
@@ -36,8 +40,7 @@ var _ = Describe("Plugins", func() {
 		ui = &testterm.FakeUI{}
 		requirementsFactory = &testreq.FakeReqFactory{}
 		config = new(pluginconfigfakes.FakePluginConfiguration)
-
-		rpc.DefaultServer = rpc.NewServer()
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
 	})
 
 	runCommand := func(args ...string) bool {
@@ -64,6 +67,41 @@ var _ = Describe("Plugins", func() {
 		})
 	})
 
+	Context("If --outdated flag is provided", func() {
+		BeforeEach(func() {
+			config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+				"Test1": {Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+				"Test2": {Version: plugin.VersionType{Major: 2}},
+			})
+		})
+
+		It("lists the installed plugins with newer versions in the repositories", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {{Name: "Test1", Version: "1.3.0"}, {Name: "Test2", Version: "2.0.0"}},
+			}, []string{"repo error1"})
+
+			runCommand("--outdated")
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Searching the plugin repositories"},
+				[]string{"OK"},
+				[]string{"repo error1"},
+				[]string{"Plugin Name", "Version", "Latest Version", "Repository"},
+				[]string{"Test1", "1.2.3", "1.3.0", "repo1"},
+				[]string{"update-plugin --all"},
+			))
+			Expect(ui.Outputs).NotTo(ContainSubstrings([]string{"Test2"}))
+		})
+
+		It("says so when every plugin is up to date", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{}, nil)
+
+			runCommand("--outdated")
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"All plugins are up to date."}))
+		})
+	})
+
 	Context("when arguments are provided", func() {
 		var cmd commandregistry.Command
 		var flagContext flags.FlagContext
@@ -128,6 +166,24 @@ var _ = Describe("Plugins", func() {
 		))
 	})
 
+	It("lists all aliases of a command", func() {
+		config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Test1": {
+				Location: "path/to/plugin",
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{
+					{Name: "test_1_cmd1", Alias: "t1", Aliases: []string{"test1", "tst1"}, HelpText: "help text for test_1_cmd1"},
+				},
+			},
+		})
+
+		runCommand()
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Test1", "1.2.3", "test_1_cmd1, t1, test1, tst1", "help text for test_1_cmd1"},
+		))
+	})
+
 	It("lists 'N/A' as version when plugin does not provide a version", func() {
 		config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
 			"Test1": {
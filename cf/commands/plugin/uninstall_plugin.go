@@ -3,7 +3,6 @@ package plugin
 import (
 	"errors"
 	"fmt"
-	"net/rpc"
 	"os"
 	"os/exec"
 	"time"
@@ -50,10 +49,6 @@ func (cmd *PluginUninstall) SetDependency(deps commandregistry.Dependency, plugi
 	cmd.ui = deps.UI
 	cmd.config = deps.PluginConfig
 
-	//reset rpc registration in case there is other running instance,
-	//each service can only be registered once
-	rpc.DefaultServer = rpc.NewServer()
-
 	RPCService, err := rpcService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpcService.NewCommandRunner(), deps.Logger, cmd.ui.Writer())
 	if err != nil {
 		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
@@ -101,13 +96,14 @@ func (cmd *PluginUninstall) Execute(c flags.FlagContext) error {
 }
 
 func (cmd *PluginUninstall) notifyPluginUninstalling(meta pluginconfig.PluginMetadata) (error, error) {
-	err := cmd.rpcService.Start()
+	env, err := cmd.rpcService.StartForPlugin(meta)
 	if err != nil {
 		return nil, err
 	}
 	defer cmd.rpcService.Stop()
 
 	pluginInvocation := exec.Command(meta.Location, cmd.rpcService.Port(), "CLI-MESSAGE-UNINSTALL")
+	pluginInvocation.Env = env
 	pluginInvocation.Stdout = os.Stdout
 
 	return pluginInvocation.Run(), nil
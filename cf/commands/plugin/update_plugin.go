@@ -0,0 +1,275 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/downloader"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/cloudfoundry/cli/utils"
+	"github.com/cloudfoundry/gofileutils/fileutils"
+
+	pluginRPCService "github.com/cloudfoundry/cli/plugin/rpc"
+)
+
+type PluginUpdate struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	pluginConfig pluginconfig.PluginConfiguration
+	pluginRepo   pluginrepo.PluginRepo
+	checksum     utils.Checksum
+	rpcService   *pluginRPCService.CliRpcService
+}
+
+func init() {
+	commandregistry.Register(&PluginUpdate{})
+}
+
+func (cmd *PluginUpdate) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["all"] = &flags.BoolFlag{Name: "all", Usage: T("Update every installed plugin that has a newer version in a registered repository")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force update of plugins without confirmation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "update-plugin",
+		Description: T("Update installed CLI plugins to the newest version in the registered repositories"),
+		Usage: []string{
+			T(`CF_NAME update-plugin (PLUGIN_NAME | --all) [-f]
+
+   Prompts for confirmation unless '-f' is provided. The installed version is
+   kept if the new version cannot be started.`),
+		},
+		Examples: []string{
+			"CF_NAME update-plugin plugin-echo",
+			"CF_NAME update-plugin --all -f",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *PluginUpdate) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if fc.Bool("all") == (len(fc.Args()) == 1) || len(fc.Args()) > 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires either a plugin name or --all\n\n") + commandregistry.Commands.CommandUsage("update-plugin"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *PluginUpdate) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginConfig = deps.PluginConfig
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+
+	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer())
+	if err != nil {
+		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
+	}
+
+	cmd.rpcService = rpcService
+
+	return cmd
+}
+
+func (cmd *PluginUpdate) Execute(c flags.FlagContext) error {
+	plugins := cmd.pluginConfig.Plugins()
+
+	pluginName := ""
+	if !c.Bool("all") {
+		pluginName = c.Args()[0]
+		if _, ok := plugins[pluginName]; !ok {
+			return errors.New(T("Plugin {{.PluginName}} is not installed.", map[string]interface{}{"PluginName": pluginName}))
+		}
+	}
+
+	repos := cmd.config.PluginRepos()
+	if len(repos) == 0 {
+		return errors.New(T("No plugin repositories are registered.\nTip: use 'add-plugin-repo' to register a repository"))
+	}
+
+	cmd.ui.Say(T("Searching the plugin repositories for newer versions..."))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := []pluginrepo.Update{}
+	for _, update := range pluginrepo.FindUpdates(plugins, repoPlugins) {
+		if pluginName == "" || update.Name == pluginName {
+			updates = append(updates, update)
+		}
+	}
+
+	if len(updates) == 0 {
+		cmd.ui.Ok()
+		if pluginName == "" {
+			cmd.ui.Say(T("All plugins are up to date."))
+		} else {
+			cmd.ui.Say(T("Plugin {{.PluginName}} is up to date.", map[string]interface{}{"PluginName": pluginName}))
+		}
+		return nil
+	}
+
+	names := []string{}
+	for _, update := range updates {
+		names = append(names, fmt.Sprintf("%s v%s", update.Name, versionString(update.Version)))
+	}
+
+	if !c.Bool("f") && !cmd.ui.Confirm(T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to update to {{.Plugins}}? (y or n)", map[string]interface{}{"Plugins": strings.Join(names, ", ")})) {
+		return errors.New(T("Plugin update cancelled"))
+	}
+
+	for _, update := range updates {
+		err := cmd.updatePlugin(update, plugins)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *PluginUpdate) updatePlugin(update pluginrepo.Update, plugins map[string]pluginconfig.PluginMetadata) error {
+	cmd.ui.Say(T("Updating plugin {{.PluginName}} from v{{.InstalledVersion}} to v{{.Version}} from repository {{.RepoName}}...",
+		map[string]interface{}{
+			"PluginName":       terminal.EntityNameColor(update.Name),
+			"InstalledVersion": versionString(update.InstalledVersion),
+			"Version":          versionString(update.Version),
+			"RepoName":         terminal.EntityNameColor(update.RepoName),
+		}))
+
+	repo, _ := pluginrepo.FindRepo(cmd.config.PluginRepos(), update.RepoName)
+	transport := pluginrepo.NewTransport(repo, net.ProxyFromConfig(cmd.config))
+
+	fileDownloader := downloader.NewDownloaderWithTransport(os.TempDir(), transport)
+	defer func() {
+		err := fileDownloader.RemoveFile()
+		if err != nil {
+			cmd.ui.Say(T("Problem removing downloaded binary in temp directory: ") + err.Error())
+		}
+	}()
+
+	pluginDownloader := &plugininstaller.PluginDownloader{UI: cmd.ui, FileDownloader: fileDownloader}
+	downloadedFilepath, binary := pluginDownloader.DownloadFromPlugin(update.Plugin)
+
+	if !plugininstaller.ChecksumMatches(cmd.ui, cmd.checksum, downloadedFilepath, binary.Checksum) {
+		return errors.New(T("Downloaded plugin binary's checksum does not match repo metadata"))
+	}
+
+	verifier := &plugininstaller.SignatureVerifier{
+		UI:          cmd.ui,
+		TrustedKeys: cmd.config.TrustedPluginKeys(),
+		Downloader:  downloader.NewDownloaderWithTransport(os.TempDir(), transport),
+	}
+	verifier.Verify(downloadedFilepath, binary.Url, plugininstaller.TrustPolicy(repo, cmd.config.PluginTrustPolicy()))
+
+	location := plugins[update.Name].Location
+	stagedFilepath := location + ".new"
+	backupFilepath := location + ".old"
+
+	// The new binary is copied next to the installed one so that the rename
+	// replacing it is atomic, and the installed one is kept until the new
+	// one has answered its SendMetadata handshake.
+	err := fileutils.CopyPathToPath(downloadedFilepath, stagedFilepath)
+	if err != nil {
+		return errors.New(T("Could not copy plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+	defer os.Remove(stagedFilepath)
+
+	err = fileutils.CopyPathToPath(location, backupFilepath)
+	if err != nil {
+		return errors.New(T("Could not back up plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+	defer os.Remove(backupFilepath)
+
+	err = os.Rename(stagedFilepath, location)
+	if err != nil {
+		return errors.New(T("Could not replace plugin binary: \n{{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	pluginMetadata, authenticatedRpc, err := cmd.verifyUpdatedPlugin(update.Name, location, plugins)
+	if err != nil {
+		restoreErr := os.Rename(backupFilepath, location)
+		if restoreErr != nil {
+			return errors.New(T("{{.Error}}\nThe previous version could not be restored: {{.RestoreError}}",
+				map[string]interface{}{"Error": err.Error(), "RestoreError": restoreErr.Error()}))
+		}
+		return errors.New(T("{{.Error}}\nPlugin {{.PluginName}} v{{.Version}} has been kept.",
+			map[string]interface{}{"Error": err.Error(), "PluginName": update.Name, "Version": versionString(update.InstalledVersion)}))
+	}
+
+	cmd.pluginConfig.SetPlugin(update.Name, pluginconfig.PluginMetadata{
+		Location:         location,
+		Version:          pluginMetadata.Version,
+		Commands:         pluginMetadata.Commands,
+		Hooks:            pluginMetadata.Hooks,
+		AuthenticatedRpc: authenticatedRpc,
+	})
+
+	cmd.ui.Say(T("Plugin {{.PluginName}} successfully updated to v{{.Version}}.",
+		map[string]interface{}{"PluginName": update.Name, "Version": versionString(pluginMetadata.Version)}))
+	return nil
+}
+
+// verifyUpdatedPlugin runs the SendMetadata handshake of the new binary and
+// checks that it is the same plugin, that its commands are still free and
+// that its flags and hooks are valid
+func (cmd *PluginUpdate) verifyUpdatedPlugin(name, location string, plugins map[string]pluginconfig.PluginMetadata) (*plugin.PluginMetadata, bool, error) {
+	pluginMetadata, authenticatedRpc, err := obtainPluginMetadata(cmd.rpcService, location)
+	if err != nil {
+		return nil, false, errors.New(T("The new version of plugin {{.PluginName}} failed to start: {{.Error}}",
+			map[string]interface{}{"PluginName": name, "Error": err.Error()}))
+	}
+
+	if pluginMetadata == nil || pluginMetadata.Name != name {
+		return nil, false, errors.New(T("The downloaded binary is not plugin {{.PluginName}}", map[string]interface{}{"PluginName": name}))
+	}
+
+	if pluginMetadata.Commands == nil {
+		return nil, false, errors.New(T("Error getting command list from plugin {{.FilePath}}", map[string]interface{}{"FilePath": location}))
+	}
+
+	others := make(map[string]pluginconfig.PluginMetadata)
+	for otherName, metadata := range plugins {
+		if otherName != name {
+			others[otherName] = metadata
+		}
+	}
+
+	err = ensureCommandsDoNotConflict(pluginMetadata.Commands, others)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = ensureFlagsAreValid(pluginMetadata.Commands)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = ensureHooksAreValid(pluginMetadata.Hooks)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return pluginMetadata, authenticatedRpc, nil
+}
+
+func versionString(version plugin.VersionType) string {
+	return fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Build)
+}
@@ -0,0 +1,206 @@
+package plugin_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/plugin"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+	"github.com/cloudfoundry/cli/utils/utilsfakes"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update-plugin", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		config              coreconfig.Repository
+		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		fakeChecksum        *utilsfakes.FakeChecksum
+		deps                commandregistry.Dependency
+
+		pluginDir       string
+		installedPath   string
+		installedBinary []byte
+		newBinary       []byte
+		testServer      *httptest.Server
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.PluginConfig = pluginConfig
+		deps.PluginRepo = fakePluginRepo
+		deps.ChecksumUtil = fakeChecksum
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-plugin").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("update-plugin", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	repoListing := func(version string) map[string][]clipr.Plugin {
+		binaries := []clipr.Binary{}
+		for _, platform := range []string{"osx", "win32", "win64", "linux32", "linux64"} {
+			binaries = append(binaries, clipr.Binary{Platform: platform, Url: testServer.URL + "/test_1.exe"})
+		}
+		return map[string][]clipr.Plugin{
+			"repo1": {{Name: "Test1", Version: version, Binaries: binaries}},
+		}
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://repo1.example.com"})
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakeChecksum = new(utilsfakes.FakeChecksum)
+		fakeChecksum.CheckSha256Returns(true)
+
+		var err error
+		pluginDir, err = ioutil.TempDir("", "update-plugin")
+		Expect(err).NotTo(HaveOccurred())
+		pluginConfig.GetPluginPathReturns(pluginDir)
+
+		dir, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		newBinary, err = ioutil.ReadFile(filepath.Join(dir, "..", "..", "..", "fixtures", "plugins", "test_1.exe"))
+		Expect(err).NotTo(HaveOccurred())
+
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(newBinary)
+		}))
+
+		installedBinary = []byte("the installed version")
+		installedPath = filepath.Join(pluginDir, "test_1.exe")
+		Expect(ioutil.WriteFile(installedPath, installedBinary, 0700)).To(Succeed())
+
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Test1": {
+				Location: installedPath,
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(pluginDir)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when neither a plugin name nor --all is given", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "plugin name or --all"}))
+		})
+
+		It("fails with usage when both a plugin name and --all are given", func() {
+			Expect(runCommand("Test1", "--all")).To(BeFalse())
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "plugin name or --all"}))
+		})
+	})
+
+	It("fails when the plugin is not installed", func() {
+		runCommand("not-installed", "-f")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Plugin not-installed is not installed."},
+		))
+	})
+
+	It("says so when the plugin is up to date", func() {
+		fakePluginRepo.GetPluginsReturns(repoListing("1.2.3"), nil)
+
+		runCommand("Test1", "-f")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin Test1 is up to date."}))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	It("replaces the binary and records the new version", func() {
+		fakePluginRepo.GetPluginsReturns(repoListing("1.2.4"), nil)
+
+		runCommand("--all", "-f")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Updating plugin Test1 from v1.2.3 to v1.2.4 from repository repo1"},
+			[]string{"Plugin Test1 successfully updated to v1.2.4."},
+			[]string{"OK"},
+		))
+
+		Expect(ioutil.ReadFile(installedPath)).To(Equal(newBinary))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(1))
+		name, metadata := pluginConfig.SetPluginArgsForCall(0)
+		Expect(name).To(Equal("Test1"))
+		Expect(metadata.Location).To(Equal(installedPath))
+		Expect(metadata.Version).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 4}))
+		Expect(metadata.Commands).NotTo(BeEmpty())
+
+		files, _ := ioutil.ReadDir(pluginDir)
+		Expect(files).To(HaveLen(1))
+	})
+
+	It("fails without touching the installed binary when the checksum does not match", func() {
+		fakePluginRepo.GetPluginsReturns(repoListing("1.2.4"), nil)
+		fakeChecksum.CheckSha256Returns(false)
+
+		runCommand("Test1", "-f")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"checksum does not match"}))
+		Expect(ioutil.ReadFile(installedPath)).To(Equal(installedBinary))
+	})
+
+	It("restores the installed binary when the new one fails its handshake", func() {
+		if runtime.GOOS == "windows" {
+			Skip("uses a shell script as the plugin binary")
+		}
+		newBinary = []byte("#!/bin/sh\nexit 1\n")
+		fakePluginRepo.GetPluginsReturns(repoListing("1.2.4"), nil)
+
+		runCommand("Test1", "-f")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"The new version of plugin Test1 failed to start"},
+			[]string{"Plugin Test1 v1.2.3 has been kept."},
+		))
+		Expect(ioutil.ReadFile(installedPath)).To(Equal(installedBinary))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+
+		files, _ := ioutil.ReadDir(pluginDir)
+		Expect(files).To(HaveLen(1))
+	})
+
+	It("asks for confirmation unless -f is given", func() {
+		fakePluginRepo.GetPluginsReturns(repoListing("1.2.4"), nil)
+		ui.Inputs = []string{"n"}
+
+		runCommand("Test1")
+
+		Expect(ui.Prompts).To(ContainSubstrings([]string{"Do you want to update to Test1 v1.2.4?"}))
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Plugin update cancelled"}))
+		Expect(ioutil.ReadFile(installedPath)).To(Equal(installedBinary))
+	})
+})
@@ -0,0 +1,95 @@
+package pluginrepo
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/utils"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type AddPluginKey struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&AddPluginKey{})
+}
+
+func (cmd *AddPluginKey) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "add-plugin-key",
+		Description: T("Trust a public key to sign plugin binaries"),
+		Usage: []string{
+			T(`CF_NAME add-plugin-key KEY_NAME PATH_TO_PUBLIC_KEY
+
+   The key is an RSA or ECDSA public key in PEM format. A plugin binary is
+   signed by a file next to it with '.sig' appended to its name, such as one
+   made with 'openssl dgst -sha256 -sign PRIVATE_KEY -out PLUGIN.sig PLUGIN'.`),
+		},
+		Examples: []string{
+			"CF_NAME add-plugin-key MyCompany ~/keys/plugins.pem",
+		},
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *AddPluginKey) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires KEY_NAME and PATH_TO_PUBLIC_KEY as arguments\n\n") + commandregistry.Commands.CommandUsage("add-plugin-key"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *AddPluginKey) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *AddPluginKey) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("")
+	keyName := strings.Trim(c.Args()[0], " ")
+
+	for _, key := range cmd.config.TrustedPluginKeys() {
+		if strings.ToLower(key.Name) == strings.ToLower(keyName) {
+			return errors.New(T("Plugin key named \"{{.KeyName}}\" already exists, please use another name.", map[string]interface{}{"KeyName": keyName}))
+		}
+	}
+
+	publicKey, err := ioutil.ReadFile(c.Args()[1])
+	if err != nil {
+		return errors.New(T("Error reading public key file: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	_, err = utils.ParsePublicKey(string(publicKey))
+	if err != nil {
+		return errors.New(T("Invalid public key in {{.Path}}: {{.Error}}", map[string]interface{}{"Path": c.Args()[1], "Error": err.Error()}))
+	}
+
+	fingerprint, _ := utils.PublicKeyFingerprint(string(publicKey))
+
+	cmd.config.SetTrustedPluginKey(models.PluginKey{
+		Name:      keyName,
+		PublicKey: string(publicKey),
+	})
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Plugins signed by key {{.KeyName}} with fingerprint {{.Fingerprint}} are now trusted", map[string]interface{}{
+		"KeyName":     terminal.EntityNameColor(keyName),
+		"Fingerprint": fingerprint,
+	}))
+	cmd.ui.Say("")
+	return nil
+}
@@ -0,0 +1,102 @@
+package pluginrepo_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("add-plugin-key", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+		tmpDir              string
+		keyPath             string
+		publicKey           string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("add-plugin-key").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "add-plugin-key")
+		Expect(err).NotTo(HaveOccurred())
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+		publicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+		keyPath = filepath.Join(tmpDir, "key.pem")
+		Expect(ioutil.WriteFile(keyPath, []byte(publicKey), 0600)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	var callAddPluginKey = func(args ...string) bool {
+		return testcmd.RunCLICommand("add-plugin-key", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when not given a name and a path", func() {
+		Expect(callAddPluginKey("key1")).To(BeFalse())
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"Incorrect Usage", "KEY_NAME and PATH_TO_PUBLIC_KEY"}))
+	})
+
+	It("saves the key to the config", func() {
+		callAddPluginKey("key1", keyPath)
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"Plugins signed by key key1 with fingerprint", "are now trusted"},
+		))
+		Expect(config.TrustedPluginKeys()).To(Equal([]models.PluginKey{{Name: "key1", PublicKey: publicKey}}))
+	})
+
+	It("fails when a key with the same name exists", func() {
+		config.SetTrustedPluginKey(models.PluginKey{Name: "Key1", PublicKey: "existing"})
+
+		callAddPluginKey("key1", keyPath)
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"Plugin key named", "key1", "already exists"}))
+		Expect(config.TrustedPluginKeys()).To(HaveLen(1))
+	})
+
+	It("fails when the file is not a public key", func() {
+		Expect(ioutil.WriteFile(keyPath, []byte("not a key"), 0600)).To(Succeed())
+
+		callAddPluginKey("key1", keyPath)
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"Invalid public key in", "key.pem"}))
+		Expect(config.TrustedPluginKeys()).To(BeEmpty())
+	})
+})
@@ -9,9 +9,11 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/models"
+	cfnet "github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/flags"
@@ -31,15 +33,25 @@ func init() {
 }
 
 func (cmd *AddPluginRepo) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["username"] = &flags.StringFlag{Name: "username", Usage: T("Username for a repository behind basic authentication")}
+	fs["password"] = &flags.StringFlag{Name: "password", Usage: T("Password for the username, prompted for when not given"), Sensitive: true}
+	fs["token"] = &flags.StringFlag{Name: "token", Usage: T("Bearer token for a repository behind token authentication"), Sensitive: true}
+
 	return commandregistry.CommandMetadata{
 		Name:        "add-plugin-repo",
 		Description: T("Add a new plugin repository"),
 		Usage: []string{
-			T(`CF_NAME add-plugin-repo REPO_NAME URL`),
+			T(`CF_NAME add-plugin-repo REPO_NAME URL [--username USERNAME [--password PASSWORD] | --token TOKEN]
+
+   The credentials are kept in the secret backend of the CLI. A local copy made with 'mirror-plugin-repo' is added with a file:// URL.`),
 		},
 		Examples: []string{
 			"CF_NAME add-plugin-repo PrivateRepo https://myprivaterepo.com/repo/",
+			"CF_NAME add-plugin-repo PrivateRepo https://myprivaterepo.com/repo/ --username admin",
+			"CF_NAME add-plugin-repo MirroredRepo file:///opt/plugin-mirror",
 		},
+		Flags:     fs,
 		TotalArgs: 2,
 	}
 }
@@ -49,6 +61,14 @@ func (cmd *AddPluginRepo) Requirements(requirementsFactory requirements.Factory,
 		cmd.ui.Failed(T("Incorrect Usage. Requires REPO_NAME and URL as arguments\n\n") + commandregistry.Commands.CommandUsage("add-plugin-repo"))
 	}
 
+	if fc.IsSet("token") && (fc.IsSet("username") || fc.IsSet("password")) {
+		cmd.ui.Failed(T("Cannot specify token together with username and/or password."))
+	}
+
+	if fc.IsSet("password") && !fc.IsSet("username") {
+		cmd.ui.Failed(T("Cannot specify password without username."))
+	}
+
 	reqs := []requirements.Requirement{}
 	return reqs
 }
@@ -61,7 +81,10 @@ func (cmd *AddPluginRepo) SetDependency(deps commandregistry.Dependency, pluginC
 
 func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 	cmd.ui.Say("")
-	repoURL := strings.ToLower(c.Args()[1])
+	repoURL := c.Args()[1]
+	if !isFileURL(repoURL) {
+		repoURL = strings.ToLower(repoURL)
+	}
 	repoName := strings.Trim(c.Args()[0], " ")
 
 	err := cmd.checkIfRepoExists(repoName, repoURL)
@@ -74,7 +97,21 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	resp, err := http.Get(repoURL)
+	repo := models.PluginRepo{
+		Name:     c.Args()[0],
+		URL:      c.Args()[1],
+		Username: c.String("username"),
+		Token:    c.String("token"),
+	}
+	if repo.Username != "" {
+		repo.Password = c.String("password")
+		if !c.IsSet("password") {
+			repo.Password = cmd.ui.AskForPassword(T("Password"))
+		}
+	}
+
+	client := &http.Client{Transport: pluginrepo.NewTransport(repo, cfnet.ProxyFromConfig(cmd.config))}
+	resp, err := client.Get(repoURL)
 	if err != nil {
 		if urlErr, ok := err.(*url.Error); ok {
 			if opErr, opErrOk := urlErr.Err.(*net.OpError); opErrOk {
@@ -97,6 +134,13 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 		return errors.New(repoURL + T(" is not responding. Please make sure it is a valid plugin repo."))
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return errors.New(T("Authentication with {{.RepoURL}} failed: {{.Status}}\nTip: use '--username' or '--token' to provide credentials for the repo", map[string]interface{}{
+			"RepoURL": repoURL,
+			"Status":  resp.Status,
+		}))
+	}
+
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return errors.New(T("Error reading response from server: ") + err.Error())
@@ -112,10 +156,7 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 		return errors.New(T(`"Plugins" object not found in the responded data.`))
 	}
 
-	cmd.config.SetPluginRepo(models.PluginRepo{
-		Name: c.Args()[0],
-		URL:  c.Args()[1],
-	})
+	cmd.config.SetPluginRepo(repo)
 
 	cmd.ui.Ok()
 	cmd.ui.Say(repoURL + T(" added as '") + c.Args()[0] + "'")
@@ -136,7 +177,7 @@ func (cmd AddPluginRepo) checkIfRepoExists(repoName, repoURL string) error {
 }
 
 func (cmd AddPluginRepo) verifyURL(repoURL string) (string, error) {
-	if !strings.HasPrefix(repoURL, "http://") && !strings.HasPrefix(repoURL, "https://") {
+	if !strings.HasPrefix(repoURL, "http://") && !strings.HasPrefix(repoURL, "https://") && !isFileURL(repoURL) {
 		return "", errors.New(T("{{.URL}} is not a valid url, please provide a url, e.g. https://your_repo.com", map[string]interface{}{"URL": repoURL}))
 	}
 
@@ -148,3 +189,7 @@ func (cmd AddPluginRepo) verifyURL(repoURL string) (string, error) {
 
 	return repoURL, nil
 }
+
+func isFileURL(repoURL string) bool {
+	return strings.HasPrefix(strings.ToLower(repoURL), "file://")
+}
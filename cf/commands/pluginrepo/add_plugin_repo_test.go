@@ -2,8 +2,11 @@ package pluginrepo_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 
 	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
@@ -78,6 +81,95 @@ var _ = Describe("add-plugin-repo", func() {
 		})
 	})
 
+	Context("When the repo server requires authentication", func() {
+		BeforeEach(func() {
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				if r.Header.Get("Authorization") != "Bearer my-token" && (!ok || username != "admin" || password != "secret") {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprintln(w, `{"plugins":[]}`)
+			})
+			testServer = httptest.NewServer(h)
+		})
+
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("saves the username and password of the repo", func() {
+			callAddPluginRepo([]string{"repo", testServer.URL, "--username", "admin", "--password", "secret"})
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+			Expect(config.PluginRepos()[0].Username).To(Equal("admin"))
+			Expect(config.PluginRepos()[0].Password).To(Equal("secret"))
+		})
+
+		It("prompts for the password when it is not given", func() {
+			ui.Inputs = []string{"secret"}
+			callAddPluginRepo([]string{"repo", testServer.URL, "--username", "admin"})
+
+			Expect(ui.PasswordPrompts).To(ContainSubstrings([]string{"Password"}))
+			Expect(config.PluginRepos()[0].Password).To(Equal("secret"))
+		})
+
+		It("saves the token of the repo", func() {
+			callAddPluginRepo([]string{"repo", testServer.URL, "--token", "my-token"})
+
+			Expect(config.PluginRepos()[0].Token).To(Equal("my-token"))
+		})
+
+		It("informs user when the credentials are rejected", func() {
+			callAddPluginRepo([]string{"repo", testServer.URL, "--token", "wrong-token"})
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Authentication with", "failed: 401 Unauthorized"},
+			))
+			Expect(config.PluginRepos()).To(BeEmpty())
+		})
+
+		It("fails with usage when given a token and a username", func() {
+			Expect(callAddPluginRepo([]string{"repo", testServer.URL, "--token", "my-token", "--username", "admin"})).To(BeFalse())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Cannot specify token together with username and/or password."},
+			))
+		})
+
+		It("fails with usage when given a password without a username", func() {
+			Expect(callAddPluginRepo([]string{"repo", testServer.URL, "--password", "secret"})).To(BeFalse())
+
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Cannot specify password without username."},
+			))
+		})
+	})
+
+	Context("When the repo is a local directory", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "Plugin-Mirror")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(dir, "list"), []byte(`{"plugins":[]}`), 0600)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("saves the file url into config", func() {
+			callAddPluginRepo([]string{"mirror", "file://" + filepath.ToSlash(dir)})
+
+			Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}))
+			Expect(config.PluginRepos()[0].URL).To(Equal("file://" + filepath.ToSlash(dir)))
+		})
+	})
+
 	Context("repo name already existing", func() {
 		BeforeEach(func() {
 			config.SetPluginRepo(models.PluginRepo{Name: "repo", URL: "http://repo.com"})
@@ -1,6 +1,7 @@
 package pluginrepo
 
 import (
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/requirements"
@@ -52,10 +53,10 @@ func (cmd *ListPluginRepos) SetDependency(deps commandregistry.Dependency, plugi
 func (cmd *ListPluginRepos) Execute(c flags.FlagContext) error {
 	repos := cmd.config.PluginRepos()
 
-	table := cmd.ui.Table([]string{T("Repo Name"), T("URL")})
+	table := cmd.ui.Table([]string{T("Repo Name"), T("URL"), T("Trust Policy")})
 
 	for _, repo := range repos {
-		table.Add(repo.Name, repo.URL)
+		table.Add(repo.Name, repo.URL, plugininstaller.TrustPolicy(repo, cmd.config.PluginTrustPolicy()))
 	}
 
 	cmd.ui.Ok()
@@ -46,15 +46,17 @@ var _ = Describe("list-plugin-repo", func() {
 			URL:  "http://url1.com",
 		})
 		config.SetPluginRepo(models.PluginRepo{
-			Name: "repo2",
-			URL:  "http://url2.com",
+			Name:        "repo2",
+			URL:         "http://url2.com",
+			TrustPolicy: models.PluginTrustRequireSigned,
 		})
 
 		callListPluginRepos()
 
 		Expect(ui.Outputs).To(ContainSubstrings(
-			[]string{"repo1", "http://url1.com"},
-			[]string{"repo2", "http://url2.com"},
+			[]string{"Repo Name", "URL", "Trust Policy"},
+			[]string{"repo1", "http://url1.com", "warn"},
+			[]string{"repo2", "http://url2.com", "require-signed"},
 		))
 
 	})
@@ -0,0 +1,196 @@
+package pluginrepo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/actors/plugininstaller"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	cfnet "github.com/cloudfoundry/cli/cf/net"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/downloader"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/utils"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type MirrorPluginRepo struct {
+	ui         terminal.UI
+	config     coreconfig.Reader
+	pluginRepo pluginrepo.PluginRepo
+	checksum   utils.Checksum
+}
+
+func init() {
+	commandregistry.Register(&MirrorPluginRepo{})
+}
+
+func (cmd *MirrorPluginRepo) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["platform"] = &flags.StringSliceFlag{Name: "platform", Usage: T("Only copy the binaries for this platform (osx, linux32, linux64, win32 or win64), can be given more than once")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "mirror-plugin-repo",
+		Description: T("Copy the plugins of a repository to a local directory"),
+		Usage: []string{
+			T(`CF_NAME mirror-plugin-repo REPO_NAME DIRECTORY [--platform PLATFORM]
+
+   Writes the index of the repository and its plugin binaries to DIRECTORY, which can then be added as a repository with a file:// URL, e.g. on a machine without internet access.`),
+		},
+		Examples: []string{
+			"CF_NAME mirror-plugin-repo CF-Community /opt/plugin-mirror --platform linux64",
+			"CF_NAME add-plugin-repo MirroredRepo file:///opt/plugin-mirror",
+		},
+		Flags:     fs,
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *MirrorPluginRepo) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires REPO_NAME and DIRECTORY as arguments\n\n") + commandregistry.Commands.CommandUsage("mirror-plugin-repo"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *MirrorPluginRepo) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+	return cmd
+}
+
+func (cmd *MirrorPluginRepo) Execute(c flags.FlagContext) error {
+	repoName := c.Args()[0]
+	dir, err := filepath.Abs(c.Args()[1])
+	if err != nil {
+		return err
+	}
+
+	repo, found := pluginrepo.FindRepo(cmd.config.PluginRepos(), repoName)
+	if !found {
+		return errors.New(repoName + T(" does not exist as an available plugin repo."+"\nTip: use `add-plugin-repo` command to add repos."))
+	}
+
+	cmd.ui.Say(T("Mirroring repository {{.RepoName}} to {{.Directory}}...", map[string]interface{}{
+		"RepoName":  terminal.EntityNameColor(repo.Name),
+		"Directory": terminal.EntityNameColor(dir),
+	}))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins([]models.PluginRepo{repo})
+	if len(repoErrors) != 0 {
+		return errors.New(T("Error getting plugin metadata from repo: ") + repoErrors[0])
+	}
+
+	platforms := map[string]bool{}
+	for _, platform := range c.StringSlice("platform") {
+		platforms[platform] = true
+	}
+
+	transport := pluginrepo.NewTransport(repo, cfnet.ProxyFromConfig(cmd.config))
+	mirrored := []clipr.Plugin{}
+	for _, plugin := range repoPlugins[repo.Name] {
+		binaries := []clipr.Binary{}
+		for _, binary := range plugin.Binaries {
+			if len(platforms) != 0 && !platforms[binary.Platform] {
+				continue
+			}
+
+			binary, err = cmd.mirrorBinary(transport, dir, plugin, binary)
+			if err != nil {
+				return err
+			}
+			binaries = append(binaries, binary)
+		}
+
+		if len(binaries) != 0 {
+			plugin.Binaries = binaries
+			mirrored = append(mirrored, plugin)
+		}
+	}
+
+	index, err := json.MarshalIndent(clipr.PluginsJson{Plugins: mirrored}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "list"), index, 0644)
+	if err != nil {
+		return errors.New(T("Could not write the repository index: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Mirrored {{.Count}} plugins. Add the mirror with:\n   cf add-plugin-repo {{.RepoName}} {{.URL}}", map[string]interface{}{
+		"Count":    len(mirrored),
+		"RepoName": repo.Name,
+		"URL":      pluginrepo.FileURL(dir),
+	}))
+	return nil
+}
+
+// mirrorBinary downloads a plugin binary, and its signature if there is one,
+// to DIRECTORY/NAME/VERSION/PLATFORM and returns the binary with its URL
+// relative to the index of the mirror
+func (cmd *MirrorPluginRepo) mirrorBinary(transport http.RoundTripper, dir string, plugin clipr.Plugin, binary clipr.Binary) (clipr.Binary, error) {
+	cmd.ui.Say(T("Downloading {{.PluginName}} {{.Version}} for {{.Platform}}...", map[string]interface{}{
+		"PluginName": terminal.EntityNameColor(plugin.Name),
+		"Version":    plugin.Version,
+		"Platform":   binary.Platform,
+	}))
+
+	for _, segment := range []string{plugin.Name, plugin.Version, binary.Platform} {
+		if !isSafePathSegment(segment) {
+			return binary, errors.New(T("Invalid plugin metadata from repo: {{.Value}}", map[string]interface{}{"Value": segment}))
+		}
+	}
+
+	relativeDir := path.Join(plugin.Name, plugin.Version, binary.Platform)
+	binaryDir := filepath.Join(dir, filepath.FromSlash(relativeDir))
+	err := os.MkdirAll(binaryDir, 0755)
+	if err != nil {
+		return binary, err
+	}
+
+	_, filename, err := downloader.NewDownloaderWithTransport(binaryDir, transport).DownloadFile(binary.Url)
+	if err != nil {
+		return binary, errors.New(T("Download of {{.URL}} failed: {{.Error}}", map[string]interface{}{"URL": binary.Url, "Error": err.Error()}))
+	}
+
+	binaryPath := filepath.Join(binaryDir, filename)
+	if !plugininstaller.ChecksumMatches(cmd.ui, cmd.checksum, binaryPath, binary.Checksum) {
+		os.Remove(binaryPath)
+		return binary, errors.New(T("Checksum of {{.URL}} does not match repo metadata", map[string]interface{}{"URL": binary.Url}))
+	}
+
+	signatureDownloader := downloader.NewDownloaderWithTransport(binaryDir, transport)
+	_, signatureFilename, err := signatureDownloader.DownloadFile(binary.Url + ".sig")
+	if err == nil && signatureFilename != filename+".sig" {
+		os.Rename(filepath.Join(binaryDir, signatureFilename), binaryPath+".sig")
+	}
+
+	binary.Url = (&url.URL{Path: path.Join(relativeDir, filename)}).String()
+	return binary, nil
+}
+
+// isSafePathSegment returns whether a name from the repository index can be
+// used as a single element of a path inside the mirror
+func isSafePathSegment(segment string) bool {
+	return segment != "." && segment != ".." && !strings.ContainsAny(segment, `/\`)
+}
@@ -0,0 +1,189 @@
+package pluginrepo_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	actorpluginrepo "github.com/cloudfoundry/cli/cf/actors/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/pluginrepo"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/utils/utilsfakes"
+
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("mirror-plugin-repo", func() {
+	var (
+		ui             *testterm.FakeUI
+		config         coreconfig.Repository
+		fakePluginRepo *pluginrepofakes.FakePluginRepo
+		fakeChecksum   *utilsfakes.FakeChecksum
+		cmd            *pluginrepo.MirrorPluginRepo
+		flagContext    flags.FlagContext
+		testServer     *httptest.Server
+		dir            string
+	)
+
+	BeforeEach(func() {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/bin/echo_linux64":
+				fmt.Fprint(w, "linux binary")
+			case "/bin/echo_linux64.sig":
+				fmt.Fprint(w, "signature")
+			case "/bin/echo_osx":
+				fmt.Fprint(w, "osx binary")
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		})
+		testServer = httptest.NewServer(h)
+
+		var err error
+		dir, err = ioutil.TempDir("", "plugin-mirror")
+		Expect(err).ToNot(HaveOccurred())
+
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"private": {
+				{
+					Name:    "echo",
+					Version: "1.0.0",
+					Binaries: []clipr.Binary{
+						{Platform: "linux64", Url: testServer.URL + "/bin/echo_linux64", Checksum: "linux-checksum"},
+						{Platform: "osx", Url: testServer.URL + "/bin/echo_osx", Checksum: "osx-checksum"},
+					},
+				},
+			},
+		}, []string{})
+
+		fakeChecksum = new(utilsfakes.FakeChecksum)
+		fakeChecksum.CheckSha256Returns(true)
+
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetPluginRepo(models.PluginRepo{Name: "private", URL: testServer.URL, Token: "my-token"})
+
+		deps := commandregistry.Dependency{
+			UI:           ui,
+			Config:       config,
+			PluginRepo:   fakePluginRepo,
+			ChecksumUtil: fakeChecksum,
+		}
+
+		cmd = new(pluginrepo.MirrorPluginRepo)
+		cmd = cmd.SetDependency(deps, false).(*pluginrepo.MirrorPluginRepo)
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(dir)
+	})
+
+	var callMirrorPluginRepo = func(args ...string) error {
+		err := flagContext.Parse(args...)
+		Expect(err).ToNot(HaveOccurred())
+
+		return cmd.Execute(flagContext)
+	}
+
+	var readIndex = func() []clipr.Plugin {
+		contents, err := ioutil.ReadFile(filepath.Join(dir, "list"))
+		Expect(err).ToNot(HaveOccurred())
+
+		index := clipr.PluginsJson{}
+		Expect(json.Unmarshal(contents, &index)).To(Succeed())
+		return index.Plugins
+	}
+
+	It("fetches the plugins of the repo with its credentials", func() {
+		Expect(callMirrorPluginRepo("private", dir)).To(Succeed())
+
+		Expect(fakePluginRepo.GetPluginsCallCount()).To(Equal(1))
+		repos := fakePluginRepo.GetPluginsArgsForCall(0)
+		Expect(repos).To(HaveLen(1))
+		Expect(repos[0].Token).To(Equal("my-token"))
+	})
+
+	It("copies the binaries and signatures of the repo", func() {
+		Expect(callMirrorPluginRepo("private", dir)).To(Succeed())
+
+		contents, err := ioutil.ReadFile(filepath.Join(dir, "echo", "1.0.0", "linux64", "echo_linux64"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("linux binary"))
+
+		contents, err = ioutil.ReadFile(filepath.Join(dir, "echo", "1.0.0", "linux64", "echo_linux64.sig"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("signature"))
+
+		_, err = os.Stat(filepath.Join(dir, "echo", "1.0.0", "osx", "echo_osx"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes an index with binary urls relative to it", func() {
+		Expect(callMirrorPluginRepo("private", dir)).To(Succeed())
+
+		plugins := readIndex()
+		Expect(plugins).To(HaveLen(1))
+		Expect(plugins[0].Name).To(Equal("echo"))
+		Expect(plugins[0].Binaries).To(Equal([]clipr.Binary{
+			{Platform: "linux64", Url: "echo/1.0.0/linux64/echo_linux64", Checksum: "linux-checksum"},
+			{Platform: "osx", Url: "echo/1.0.0/osx/echo_osx", Checksum: "osx-checksum"},
+		}))
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"cf add-plugin-repo private " + actorpluginrepo.FileURL(dir)},
+		))
+	})
+
+	It("only copies the binaries for the given platforms", func() {
+		Expect(callMirrorPluginRepo("private", dir, "--platform", "osx")).To(Succeed())
+
+		plugins := readIndex()
+		Expect(plugins[0].Binaries).To(HaveLen(1))
+		Expect(plugins[0].Binaries[0].Platform).To(Equal("osx"))
+
+		_, err := os.Stat(filepath.Join(dir, "echo", "1.0.0", "linux64"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("returns an error when a checksum does not match", func() {
+		fakeChecksum.CheckSha256Returns(false)
+
+		err := callMirrorPluginRepo("private", dir)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("does not match repo metadata"))
+	})
+
+	It("returns an error when the index names a path outside the mirror", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"private": {{Name: "..", Version: "1.0.0", Binaries: []clipr.Binary{{Platform: "osx", Url: testServer.URL + "/bin/echo_osx"}}}},
+		}, []string{})
+
+		err := callMirrorPluginRepo("private", dir)
+		Expect(err).To(MatchError(ContainSubstring("Invalid plugin metadata from repo: ..")))
+	})
+
+	It("returns an error when the repo does not exist", func() {
+		err := callMirrorPluginRepo("unknown", dir)
+		Expect(err).To(MatchError(ContainSubstring("unknown does not exist as an available plugin repo.")))
+	})
+})
@@ -0,0 +1,73 @@
+package pluginrepo
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/utils"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type PluginKeys struct {
+	ui     terminal.UI
+	config coreconfig.Reader
+}
+
+func init() {
+	commandregistry.Register(&PluginKeys{})
+}
+
+func (cmd *PluginKeys) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "plugin-keys",
+		Description: T("List the public keys trusted to sign plugin binaries"),
+		Usage: []string{
+			T("CF_NAME plugin-keys"),
+		},
+	}
+}
+
+func (cmd *PluginKeys) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+	return reqs
+}
+
+func (cmd *PluginKeys) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *PluginKeys) Execute(c flags.FlagContext) error {
+	keys := cmd.config.TrustedPluginKeys()
+
+	table := cmd.ui.Table([]string{T("Key Name"), T("Fingerprint (SHA-256)")})
+
+	for _, key := range keys {
+		fingerprint, err := utils.PublicKeyFingerprint(key.PublicKey)
+		if err != nil {
+			fingerprint = T("invalid key")
+		}
+		table.Add(key.Name, fingerprint)
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table.Print()
+
+	cmd.ui.Say("")
+	return nil
+}
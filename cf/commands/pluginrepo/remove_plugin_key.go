@@ -0,0 +1,69 @@
+package pluginrepo
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type RemovePluginKey struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&RemovePluginKey{})
+}
+
+func (cmd *RemovePluginKey) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "remove-plugin-key",
+		Description: T("Stop trusting a public key to sign plugin binaries"),
+		Usage: []string{
+			T("CF_NAME remove-plugin-key KEY_NAME"),
+		},
+		Examples: []string{
+			"CF_NAME remove-plugin-key MyCompany",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *RemovePluginKey) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("remove-plugin-key"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *RemovePluginKey) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *RemovePluginKey) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("")
+	keyName := strings.Trim(c.Args()[0], " ")
+
+	for _, key := range cmd.config.TrustedPluginKeys() {
+		if strings.ToLower(key.Name) == strings.ToLower(keyName) {
+			cmd.config.UnSetTrustedPluginKey(key.Name)
+			cmd.ui.Ok()
+			cmd.ui.Say(T("Plugin key {{.KeyName}} is no longer trusted", map[string]interface{}{"KeyName": key.Name}))
+			cmd.ui.Say("")
+			return nil
+		}
+	}
+
+	return errors.New(T("{{.KeyName}} does not exist as a plugin key", map[string]interface{}{"KeyName": keyName}))
+}
@@ -0,0 +1,56 @@
+package pluginrepo_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("remove-plugin-key", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("remove-plugin-key").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetTrustedPluginKey(models.PluginKey{Name: "key1", PublicKey: "public-key-1"})
+		config.SetTrustedPluginKey(models.PluginKey{Name: "key2", PublicKey: "public-key-2"})
+	})
+
+	var callRemovePluginKey = func(args ...string) bool {
+		return testcmd.RunCLICommand("remove-plugin-key", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("removes the key from the config", func() {
+		callRemovePluginKey("KEY1")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}, []string{"Plugin key key1 is no longer trusted"}))
+		Expect(config.TrustedPluginKeys()).To(Equal([]models.PluginKey{{Name: "key2", PublicKey: "public-key-2"}}))
+	})
+
+	It("fails when the key does not exist", func() {
+		callRemovePluginKey("key3")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"key3 does not exist as a plugin key"}))
+		Expect(config.TrustedPluginKeys()).To(HaveLen(2))
+	})
+})
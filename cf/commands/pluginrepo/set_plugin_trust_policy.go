@@ -0,0 +1,102 @@
+package pluginrepo
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/flags"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+type SetPluginTrustPolicy struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&SetPluginTrustPolicy{})
+}
+
+func (cmd *SetPluginTrustPolicy) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository to set the policy for")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "set-plugin-trust-policy",
+		Description: T("Set what happens to plugin binaries that are not signed by a trusted key"),
+		Usage: []string{
+			T(`CF_NAME set-plugin-trust-policy (require-signed | warn | allow) [-r REPO_NAME]
+
+   Without '-r' the policy applies to plugins installed from a URL or a local
+   path, and to repositories without a policy of their own. The policy is
+   'warn' until one is set.`),
+		},
+		Examples: []string{
+			"CF_NAME set-plugin-trust-policy require-signed",
+			"CF_NAME set-plugin-trust-policy allow -r PrivateRepo",
+		},
+		Flags:     fs,
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *SetPluginTrustPolicy) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("set-plugin-trust-policy"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *SetPluginTrustPolicy) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *SetPluginTrustPolicy) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("")
+	policy := strings.ToLower(c.Args()[0])
+
+	valid := false
+	for _, p := range models.PluginTrustPolicies {
+		valid = valid || p == policy
+	}
+	if !valid {
+		return errors.New(T("Invalid trust policy {{.Policy}}, expected one of: {{.Policies}}", map[string]interface{}{
+			"Policy":   c.Args()[0],
+			"Policies": strings.Join(models.PluginTrustPolicies, ", "),
+		}))
+	}
+
+	repoName := c.String("r")
+	if repoName == "" {
+		cmd.config.SetPluginTrustPolicy(policy)
+		cmd.ui.Ok()
+		cmd.ui.Say(T("Plugin trust policy set to {{.Policy}}", map[string]interface{}{"Policy": terminal.EntityNameColor(policy)}))
+		cmd.ui.Say("")
+		return nil
+	}
+
+	for i, repo := range cmd.config.PluginRepos() {
+		if strings.ToLower(repo.Name) == strings.ToLower(repoName) {
+			cmd.config.SetPluginRepoTrustPolicy(i, policy)
+			cmd.ui.Ok()
+			cmd.ui.Say(T("Trust policy of repository {{.RepoName}} set to {{.Policy}}", map[string]interface{}{
+				"RepoName": repo.Name,
+				"Policy":   terminal.EntityNameColor(policy),
+			}))
+			cmd.ui.Say("")
+			return nil
+		}
+	}
+
+	return errors.New(repoName + T(" does not exist as a repo"))
+}
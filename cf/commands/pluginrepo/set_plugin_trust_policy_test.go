@@ -0,0 +1,73 @@
+package pluginrepo_test
+
+import (
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/models"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("set-plugin-trust-policy", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("set-plugin-trust-policy").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://url1.com"})
+	})
+
+	var callSetPluginTrustPolicy = func(args ...string) bool {
+		return testcmd.RunCLICommand("set-plugin-trust-policy", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("sets the default policy", func() {
+		callSetPluginTrustPolicy("require-signed")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}, []string{"Plugin trust policy set to require-signed"}))
+		Expect(config.PluginTrustPolicy()).To(Equal(models.PluginTrustRequireSigned))
+		Expect(config.PluginRepos()[0].TrustPolicy).To(BeEmpty())
+	})
+
+	It("sets the policy of a repository", func() {
+		callSetPluginTrustPolicy("Allow", "-r", "REPO1")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"OK"}, []string{"Trust policy of repository repo1 set to allow"}))
+		Expect(config.PluginRepos()[0].TrustPolicy).To(Equal(models.PluginTrustAllow))
+		Expect(config.PluginTrustPolicy()).To(BeEmpty())
+	})
+
+	It("fails for an unknown repository", func() {
+		callSetPluginTrustPolicy("allow", "-r", "repo2")
+
+		Expect(ui.Outputs).To(ContainSubstrings([]string{"FAILED"}, []string{"repo2 does not exist as a repo"}))
+	})
+
+	It("fails for an unknown policy", func() {
+		callSetPluginTrustPolicy("sometimes")
+
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Invalid trust policy sometimes", "require-signed, warn, allow"},
+		))
+		Expect(config.PluginTrustPolicy()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/replay"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/cf/trace"
+	"github.com/cloudfoundry/cli/flags"
+)
+
+type Replay struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(&Replay{})
+}
+
+func (cmd *Replay) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["port"] = &flags.IntFlag{Name: "port", Usage: T("Port to listen on (Default: a free port)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "replay",
+		Description: T("Serve the responses recorded in a HAR trace from a local stand-in API"),
+		Usage: []string{
+			T(`CF_NAME replay HAR_FILE [--port PORT]
+
+   Record a trace with 'CF_NAME --trace-file out.har COMMAND' or CF_TRACE_FORMAT=har,
+   then replay it and point the CLI at the address printed with 'CF_NAME api'.
+   Requests are answered with the recorded response for the same method and
+   path, in the order they were recorded.`),
+		},
+		Examples: []string{
+			"CF_NAME replay out.har --port 8080",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *Replay) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) []requirements.Requirement {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("replay"))
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs
+}
+
+func (cmd *Replay) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd *Replay) Execute(c flags.FlagContext) error {
+	path := c.Args()[0]
+
+	har, err := trace.ReadHAR(path)
+	if err != nil {
+		return errors.New(T("Error reading HAR file {{.Path}}: {{.Err}}", map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	server := replay.NewServer(har, func(request *http.Request, matched bool) {
+		if matched {
+			cmd.ui.Say("%s %s", request.Method, request.URL.RequestURI())
+		} else {
+			cmd.ui.Warn(T("{{.Method}} {{.URI}} was not recorded", map[string]interface{}{
+				"Method": request.Method,
+				"URI":    request.URL.RequestURI(),
+			}))
+		}
+	})
+
+	return serveLocally(cmd.ui, c.Int("port"), server, func(address string) (string, string) {
+		description := T("Replaying {{.Count}} recorded requests from {{.Path}} on {{.Address}}", map[string]interface{}{
+			"Count":   len(har.Log.Entries),
+			"Path":    terminal.EntityNameColor(path),
+			"Address": terminal.EntityNameColor(address),
+		})
+		return description, "cf api " + address
+	})
+}
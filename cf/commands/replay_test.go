@@ -0,0 +1,73 @@
+package commands_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	testcmd "github.com/cloudfoundry/cli/testhelpers/commands"
+	testreq "github.com/cloudfoundry/cli/testhelpers/requirements"
+	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("replay command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *testreq.FakeReqFactory
+		deps                commandregistry.Dependency
+		tmpDir              string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("replay").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = &testreq.FakeReqFactory{}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "replay-command")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("replay", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when no HAR file is given", func() {
+		runCommand()
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("fails when the HAR file does not exist", func() {
+		runCommand(filepath.Join(tmpDir, "missing.har"))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Error reading HAR file", "missing.har"},
+		))
+	})
+
+	It("fails when the file is not a HAR file", func() {
+		path := filepath.Join(tmpDir, "trace.log")
+		Expect(ioutil.WriteFile(path, []byte("REQUEST: GET /v2/info"), 0600)).To(Succeed())
+
+		runCommand(path)
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Error reading HAR file", "trace.log"},
+		))
+	})
+})
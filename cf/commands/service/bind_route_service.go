@@ -36,6 +36,7 @@ func (cmd *BindRouteService) MetaData() commandregistry.CommandMetadata {
 	fs["parameters"] = &flags.StringFlag{
 		ShortName: "c",
 		Usage:     T("Valid JSON object containing service-specific configuration parameters, provided inline or in a file. For a list of supported configuration parameters, see documentation for the particular service offering."),
+		Sensitive: true,
 	}
 	fs["f"] = &flags.BackwardsCompatibilityFlag{}
 
@@ -50,7 +50,7 @@ func (cmd *UpdateService) MetaData() commandregistry.CommandMetadata {
 
 	fs := make(map[string]flags.FlagSet)
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Change service plan for a service instance")}
-	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
+	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering."), Sensitive: true}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("User provided tags")}
 
 	return commandregistry.CommandMetadata{
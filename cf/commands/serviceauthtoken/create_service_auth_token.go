@@ -29,6 +29,7 @@ func (cmd *CreateServiceAuthTokenFields) MetaData() commandregistry.CommandMetad
 		Usage: []string{
 			T("CF_NAME create-service-auth-token LABEL PROVIDER TOKEN"),
 		},
+		SensitiveArgs: []int{2},
 	}
 }
 
@@ -28,6 +28,7 @@ func (cmd *UpdateServiceAuthTokenFields) MetaData() commandregistry.CommandMetad
 		Usage: []string{
 			T("CF_NAME update-service-auth-token LABEL PROVIDER TOKEN"),
 		},
+		SensitiveArgs: []int{2},
 	}
 }
 
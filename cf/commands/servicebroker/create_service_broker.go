@@ -32,7 +32,8 @@ func (cmd *CreateServiceBroker) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME create-service-broker SERVICE_BROKER USERNAME PASSWORD URL [--space-scoped]"),
 		},
-		Flags: fs,
+		Flags:         fs,
+		SensitiveArgs: []int{2},
 	}
 }
 
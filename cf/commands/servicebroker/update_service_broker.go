@@ -27,6 +27,7 @@ func (cmd *UpdateServiceBroker) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME update-service-broker SERVICE_BROKER USERNAME PASSWORD URL"),
 		},
+		SensitiveArgs: []int{2},
 	}
 }
 
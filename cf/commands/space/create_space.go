@@ -132,14 +132,17 @@ func (cmd *CreateSpace) Execute(c flags.FlagContext) error {
 	}
 	cmd.ui.Ok()
 
-	err = cmd.spaceRoleSetter.SetSpaceRole(space, orgGUID, orgName, models.RoleSpaceManager, cmd.config.UserGUID(), cmd.config.Username())
-	if err != nil {
-		return err
-	}
+	// sessions authenticated with client credentials have no user to assign roles to
+	if cmd.config.UserGUID() != "" {
+		err = cmd.spaceRoleSetter.SetSpaceRole(space, orgGUID, orgName, models.RoleSpaceManager, cmd.config.UserGUID(), cmd.config.Username())
+		if err != nil {
+			return err
+		}
 
-	err = cmd.spaceRoleSetter.SetSpaceRole(space, orgGUID, orgName, models.RoleSpaceDeveloper, cmd.config.UserGUID(), cmd.config.Username())
-	if err != nil {
-		return err
+		err = cmd.spaceRoleSetter.SetSpaceRole(space, orgGUID, orgName, models.RoleSpaceDeveloper, cmd.config.UserGUID(), cmd.config.Username())
+		if err != nil {
+			return err
+		}
 	}
 
 	cmd.ui.Say(T("\nTIP: Use '{{.CFTargetCommand}}' to target new space",
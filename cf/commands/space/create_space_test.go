@@ -134,6 +134,25 @@ var _ = Describe("create-space command", func() {
 		Expect(role).To(Equal(models.RoleSpaceManager))
 	})
 
+	Context("when the session has no user, as with client credentials", func() {
+		BeforeEach(func() {
+			configRepo = testconfig.NewRepositoryWithAccessToken(coreconfig.TokenInfo{ClientID: "my-client"})
+			configRepo.SetOrganizationFields(configOrg)
+		})
+
+		It("creates the space without assigning roles", func() {
+			runCommand("my-space")
+			Expect(ui.Outputs).To(ContainSubstrings(
+				[]string{"Creating space", "my-space", "my-org", "my-client"},
+				[]string{"OK"},
+				[]string{"TIP"},
+			))
+			Expect(ui.Outputs).ToNot(ContainSubstrings([]string{"Assigning"}))
+			Expect(spaceRepo.CreateCallCount()).To(Equal(1))
+			Expect(userRepo.SetSpaceRoleByGUIDCallCount()).To(BeZero())
+		})
+	})
+
 	It("warns the user when a space with that name already exists", func() {
 		spaceRepo.CreateReturns(models.Space{}, errors.NewHTTPError(400, errors.SpaceNameTaken, "Space already exists"))
 		runCommand("my-space")
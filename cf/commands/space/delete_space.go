@@ -1,6 +1,8 @@
 package space
 
 import (
+	"path"
+
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api/spaces"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
@@ -26,12 +28,13 @@ func init() {
 func (cmd *DeleteSpace) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["no-wait"] = &flags.BoolFlag{Name: "no-wait", Usage: T("Return as soon as the deletion has started, printing the job to follow with 'CF_NAME job'")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-space",
 		Description: T("Delete a space"),
 		Usage: []string{
-			T("CF_NAME delete-space SPACE [-f]"),
+			T("CF_NAME delete-space SPACE [-f] [--no-wait]"),
 		},
 		Flags: fs,
 	}
@@ -77,13 +80,27 @@ func (cmd *DeleteSpace) Execute(c flags.FlagContext) error {
 
 	space := cmd.spaceReq.GetSpace()
 
-	err := cmd.spaceRepo.Delete(space.GUID)
+	var err error
+	jobURL := ""
+	if c.Bool("no-wait") {
+		jobURL, err = cmd.spaceRepo.DeleteWithoutWaiting(space.GUID)
+	} else {
+		err = cmd.spaceRepo.Delete(space.GUID)
+	}
 	if err != nil {
 		return err
 	}
 
 	cmd.ui.Ok()
 
+	if jobURL != "" {
+		cmd.ui.Say(T("Deletion continues in job {{.JobURL}}\nUse '{{.Command}}' to follow it",
+			map[string]interface{}{
+				"JobURL":  jobURL,
+				"Command": terminal.CommandColor(cf.Name + " job " + path.Base(jobURL) + " --wait"),
+			}))
+	}
+
 	if cmd.config.SpaceFields().GUID == space.GUID {
 		cmd.config.SetSpaceFields(models.SpaceFields{})
 		cmd.ui.Say(T("TIP: No space targeted, use '{{.CfTargetCommand}}' to target a space",
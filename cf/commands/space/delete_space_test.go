@@ -95,6 +95,18 @@ var _ = Describe("delete-space command", func() {
 		Expect(spaceRepo.DeleteArgsForCall(0)).To(Equal("space-to-delete-guid"))
 	})
 
+	It("returns the deletion job without waiting when --no-wait is given", func() {
+		spaceRepo.DeleteWithoutWaitingReturns("/v2/jobs/the-job-guid", nil)
+		runCommand("-f", "--no-wait", "space-to-delete")
+
+		Expect(spaceRepo.DeleteCallCount()).To(Equal(0))
+		Expect(spaceRepo.DeleteWithoutWaitingArgsForCall(0)).To(Equal("space-to-delete-guid"))
+		Expect(ui.Outputs).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"Deletion continues in job", "/v2/jobs/the-job-guid"},
+		))
+	})
+
 	It("clears the space from the config, when deleting the space currently targeted", func() {
 		config.SetSpaceFields(space.SpaceFields)
 		runCommand("-f", "space-to-delete")
@@ -29,6 +29,7 @@ func (cmd *CreateUser) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME create-user USERNAME PASSWORD"),
 		},
+		SensitiveArgs: []int{1},
 	}
 }
 
@@ -1,8 +1,11 @@
 package configuration
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+
+	"github.com/cloudfoundry/cli/cf/configuration/safefile"
 )
 
 const (
@@ -26,8 +29,31 @@ type DataInterface interface {
 	JSONUnmarshalV3([]byte) error
 }
 
+// LockingPersistor is implemented by persistors that can keep other
+// processes out while more than one Load or Save happens. WithLock calls fn
+// with a persistor that loads and saves without taking the lock again.
+type LockingPersistor interface {
+	Persistor
+	WithLock(fn func(Persistor) error) error
+}
+
+// CorruptFileError is returned by Load when the file exists but cannot be
+// parsed. The file is left untouched so that it can be inspected or repaired.
+type CorruptFileError struct {
+	Path string
+	Err  error
+}
+
+func (e *CorruptFileError) Error() string {
+	return fmt.Sprintf("%s is corrupt (%s). Fix or remove the file and try again.", e.Path, e.Err)
+}
+
+// DiskPersistor reads and writes a JSON file. Concurrent processes are
+// serialised with an advisory lock on a sibling ".lock" file, and files are
+// replaced atomically so that readers never observe a partial write.
 type DiskPersistor struct {
 	filePath string
+	locked   bool
 }
 
 func NewDiskPersistor(path string) DiskPersistor {
@@ -49,34 +75,48 @@ func (dp DiskPersistor) Delete() {
 }
 
 func (dp DiskPersistor) Load(data DataInterface) error {
-	err := dp.read(data)
-	if os.IsPermission(err) {
-		return err
-	}
-
-	if err != nil {
-		err = dp.write(data)
-	}
-	return err
+	return dp.WithLock(func(Persistor) error {
+		jsonBytes, err := ioutil.ReadFile(dp.filePath)
+		if os.IsNotExist(err) || (err == nil && len(jsonBytes) == 0) {
+			return dp.write(data)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = data.JSONUnmarshalV3(jsonBytes)
+		if err != nil {
+			return &CorruptFileError{Path: dp.filePath, Err: err}
+		}
+		return nil
+	})
 }
 
 func (dp DiskPersistor) Save(data DataInterface) error {
-	return dp.write(data)
+	return dp.WithLock(func(Persistor) error {
+		return dp.write(data)
+	})
 }
 
-func (dp DiskPersistor) read(data DataInterface) error {
+func (dp DiskPersistor) WithLock(fn func(Persistor) error) error {
+	if dp.locked {
+		return fn(dp)
+	}
+
 	err := dp.makeDirectory()
 	if err != nil {
 		return err
 	}
 
-	jsonBytes, err := ioutil.ReadFile(dp.filePath)
+	unlock, err := safefile.Lock(dp.filePath)
 	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	err = data.JSONUnmarshalV3(jsonBytes)
-	return err
+	locked := dp
+	locked.locked = true
+	return fn(locked)
 }
 
 func (dp DiskPersistor) write(data DataInterface) error {
@@ -85,6 +125,5 @@ func (dp DiskPersistor) write(data DataInterface) error {
 		return err
 	}
 
-	err = ioutil.WriteFile(dp.filePath, bytes, filePermissions)
-	return err
+	return safefile.WriteFile(dp.filePath, bytes, filePermissions)
 }
@@ -2,8 +2,14 @@ package configuration_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	. "github.com/cloudfoundry/cli/cf/configuration"
 	. "github.com/onsi/ginkgo"
@@ -30,6 +36,7 @@ var _ = Describe("DiskPersistor", func() {
 
 	AfterEach(func() {
 		os.Remove(tmpFile.Name())
+		os.Remove(tmpFile.Name() + ".lock")
 	})
 
 	Describe(".Delete", func() {
@@ -54,6 +61,70 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(dataBytes)).To(ContainSubstring(d.Info))
 		})
+
+		It("replaces the file without leaving temporary files behind", func() {
+			err := diskPersistor.Save(&data{Info: "first"})
+			Expect(err).ToNot(HaveOccurred())
+			err = diskPersistor.Save(&data{Info: "second"})
+			Expect(err).ToNot(HaveOccurred())
+
+			dataBytes, err := ioutil.ReadFile(tmpFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(dataBytes)).To(ContainSubstring("second"))
+			Expect(string(dataBytes)).ToNot(ContainSubstring("first"))
+
+			info, err := os.Stat(tmpFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(tmpFile.Name()), "."+filepath.Base(tmpFile.Name())+".tmp*"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leftovers).To(BeEmpty())
+		})
+
+		It("never leaves a partially written file when saving concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					persistor := NewDiskPersistor(tmpFile.Name())
+					Expect(persistor.Save(&data{Info: fmt.Sprintf("writer %d %s", i, strings.Repeat("x", 4096))})).To(Succeed())
+
+					d := &data{}
+					Expect(persistor.Load(d)).To(Succeed())
+					Expect(d.Info).To(HavePrefix("writer "))
+				}(i)
+			}
+			wg.Wait()
+		})
+
+		Context("when another process holds the lock", func() {
+			var cmd *exec.Cmd
+
+			BeforeEach(func() {
+				if _, err := exec.LookPath("flock"); err != nil {
+					Skip("flock(1) is not available")
+				}
+
+				cmd = exec.Command("flock", tmpFile.Name()+".lock", "sleep", "1")
+				Expect(cmd.Start()).To(Succeed())
+
+				Eventually(func() error {
+					return exec.Command("flock", "-n", tmpFile.Name()+".lock", "true").Run()
+				}).Should(HaveOccurred())
+			})
+
+			It("waits for the lock to be released", func() {
+				started := time.Now()
+				err := diskPersistor.Save(&data{Info: "after lock"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(time.Since(started)).To(BeNumerically(">", 100*time.Millisecond))
+				Expect(cmd.Wait()).To(Succeed())
+			})
+		})
 	})
 
 	Describe(".Load", func() {
@@ -75,6 +146,34 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(d.Info).To(Equal("test string"))
 		})
+
+		It("writes the data when the file does not exist", func() {
+			os.Remove(tmpFile.Name())
+
+			err := diskPersistor.Load(&data{Info: "defaults"})
+			Expect(err).ToNot(HaveOccurred())
+
+			dataBytes, err := ioutil.ReadFile(tmpFile.Name())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(dataBytes)).To(ContainSubstring("defaults"))
+		})
+
+		Context("when the file is corrupt", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(tmpFile.Name(), []byte(`{"Info":"trunc`), 0600)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns an error and leaves the file alone", func() {
+				err := diskPersistor.Load(&data{})
+				Expect(err).To(BeAssignableToTypeOf(&CorruptFileError{}))
+				Expect(err.Error()).To(ContainSubstring(tmpFile.Name() + " is corrupt"))
+
+				dataBytes, err := ioutil.ReadFile(tmpFile.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(dataBytes)).To(Equal(`{"Info":"trunc`))
+			})
+		})
 	})
 })
 
@@ -18,6 +18,32 @@ type AuthPrompt struct {
 	DisplayName string
 }
 
+// SavedContext is a named snapshot of the session-specific parts of Data, allowing
+// users to switch between several foundations without logging in again.
+type SavedContext struct {
+	Target                   string
+	APIVersion               string
+	AuthorizationEndpoint    string
+	LoggregatorEndPoint      string
+	DopplerEndPoint          string
+	UaaEndpoint              string
+	RoutingAPIEndpoint       string
+	AccessToken              string
+	SSHOAuthClient           string
+	UAAOAuthClient           string
+	UAAOAuthClientSecret     string
+	UAAGrantType             string
+	RefreshToken             string
+	OrganizationFields       models.OrganizationFields
+	SpaceFields              models.SpaceFields
+	SSLDisabled              bool
+	CACertFile               string
+	ClientCertFile           string
+	ClientKeyFile            string
+	MinCLIVersion            string
+	MinRecommendedCLIVersion string
+}
+
 type Data struct {
 	ConfigVersion            int
 	Target                   string
@@ -29,17 +55,33 @@ type Data struct {
 	RoutingAPIEndpoint       string
 	AccessToken              string
 	SSHOAuthClient           string
+	UAAOAuthClient           string
+	UAAOAuthClientSecret     string
+	UAAGrantType             string
 	RefreshToken             string
 	OrganizationFields       models.OrganizationFields
 	SpaceFields              models.SpaceFields
 	SSLDisabled              bool
+	CACertFile               string
+	ClientCertFile           string
+	ClientKeyFile            string
 	AsyncTimeout             uint
+	RequestRetries           uint
+	RequestRateLimit         uint
+	MaxConcurrentRequests    uint
+	Proxy                    string
+	CacheTTL                 uint
 	Trace                    string
 	ColorEnabled             string
 	Locale                   string
 	PluginRepos              []models.PluginRepo
+	PluginTrustPolicy        string
+	TrustedPluginKeys        []models.PluginKey
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	CurrentContext           string
+	Contexts                 map[string]SavedContext
+	SecretBackend            string
 }
 
 func NewData() (data *Data) {
@@ -47,6 +89,56 @@ func NewData() (data *Data) {
 	return
 }
 
+func (d *Data) currentSession() SavedContext {
+	return SavedContext{
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndPoint:      d.LoggregatorEndPoint,
+		DopplerEndPoint:          d.DopplerEndPoint,
+		UaaEndpoint:              d.UaaEndpoint,
+		RoutingAPIEndpoint:       d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		UAAOAuthClient:           d.UAAOAuthClient,
+		UAAOAuthClientSecret:     d.UAAOAuthClientSecret,
+		UAAGrantType:             d.UAAGrantType,
+		RefreshToken:             d.RefreshToken,
+		OrganizationFields:       d.OrganizationFields,
+		SpaceFields:              d.SpaceFields,
+		SSLDisabled:              d.SSLDisabled,
+		CACertFile:               d.CACertFile,
+		ClientCertFile:           d.ClientCertFile,
+		ClientKeyFile:            d.ClientKeyFile,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+func (d *Data) setSession(ctx SavedContext) {
+	d.Target = ctx.Target
+	d.APIVersion = ctx.APIVersion
+	d.AuthorizationEndpoint = ctx.AuthorizationEndpoint
+	d.LoggregatorEndPoint = ctx.LoggregatorEndPoint
+	d.DopplerEndPoint = ctx.DopplerEndPoint
+	d.UaaEndpoint = ctx.UaaEndpoint
+	d.RoutingAPIEndpoint = ctx.RoutingAPIEndpoint
+	d.AccessToken = ctx.AccessToken
+	d.SSHOAuthClient = ctx.SSHOAuthClient
+	d.UAAOAuthClient = ctx.UAAOAuthClient
+	d.UAAOAuthClientSecret = ctx.UAAOAuthClientSecret
+	d.UAAGrantType = ctx.UAAGrantType
+	d.RefreshToken = ctx.RefreshToken
+	d.OrganizationFields = ctx.OrganizationFields
+	d.SpaceFields = ctx.SpaceFields
+	d.SSLDisabled = ctx.SSLDisabled
+	d.CACertFile = ctx.CACertFile
+	d.ClientCertFile = ctx.ClientCertFile
+	d.ClientKeyFile = ctx.ClientKeyFile
+	d.MinCLIVersion = ctx.MinCLIVersion
+	d.MinRecommendedCLIVersion = ctx.MinRecommendedCLIVersion
+}
+
 func (d *Data) JSONMarshalV3() (output []byte, err error) {
 	d.ConfigVersion = 3
 	return json.MarshalIndent(d, "", "  ")
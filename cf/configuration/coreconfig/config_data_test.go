@@ -21,6 +21,9 @@ var _ = Describe("V3 Config files", func() {
 		"RoutingAPIEndpoint": "routing-api.example.com",
 		"AccessToken": "the-access-token",
 		"SSHOAuthClient": "ssh-oauth-client-id",
+		"UAAOAuthClient": "cf",
+		"UAAOAuthClientSecret": "",
+		"UAAGrantType": "",
 		"RefreshToken": "the-refresh-token",
 		"OrganizationFields": {
 			"GUID": "the-org-guid",
@@ -41,18 +44,77 @@ var _ = Describe("V3 Config files", func() {
 			"AllowSSH": false
 		},
 		"SSLDisabled": true,
+		"CACertFile": "/etc/cf/ca.pem",
+		"ClientCertFile": "/etc/cf/client.pem",
+		"ClientKeyFile": "/etc/cf/client-key.pem",
 		"AsyncTimeout": 1000,
+		"RequestRetries": 3,
+		"RequestRateLimit": 20,
+		"MaxConcurrentRequests": 4,
+		"Proxy": "socks5://proxy.example.com:1080",
+		"CacheTTL": 60,
 		"Trace": "path/to/some/file",
 		"ColorEnabled": "true",
 		"Locale": "fr_FR",
 		"PluginRepos": [
 		{
 			"Name": "repo1",
-			"URL": "http://repo.com"
+			"URL": "http://repo.com",
+			"TrustPolicy": "require-signed"
+		}
+		],
+		"PluginTrustPolicy": "warn",
+		"TrustedPluginKeys": [
+		{
+			"Name": "key1",
+			"PublicKey": "the-public-key"
 		}
 		],
 		"MinCLIVersion": "6.0.0",
-		"MinRecommendedCLIVersion": "6.9.0"
+		"MinRecommendedCLIVersion": "6.9.0",
+		"CurrentContext": "prod",
+		"Contexts": {
+			"prod": {
+				"Target": "api.example.com",
+				"APIVersion": "3",
+				"AuthorizationEndpoint": "auth.example.com",
+				"LoggregatorEndPoint": "loggregator.example.com",
+				"DopplerEndPoint": "doppler.example.com",
+				"UaaEndpoint": "uaa.example.com",
+				"RoutingAPIEndpoint": "routing-api.example.com",
+				"AccessToken": "the-access-token",
+				"SSHOAuthClient": "ssh-oauth-client-id",
+				"UAAOAuthClient": "cf",
+				"UAAOAuthClientSecret": "",
+				"UAAGrantType": "",
+				"RefreshToken": "the-refresh-token",
+				"OrganizationFields": {
+					"GUID": "the-org-guid",
+					"Name": "the-org",
+					"QuotaDefinition": {
+						"name":"",
+						"memory_limit":0,
+						"instance_memory_limit":0,
+						"total_routes":0,
+						"total_services":0,
+						"non_basic_services_allowed": false,
+						"app_instance_limit":0
+					}
+				},
+				"SpaceFields": {
+					"GUID": "the-space-guid",
+					"Name": "the-space",
+					"AllowSSH": false
+				},
+				"SSLDisabled": true,
+				"CACertFile": "/etc/cf/prod-ca.pem",
+				"ClientCertFile": "",
+				"ClientKeyFile": "",
+				"MinCLIVersion": "6.0.0",
+				"MinRecommendedCLIVersion": "6.9.0"
+			}
+		},
+		"SecretBackend": "encrypted-file"
 	}`
 
 	// V2 by virtue of ConfigVersion only
@@ -68,6 +130,9 @@ var _ = Describe("V3 Config files", func() {
 		"RoutingAPIEndpoint": "routing-api.example.com",
 		"AccessToken": "the-access-token",
 		"SSHOAuthClient": "ssh-oauth-client-id",
+		"UAAOAuthClient": "cf",
+		"UAAOAuthClientSecret": "",
+		"UAAGrantType": "",
 		"RefreshToken": "the-refresh-token",
 		"OrganizationFields": {
 			"GUID": "the-org-guid",
@@ -94,7 +159,15 @@ var _ = Describe("V3 Config files", func() {
 		"PluginRepos": [
 		{
 			"Name": "repo1",
-			"URL": "http://repo.com"
+			"URL": "http://repo.com",
+			"TrustPolicy": "require-signed"
+		}
+		],
+		"PluginTrustPolicy": "warn",
+		"TrustedPluginKeys": [
+		{
+			"Name": "key1",
+			"PublicKey": "the-public-key"
 		}
 		],
 		"MinCLIVersion": "6.0.0",
@@ -114,6 +187,7 @@ var _ = Describe("V3 Config files", func() {
 				AccessToken:              "the-access-token",
 				RefreshToken:             "the-refresh-token",
 				SSHOAuthClient:           "ssh-oauth-client-id",
+				UAAOAuthClient:           "cf",
 				MinCLIVersion:            "6.0.0",
 				MinRecommendedCLIVersion: "6.9.0",
 				OrganizationFields: models.OrganizationFields{
@@ -124,17 +198,62 @@ var _ = Describe("V3 Config files", func() {
 					GUID: "the-space-guid",
 					Name: "the-space",
 				},
-				SSLDisabled:  true,
-				Trace:        "path/to/some/file",
-				AsyncTimeout: 1000,
-				ColorEnabled: "true",
-				Locale:       "fr_FR",
+				SSLDisabled:           true,
+				CACertFile:            "/etc/cf/ca.pem",
+				ClientCertFile:        "/etc/cf/client.pem",
+				ClientKeyFile:         "/etc/cf/client-key.pem",
+				Trace:                 "path/to/some/file",
+				AsyncTimeout:          1000,
+				RequestRetries:        3,
+				RequestRateLimit:      20,
+				MaxConcurrentRequests: 4,
+				Proxy:                 "socks5://proxy.example.com:1080",
+				CacheTTL:              60,
+				ColorEnabled:          "true",
+				Locale:                "fr_FR",
 				PluginRepos: []models.PluginRepo{
 					{
-						Name: "repo1",
-						URL:  "http://repo.com",
+						Name:        "repo1",
+						URL:         "http://repo.com",
+						TrustPolicy: "require-signed",
 					},
 				},
+				PluginTrustPolicy: "warn",
+				TrustedPluginKeys: []models.PluginKey{
+					{
+						Name:      "key1",
+						PublicKey: "the-public-key",
+					},
+				},
+				CurrentContext: "prod",
+				Contexts: map[string]coreconfig.SavedContext{
+					"prod": {
+						Target:                   "api.example.com",
+						APIVersion:               "3",
+						AuthorizationEndpoint:    "auth.example.com",
+						LoggregatorEndPoint:      "loggregator.example.com",
+						RoutingAPIEndpoint:       "routing-api.example.com",
+						DopplerEndPoint:          "doppler.example.com",
+						UaaEndpoint:              "uaa.example.com",
+						AccessToken:              "the-access-token",
+						RefreshToken:             "the-refresh-token",
+						SSHOAuthClient:           "ssh-oauth-client-id",
+						UAAOAuthClient:           "cf",
+						MinCLIVersion:            "6.0.0",
+						MinRecommendedCLIVersion: "6.9.0",
+						OrganizationFields: models.OrganizationFields{
+							GUID: "the-org-guid",
+							Name: "the-org",
+						},
+						SpaceFields: models.SpaceFields{
+							GUID: "the-space-guid",
+							Name: "the-space",
+						},
+						SSLDisabled: true,
+						CACertFile:  "/etc/cf/prod-ca.pem",
+					},
+				},
+				SecretBackend: "encrypted-file",
 			}
 
 			jsonData, err := data.JSONMarshalV3()
@@ -164,6 +283,7 @@ var _ = Describe("V3 Config files", func() {
 				AccessToken:              "the-access-token",
 				RefreshToken:             "the-refresh-token",
 				SSHOAuthClient:           "ssh-oauth-client-id",
+				UAAOAuthClient:           "cf",
 				MinCLIVersion:            "6.0.0",
 				MinRecommendedCLIVersion: "6.9.0",
 				OrganizationFields: models.OrganizationFields{
@@ -174,17 +294,62 @@ var _ = Describe("V3 Config files", func() {
 					GUID: "the-space-guid",
 					Name: "the-space",
 				},
-				SSLDisabled:  true,
-				Trace:        "path/to/some/file",
-				AsyncTimeout: 1000,
-				ColorEnabled: "true",
-				Locale:       "fr_FR",
+				SSLDisabled:           true,
+				CACertFile:            "/etc/cf/ca.pem",
+				ClientCertFile:        "/etc/cf/client.pem",
+				ClientKeyFile:         "/etc/cf/client-key.pem",
+				Trace:                 "path/to/some/file",
+				AsyncTimeout:          1000,
+				RequestRetries:        3,
+				RequestRateLimit:      20,
+				MaxConcurrentRequests: 4,
+				Proxy:                 "socks5://proxy.example.com:1080",
+				CacheTTL:              60,
+				ColorEnabled:          "true",
+				Locale:                "fr_FR",
 				PluginRepos: []models.PluginRepo{
 					{
-						Name: "repo1",
-						URL:  "http://repo.com",
+						Name:        "repo1",
+						URL:         "http://repo.com",
+						TrustPolicy: "require-signed",
+					},
+				},
+				PluginTrustPolicy: "warn",
+				TrustedPluginKeys: []models.PluginKey{
+					{
+						Name:      "key1",
+						PublicKey: "the-public-key",
+					},
+				},
+				CurrentContext: "prod",
+				Contexts: map[string]coreconfig.SavedContext{
+					"prod": {
+						Target:                   "api.example.com",
+						APIVersion:               "3",
+						AuthorizationEndpoint:    "auth.example.com",
+						LoggregatorEndPoint:      "loggregator.example.com",
+						RoutingAPIEndpoint:       "routing-api.example.com",
+						DopplerEndPoint:          "doppler.example.com",
+						UaaEndpoint:              "uaa.example.com",
+						AccessToken:              "the-access-token",
+						RefreshToken:             "the-refresh-token",
+						SSHOAuthClient:           "ssh-oauth-client-id",
+						UAAOAuthClient:           "cf",
+						MinCLIVersion:            "6.0.0",
+						MinRecommendedCLIVersion: "6.9.0",
+						OrganizationFields: models.OrganizationFields{
+							GUID: "the-org-guid",
+							Name: "the-org",
+						},
+						SpaceFields: models.SpaceFields{
+							GUID: "the-space-guid",
+							Name: "the-space",
+						},
+						SSLDisabled: true,
+						CACertFile:  "/etc/cf/prod-ca.pem",
 					},
 				},
+				SecretBackend: "encrypted-file",
 			}
 
 			actualData := coreconfig.NewData()
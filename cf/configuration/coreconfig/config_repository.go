@@ -1,20 +1,37 @@
 package coreconfig
 
 import (
+	"errors"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/models"
 )
 
+const (
+	DefaultUAAOAuthClient = "cf"
+
+	ClientCredentialsGrantType = "client_credentials"
+)
+
 type ConfigRepository struct {
 	data      *Data
 	mutex     *sync.RWMutex
 	initOnce  *sync.Once
 	persistor configuration.Persistor
 	onError   func(error)
+
+	// set while a context is overridden for the lifetime of this process; the
+	// persisted current session is kept aside so that it is written back intact
+	overrideContext string
+	savedSession    SavedContext
+	savedContext    string
 }
 
 type CCInfo struct {
@@ -27,11 +44,17 @@ type CCInfo struct {
 	RoutingAPIEndpoint       string `json:"routing_endpoint"`
 }
 
-func NewRepositoryFromFilepath(filepath string, errorHandler func(error)) Repository {
+func NewRepositoryFromFilepath(configPath string, errorHandler func(error)) Repository {
 	if errorHandler == nil {
 		return nil
 	}
-	return NewRepositoryFromPersistor(configuration.NewDiskPersistor(filepath), errorHandler)
+
+	newSecretStore := func(backend string) (secrets.Store, error) {
+		return secrets.NewStore(backend, filepath.Dir(configPath))
+	}
+	persistor := NewSecretPersistor(configuration.NewDiskPersistor(configPath), configPath, newSecretStore)
+
+	return NewRepositoryFromPersistor(persistor, errorHandler)
 }
 
 func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler func(error)) Repository {
@@ -65,6 +88,9 @@ type Reader interface {
 	RoutingAPIEndpoint() string
 	AccessToken() string
 	SSHOAuthClient() string
+	UAAOAuthClient() string
+	UAAOAuthClientSecret() string
+	UAAGrantType() string
 	RefreshToken() string
 
 	OrganizationFields() models.OrganizationFields
@@ -78,12 +104,20 @@ type Reader interface {
 	UserEmail() string
 	IsLoggedIn() bool
 	IsSSLDisabled() bool
+	CACertFile() string
+	ClientCertFile() string
+	ClientKeyFile() string
 	IsMinAPIVersion(semver.Version) bool
 	IsMinCLIVersion(string) bool
 	MinCLIVersion() string
 	MinRecommendedCLIVersion() string
 
 	AsyncTimeout() uint
+	RequestRetries() uint
+	RequestRateLimit() uint
+	MaxConcurrentRequests() uint
+	Proxy() string
+	CacheTTL() uint
 	Trace() string
 
 	ColorEnabled() string
@@ -91,6 +125,13 @@ type Reader interface {
 	Locale() string
 
 	PluginRepos() []models.PluginRepo
+	PluginTrustPolicy() string
+	TrustedPluginKeys() []models.PluginKey
+
+	SecretBackend() string
+
+	CurrentContext() string
+	Contexts() map[string]SavedContext
 }
 
 //go:generate counterfeiter . ReadWriter
@@ -109,16 +150,35 @@ type ReadWriter interface {
 	SetRoutingAPIEndpoint(string)
 	SetAccessToken(string)
 	SetSSHOAuthClient(string)
+	SetUAAOAuthClient(string)
+	SetUAAOAuthClientSecret(string)
+	SetUAAGrantType(string)
 	SetRefreshToken(string)
 	SetOrganizationFields(models.OrganizationFields)
 	SetSpaceFields(models.SpaceFields)
 	SetSSLDisabled(bool)
+	SetCACertFile(string)
+	SetClientCertFile(string)
+	SetClientKeyFile(string)
 	SetAsyncTimeout(uint)
+	SetRequestRetries(uint)
+	SetRequestRateLimit(uint)
+	SetMaxConcurrentRequests(uint)
+	SetProxy(string)
+	SetCacheTTL(uint)
 	SetTrace(string)
 	SetColorEnabled(string)
 	SetLocale(string)
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
+	SetPluginRepoTrustPolicy(int, string)
+	SetPluginTrustPolicy(string)
+	SetTrustedPluginKey(models.PluginKey)
+	UnSetTrustedPluginKey(string)
+	SetSecretBackend(string)
+	SaveContext(string)
+	UseContext(string) error
+	OverrideContext(string) error
 }
 
 //go:generate counterfeiter . Repository
@@ -147,19 +207,89 @@ func (c *ConfigRepository) read(cb func()) {
 	cb()
 }
 
+// write calls cb to change the data and saves it. When the persistor can be
+// locked, the file is read again under its lock first, so that changes saved
+// by other processes since this one loaded it, like refreshed tokens, are
+// kept.
 func (c *ConfigRepository) write(cb func()) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	c.init()
 
-	cb()
+	locking, ok := c.persistor.(configuration.LockingPersistor)
+	if !ok {
+		cb()
+
+		err := c.save(c.persistor)
+		if err != nil {
+			c.onError(err)
+		}
+		return
+	}
+
+	err := locking.WithLock(func(persistor configuration.Persistor) error {
+		err := c.reload(persistor)
+		cb()
+		if err != nil {
+			return err
+		}
 
-	err := c.persistor.Save(c.data)
+		return c.save(persistor)
+	})
 	if err != nil {
 		c.onError(err)
 	}
 }
 
+// reload replaces the data with what persistor loads, keeping the session of
+// an overridden context. It must be called with the write lock held.
+func (c *ConfigRepository) reload(persistor configuration.Persistor) error {
+	data := NewData()
+	err := persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	if c.overrideContext != "" {
+		if data.Contexts == nil {
+			data.Contexts = map[string]SavedContext{}
+		}
+
+		c.savedSession = data.currentSession()
+		c.savedContext = data.CurrentContext
+		data.setSession(c.data.currentSession())
+		data.CurrentContext = c.overrideContext
+	}
+
+	*c.data = *data
+	return nil
+}
+
+func (c *ConfigRepository) save(persistor configuration.Persistor) error {
+	if c.overrideContext == "" {
+		return persistor.Save(c.data)
+	}
+
+	c.data.Contexts[c.overrideContext] = c.data.currentSession()
+
+	persisted := *c.data
+	persisted.setSession(c.savedSession)
+	persisted.CurrentContext = c.savedContext
+	return persistor.Save(&persisted)
+}
+
+// endOverride must be called with the write lock held
+func (c *ConfigRepository) endOverride() {
+	if c.overrideContext == "" {
+		return
+	}
+
+	c.data.Contexts[c.overrideContext] = c.data.currentSession()
+	c.data.setSession(c.savedSession)
+	c.data.CurrentContext = c.savedContext
+	c.overrideContext = ""
+}
+
 // CLOSERS
 
 func (c *ConfigRepository) Close() {
@@ -247,6 +377,30 @@ func (c *ConfigRepository) SSHOAuthClient() (clientID string) {
 	return
 }
 
+func (c *ConfigRepository) UAAOAuthClient() (clientID string) {
+	c.read(func() {
+		clientID = c.data.UAAOAuthClient
+	})
+	if clientID == "" {
+		clientID = DefaultUAAOAuthClient
+	}
+	return
+}
+
+func (c *ConfigRepository) UAAOAuthClientSecret() (clientSecret string) {
+	c.read(func() {
+		clientSecret = c.data.UAAOAuthClientSecret
+	})
+	return
+}
+
+func (c *ConfigRepository) UAAGrantType() (grantType string) {
+	c.read(func() {
+		grantType = c.data.UAAGrantType
+	})
+	return
+}
+
 func (c *ConfigRepository) RefreshToken() (refreshToken string) {
 	c.read(func() {
 		refreshToken = c.data.RefreshToken
@@ -284,11 +438,27 @@ func (c *ConfigRepository) UserGUID() (guid string) {
 
 func (c *ConfigRepository) Username() (name string) {
 	c.read(func() {
-		name = NewTokenInfo(c.data.AccessToken).Username
+		name = usernameFromAccessToken(c.data.AccessToken)
 	})
 	return
 }
 
+// Username returns the name of the user the context is logged in as
+func (ctx SavedContext) Username() string {
+	return usernameFromAccessToken(ctx.AccessToken)
+}
+
+// usernameFromAccessToken returns the user name from the access token, or the
+// client ID when the token was obtained with a grant that has no user (e.g.
+// client_credentials).
+func usernameFromAccessToken(accessToken string) string {
+	info := NewTokenInfo(accessToken)
+	if info.Username == "" {
+		return info.ClientID
+	}
+	return info.Username
+}
+
 func (c *ConfigRepository) IsLoggedIn() (loggedIn bool) {
 	c.read(func() {
 		loggedIn = c.data.AccessToken != ""
@@ -317,6 +487,27 @@ func (c *ConfigRepository) IsSSLDisabled() (isSSLDisabled bool) {
 	return
 }
 
+func (c *ConfigRepository) CACertFile() (path string) {
+	c.read(func() {
+		path = c.data.CACertFile
+	})
+	return
+}
+
+func (c *ConfigRepository) ClientCertFile() (path string) {
+	c.read(func() {
+		path = c.data.ClientCertFile
+	})
+	return
+}
+
+func (c *ConfigRepository) ClientKeyFile() (path string) {
+	c.read(func() {
+		path = c.data.ClientKeyFile
+	})
+	return
+}
+
 func (c *ConfigRepository) IsMinAPIVersion(requiredVersion semver.Version) bool {
 	var apiVersion string
 	c.read(func() {
@@ -374,6 +565,41 @@ func (c *ConfigRepository) AsyncTimeout() (timeout uint) {
 	return
 }
 
+func (c *ConfigRepository) RequestRetries() (retries uint) {
+	c.read(func() {
+		retries = c.data.RequestRetries
+	})
+	return
+}
+
+func (c *ConfigRepository) RequestRateLimit() (limit uint) {
+	c.read(func() {
+		limit = c.data.RequestRateLimit
+	})
+	return
+}
+
+func (c *ConfigRepository) MaxConcurrentRequests() (max uint) {
+	c.read(func() {
+		max = c.data.MaxConcurrentRequests
+	})
+	return
+}
+
+func (c *ConfigRepository) Proxy() (proxy string) {
+	c.read(func() {
+		proxy = c.data.Proxy
+	})
+	return
+}
+
+func (c *ConfigRepository) CacheTTL() (ttl uint) {
+	c.read(func() {
+		ttl = c.data.CacheTTL
+	})
+	return
+}
+
 func (c *ConfigRepository) Trace() (trace string) {
 	c.read(func() {
 		trace = c.data.Trace
@@ -402,12 +628,66 @@ func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	return
 }
 
+func (c *ConfigRepository) PluginTrustPolicy() (policy string) {
+	c.read(func() {
+		policy = c.data.PluginTrustPolicy
+	})
+	return
+}
+
+func (c *ConfigRepository) TrustedPluginKeys() (keys []models.PluginKey) {
+	c.read(func() {
+		keys = c.data.TrustedPluginKeys
+	})
+	return
+}
+
+func (c *ConfigRepository) SecretBackend() (backend string) {
+	c.read(func() {
+		backend = c.data.SecretBackend
+	})
+	if backend == "" {
+		backend = secrets.PlaintextBackend
+	}
+	return
+}
+
+func (c *ConfigRepository) CurrentContext() (name string) {
+	c.read(func() {
+		name = c.data.CurrentContext
+	})
+	return
+}
+
+func (c *ConfigRepository) Contexts() (contexts map[string]SavedContext) {
+	c.read(func() {
+		contexts = make(map[string]SavedContext, len(c.data.Contexts))
+		for name, ctx := range c.data.Contexts {
+			contexts[name] = ctx
+		}
+	})
+	return
+}
+
+// ContextNames returns the names of the saved contexts in alphabetical order
+func ContextNames(contexts map[string]SavedContext) []string {
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // SETTERS
 
 func (c *ConfigRepository) ClearSession() {
 	c.write(func() {
 		c.data.AccessToken = ""
 		c.data.RefreshToken = ""
+		c.data.UAAOAuthClient = ""
+		c.data.UAAOAuthClientSecret = ""
+		c.data.UAAGrantType = ""
 		c.data.OrganizationFields = models.OrganizationFields{}
 		c.data.SpaceFields = models.SpaceFields{}
 	})
@@ -479,6 +759,24 @@ func (c *ConfigRepository) SetSSHOAuthClient(clientID string) {
 	})
 }
 
+func (c *ConfigRepository) SetUAAOAuthClient(clientID string) {
+	c.write(func() {
+		c.data.UAAOAuthClient = clientID
+	})
+}
+
+func (c *ConfigRepository) SetUAAOAuthClientSecret(clientSecret string) {
+	c.write(func() {
+		c.data.UAAOAuthClientSecret = clientSecret
+	})
+}
+
+func (c *ConfigRepository) SetUAAGrantType(grantType string) {
+	c.write(func() {
+		c.data.UAAGrantType = grantType
+	})
+}
+
 func (c *ConfigRepository) SetRefreshToken(token string) {
 	c.write(func() {
 		c.data.RefreshToken = token
@@ -503,12 +801,60 @@ func (c *ConfigRepository) SetSSLDisabled(disabled bool) {
 	})
 }
 
+func (c *ConfigRepository) SetCACertFile(path string) {
+	c.write(func() {
+		c.data.CACertFile = path
+	})
+}
+
+func (c *ConfigRepository) SetClientCertFile(path string) {
+	c.write(func() {
+		c.data.ClientCertFile = path
+	})
+}
+
+func (c *ConfigRepository) SetClientKeyFile(path string) {
+	c.write(func() {
+		c.data.ClientKeyFile = path
+	})
+}
+
 func (c *ConfigRepository) SetAsyncTimeout(timeout uint) {
 	c.write(func() {
 		c.data.AsyncTimeout = timeout
 	})
 }
 
+func (c *ConfigRepository) SetRequestRetries(retries uint) {
+	c.write(func() {
+		c.data.RequestRetries = retries
+	})
+}
+
+func (c *ConfigRepository) SetRequestRateLimit(limit uint) {
+	c.write(func() {
+		c.data.RequestRateLimit = limit
+	})
+}
+
+func (c *ConfigRepository) SetMaxConcurrentRequests(max uint) {
+	c.write(func() {
+		c.data.MaxConcurrentRequests = max
+	})
+}
+
+func (c *ConfigRepository) SetProxy(proxy string) {
+	c.write(func() {
+		c.data.Proxy = proxy
+	})
+}
+
+func (c *ConfigRepository) SetCacheTTL(ttl uint) {
+	c.write(func() {
+		c.data.CacheTTL = ttl
+	})
+}
+
 func (c *ConfigRepository) SetTrace(value string) {
 	c.write(func() {
 		c.data.Trace = value
@@ -538,3 +884,110 @@ func (c *ConfigRepository) UnSetPluginRepo(index int) {
 		c.data.PluginRepos = append(c.data.PluginRepos[:index], c.data.PluginRepos[index+1:]...)
 	})
 }
+
+func (c *ConfigRepository) SetPluginRepoTrustPolicy(index int, policy string) {
+	c.write(func() {
+		c.data.PluginRepos[index].TrustPolicy = policy
+	})
+}
+
+// SetPluginTrustPolicy sets the policy for plugins installed from a URL or a
+// local path, and for repositories without a policy of their own
+func (c *ConfigRepository) SetPluginTrustPolicy(policy string) {
+	c.write(func() {
+		c.data.PluginTrustPolicy = policy
+	})
+}
+
+// SetTrustedPluginKey adds a trusted key, replacing any key with the same name
+func (c *ConfigRepository) SetTrustedPluginKey(key models.PluginKey) {
+	c.write(func() {
+		for i := range c.data.TrustedPluginKeys {
+			if c.data.TrustedPluginKeys[i].Name == key.Name {
+				c.data.TrustedPluginKeys[i] = key
+				return
+			}
+		}
+		c.data.TrustedPluginKeys = append(c.data.TrustedPluginKeys, key)
+	})
+}
+
+func (c *ConfigRepository) UnSetTrustedPluginKey(name string) {
+	c.write(func() {
+		keys := []models.PluginKey{}
+		for _, key := range c.data.TrustedPluginKeys {
+			if key.Name != name {
+				keys = append(keys, key)
+			}
+		}
+		c.data.TrustedPluginKeys = keys
+	})
+}
+
+func (c *ConfigRepository) SetSecretBackend(backend string) {
+	c.write(func() {
+		c.data.SecretBackend = backend
+	})
+}
+
+// SaveContext stores the current session under the given name and makes it the
+// current context, replacing any existing context with that name.
+func (c *ConfigRepository) SaveContext(name string) {
+	c.write(func() {
+		c.endOverride()
+
+		if c.data.Contexts == nil {
+			c.data.Contexts = map[string]SavedContext{}
+		}
+		c.data.Contexts[name] = c.data.currentSession()
+		c.data.CurrentContext = name
+	})
+}
+
+// UseContext replaces the current session with the one saved under the given
+// name. The session being replaced is first written back to its own context so
+// that refreshed tokens and targets are not lost.
+func (c *ConfigRepository) UseContext(name string) (err error) {
+	c.write(func() {
+		c.endOverride()
+
+		ctx, ok := c.data.Contexts[name]
+		if !ok {
+			err = errors.New(T("Context '{{.Name}}' not found", map[string]interface{}{"Name": name}))
+			return
+		}
+
+		if _, ok := c.data.Contexts[c.data.CurrentContext]; ok {
+			c.data.Contexts[c.data.CurrentContext] = c.data.currentSession()
+		}
+		c.data.setSession(ctx)
+		c.data.CurrentContext = name
+	})
+	return
+}
+
+// OverrideContext uses the named context for the remainder of the process
+// without changing the current context on disk. Changes made to the session
+// while overridden, such as refreshed tokens, are saved to the named context.
+func (c *ConfigRepository) OverrideContext(name string) (err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+
+	ctx, ok := c.data.Contexts[name]
+	if !ok {
+		return errors.New(T("Context '{{.Name}}' not found", map[string]interface{}{"Name": name}))
+	}
+
+	if c.overrideContext == "" {
+		c.savedSession = c.data.currentSession()
+		c.savedContext = c.data.CurrentContext
+	} else {
+		c.data.Contexts[c.overrideContext] = c.data.currentSession()
+	}
+
+	c.data.setSession(ctx)
+	c.data.CurrentContext = name
+	c.overrideContext = name
+	return nil
+}
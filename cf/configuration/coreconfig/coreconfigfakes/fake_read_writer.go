@@ -70,6 +70,24 @@ type FakeReadWriter struct {
 	sSHOAuthClientReturns     struct {
 		result1 string
 	}
+	UAAOAuthClientStub        func() string
+	uAAOAuthClientMutex       sync.RWMutex
+	uAAOAuthClientArgsForCall []struct{}
+	uAAOAuthClientReturns     struct {
+		result1 string
+	}
+	UAAOAuthClientSecretStub        func() string
+	uAAOAuthClientSecretMutex       sync.RWMutex
+	uAAOAuthClientSecretArgsForCall []struct{}
+	uAAOAuthClientSecretReturns     struct {
+		result1 string
+	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
 	RefreshTokenStub        func() string
 	refreshTokenMutex       sync.RWMutex
 	refreshTokenArgsForCall []struct{}
@@ -130,6 +148,24 @@ type FakeReadWriter struct {
 	isSSLDisabledReturns     struct {
 		result1 bool
 	}
+	CACertFileStub        func() string
+	cACertFileMutex       sync.RWMutex
+	cACertFileArgsForCall []struct{}
+	cACertFileReturns     struct {
+		result1 string
+	}
+	ClientCertFileStub        func() string
+	clientCertFileMutex       sync.RWMutex
+	clientCertFileArgsForCall []struct{}
+	clientCertFileReturns     struct {
+		result1 string
+	}
+	ClientKeyFileStub        func() string
+	clientKeyFileMutex       sync.RWMutex
+	clientKeyFileArgsForCall []struct{}
+	clientKeyFileReturns     struct {
+		result1 string
+	}
 	IsMinAPIVersionStub        func(semver.Version) bool
 	isMinAPIVersionMutex       sync.RWMutex
 	isMinAPIVersionArgsForCall []struct {
@@ -164,6 +200,36 @@ type FakeReadWriter struct {
 	asyncTimeoutReturns     struct {
 		result1 uint
 	}
+	RequestRetriesStub        func() uint
+	requestRetriesMutex       sync.RWMutex
+	requestRetriesArgsForCall []struct{}
+	requestRetriesReturns     struct {
+		result1 uint
+	}
+	RequestRateLimitStub        func() uint
+	requestRateLimitMutex       sync.RWMutex
+	requestRateLimitArgsForCall []struct{}
+	requestRateLimitReturns     struct {
+		result1 uint
+	}
+	MaxConcurrentRequestsStub        func() uint
+	maxConcurrentRequestsMutex       sync.RWMutex
+	maxConcurrentRequestsArgsForCall []struct{}
+	maxConcurrentRequestsReturns     struct {
+		result1 uint
+	}
+	ProxyStub        func() string
+	proxyMutex       sync.RWMutex
+	proxyArgsForCall []struct{}
+	proxyReturns     struct {
+		result1 string
+	}
+	CacheTTLStub        func() uint
+	cacheTTLMutex       sync.RWMutex
+	cacheTTLArgsForCall []struct{}
+	cacheTTLReturns     struct {
+		result1 uint
+	}
 	TraceStub        func() string
 	traceMutex       sync.RWMutex
 	traceArgsForCall []struct{}
@@ -188,6 +254,36 @@ type FakeReadWriter struct {
 	pluginReposReturns     struct {
 		result1 []models.PluginRepo
 	}
+	PluginTrustPolicyStub        func() string
+	pluginTrustPolicyMutex       sync.RWMutex
+	pluginTrustPolicyArgsForCall []struct{}
+	pluginTrustPolicyReturns     struct {
+		result1 string
+	}
+	TrustedPluginKeysStub        func() []models.PluginKey
+	trustedPluginKeysMutex       sync.RWMutex
+	trustedPluginKeysArgsForCall []struct{}
+	trustedPluginKeysReturns     struct {
+		result1 []models.PluginKey
+	}
+	SecretBackendStub        func() string
+	secretBackendMutex       sync.RWMutex
+	secretBackendArgsForCall []struct{}
+	secretBackendReturns     struct {
+		result1 string
+	}
+	CurrentContextStub        func() string
+	currentContextMutex       sync.RWMutex
+	currentContextArgsForCall []struct{}
+	currentContextReturns     struct {
+		result1 string
+	}
+	ContextsStub        func() map[string]coreconfig.SavedContext
+	contextsMutex       sync.RWMutex
+	contextsArgsForCall []struct{}
+	contextsReturns     struct {
+		result1 map[string]coreconfig.SavedContext
+	}
 	ClearSessionStub          func()
 	clearSessionMutex         sync.RWMutex
 	clearSessionArgsForCall   []struct{}
@@ -246,6 +342,21 @@ type FakeReadWriter struct {
 	setSSHOAuthClientArgsForCall []struct {
 		arg1 string
 	}
+	SetUAAOAuthClientStub        func(string)
+	setUAAOAuthClientMutex       sync.RWMutex
+	setUAAOAuthClientArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAOAuthClientSecretStub        func(string)
+	setUAAOAuthClientSecretMutex       sync.RWMutex
+	setUAAOAuthClientSecretArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAGrantTypeStub        func(string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		arg1 string
+	}
 	SetRefreshTokenStub        func(string)
 	setRefreshTokenMutex       sync.RWMutex
 	setRefreshTokenArgsForCall []struct {
@@ -266,11 +377,51 @@ type FakeReadWriter struct {
 	setSSLDisabledArgsForCall []struct {
 		arg1 bool
 	}
+	SetCACertFileStub        func(string)
+	setCACertFileMutex       sync.RWMutex
+	setCACertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientCertFileStub        func(string)
+	setClientCertFileMutex       sync.RWMutex
+	setClientCertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientKeyFileStub        func(string)
+	setClientKeyFileMutex       sync.RWMutex
+	setClientKeyFileArgsForCall []struct {
+		arg1 string
+	}
 	SetAsyncTimeoutStub        func(uint)
 	setAsyncTimeoutMutex       sync.RWMutex
 	setAsyncTimeoutArgsForCall []struct {
 		arg1 uint
 	}
+	SetRequestRetriesStub        func(uint)
+	setRequestRetriesMutex       sync.RWMutex
+	setRequestRetriesArgsForCall []struct {
+		arg1 uint
+	}
+	SetRequestRateLimitStub        func(uint)
+	setRequestRateLimitMutex       sync.RWMutex
+	setRequestRateLimitArgsForCall []struct {
+		arg1 uint
+	}
+	SetMaxConcurrentRequestsStub        func(uint)
+	setMaxConcurrentRequestsMutex       sync.RWMutex
+	setMaxConcurrentRequestsArgsForCall []struct {
+		arg1 uint
+	}
+	SetProxyStub        func(string)
+	setProxyMutex       sync.RWMutex
+	setProxyArgsForCall []struct {
+		arg1 string
+	}
+	SetCacheTTLStub        func(uint)
+	setCacheTTLMutex       sync.RWMutex
+	setCacheTTLArgsForCall []struct {
+		arg1 uint
+	}
 	SetTraceStub        func(string)
 	setTraceMutex       sync.RWMutex
 	setTraceArgsForCall []struct {
@@ -296,6 +447,53 @@ type FakeReadWriter struct {
 	unSetPluginRepoArgsForCall []struct {
 		arg1 int
 	}
+	SetPluginRepoTrustPolicyStub        func(int, string)
+	setPluginRepoTrustPolicyMutex       sync.RWMutex
+	setPluginRepoTrustPolicyArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	SetPluginTrustPolicyStub        func(string)
+	setPluginTrustPolicyMutex       sync.RWMutex
+	setPluginTrustPolicyArgsForCall []struct {
+		arg1 string
+	}
+	SetTrustedPluginKeyStub        func(models.PluginKey)
+	setTrustedPluginKeyMutex       sync.RWMutex
+	setTrustedPluginKeyArgsForCall []struct {
+		arg1 models.PluginKey
+	}
+	UnSetTrustedPluginKeyStub        func(string)
+	unSetTrustedPluginKeyMutex       sync.RWMutex
+	unSetTrustedPluginKeyArgsForCall []struct {
+		arg1 string
+	}
+	SetSecretBackendStub        func(string)
+	setSecretBackendMutex       sync.RWMutex
+	setSecretBackendArgsForCall []struct {
+		arg1 string
+	}
+	SaveContextStub        func(string)
+	saveContextMutex       sync.RWMutex
+	saveContextArgsForCall []struct {
+		arg1 string
+	}
+	UseContextStub        func(string) error
+	useContextMutex       sync.RWMutex
+	useContextArgsForCall []struct {
+		arg1 string
+	}
+	useContextReturns struct {
+		result1 error
+	}
+	OverrideContextStub        func(string) error
+	overrideContextMutex       sync.RWMutex
+	overrideContextArgsForCall []struct {
+		arg1 string
+	}
+	overrideContextReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeReadWriter) APIEndpoint() string {
@@ -538,6 +736,78 @@ func (fake *FakeReadWriter) SSHOAuthClientReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeReadWriter) UAAOAuthClient() string {
+	fake.uAAOAuthClientMutex.Lock()
+	fake.uAAOAuthClientArgsForCall = append(fake.uAAOAuthClientArgsForCall, struct{}{})
+	fake.uAAOAuthClientMutex.Unlock()
+	if fake.UAAOAuthClientStub != nil {
+		return fake.UAAOAuthClientStub()
+	} else {
+		return fake.uAAOAuthClientReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) UAAOAuthClientCallCount() int {
+	fake.uAAOAuthClientMutex.RLock()
+	defer fake.uAAOAuthClientMutex.RUnlock()
+	return len(fake.uAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeReadWriter) UAAOAuthClientReturns(result1 string) {
+	fake.UAAOAuthClientStub = nil
+	fake.uAAOAuthClientReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) UAAOAuthClientSecret() string {
+	fake.uAAOAuthClientSecretMutex.Lock()
+	fake.uAAOAuthClientSecretArgsForCall = append(fake.uAAOAuthClientSecretArgsForCall, struct{}{})
+	fake.uAAOAuthClientSecretMutex.Unlock()
+	if fake.UAAOAuthClientSecretStub != nil {
+		return fake.UAAOAuthClientSecretStub()
+	} else {
+		return fake.uAAOAuthClientSecretReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) UAAOAuthClientSecretCallCount() int {
+	fake.uAAOAuthClientSecretMutex.RLock()
+	defer fake.uAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.uAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeReadWriter) UAAOAuthClientSecretReturns(result1 string) {
+	fake.UAAOAuthClientSecretStub = nil
+	fake.uAAOAuthClientSecretReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeReadWriter) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeReadWriter) RefreshToken() string {
 	fake.refreshTokenMutex.Lock()
 	fake.refreshTokenArgsForCall = append(fake.refreshTokenArgsForCall, struct{}{})
@@ -778,6 +1048,78 @@ func (fake *FakeReadWriter) IsSSLDisabledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeReadWriter) CACertFile() string {
+	fake.cACertFileMutex.Lock()
+	fake.cACertFileArgsForCall = append(fake.cACertFileArgsForCall, struct{}{})
+	fake.cACertFileMutex.Unlock()
+	if fake.CACertFileStub != nil {
+		return fake.CACertFileStub()
+	} else {
+		return fake.cACertFileReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) CACertFileCallCount() int {
+	fake.cACertFileMutex.RLock()
+	defer fake.cACertFileMutex.RUnlock()
+	return len(fake.cACertFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) CACertFileReturns(result1 string) {
+	fake.CACertFileStub = nil
+	fake.cACertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) ClientCertFile() string {
+	fake.clientCertFileMutex.Lock()
+	fake.clientCertFileArgsForCall = append(fake.clientCertFileArgsForCall, struct{}{})
+	fake.clientCertFileMutex.Unlock()
+	if fake.ClientCertFileStub != nil {
+		return fake.ClientCertFileStub()
+	} else {
+		return fake.clientCertFileReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) ClientCertFileCallCount() int {
+	fake.clientCertFileMutex.RLock()
+	defer fake.clientCertFileMutex.RUnlock()
+	return len(fake.clientCertFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) ClientCertFileReturns(result1 string) {
+	fake.ClientCertFileStub = nil
+	fake.clientCertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) ClientKeyFile() string {
+	fake.clientKeyFileMutex.Lock()
+	fake.clientKeyFileArgsForCall = append(fake.clientKeyFileArgsForCall, struct{}{})
+	fake.clientKeyFileMutex.Unlock()
+	if fake.ClientKeyFileStub != nil {
+		return fake.ClientKeyFileStub()
+	} else {
+		return fake.clientKeyFileReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) ClientKeyFileCallCount() int {
+	fake.clientKeyFileMutex.RLock()
+	defer fake.clientKeyFileMutex.RUnlock()
+	return len(fake.clientKeyFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) ClientKeyFileReturns(result1 string) {
+	fake.ClientKeyFileStub = nil
+	fake.clientKeyFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeReadWriter) IsMinAPIVersion(arg1 semver.Version) bool {
 	fake.isMinAPIVersionMutex.Lock()
 	fake.isMinAPIVersionArgsForCall = append(fake.isMinAPIVersionArgsForCall, struct {
@@ -914,6 +1256,126 @@ func (fake *FakeReadWriter) AsyncTimeoutReturns(result1 uint) {
 	}{result1}
 }
 
+func (fake *FakeReadWriter) RequestRetries() uint {
+	fake.requestRetriesMutex.Lock()
+	fake.requestRetriesArgsForCall = append(fake.requestRetriesArgsForCall, struct{}{})
+	fake.requestRetriesMutex.Unlock()
+	if fake.RequestRetriesStub != nil {
+		return fake.RequestRetriesStub()
+	} else {
+		return fake.requestRetriesReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) RequestRetriesCallCount() int {
+	fake.requestRetriesMutex.RLock()
+	defer fake.requestRetriesMutex.RUnlock()
+	return len(fake.requestRetriesArgsForCall)
+}
+
+func (fake *FakeReadWriter) RequestRetriesReturns(result1 uint) {
+	fake.RequestRetriesStub = nil
+	fake.requestRetriesReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeReadWriter) RequestRateLimit() uint {
+	fake.requestRateLimitMutex.Lock()
+	fake.requestRateLimitArgsForCall = append(fake.requestRateLimitArgsForCall, struct{}{})
+	fake.requestRateLimitMutex.Unlock()
+	if fake.RequestRateLimitStub != nil {
+		return fake.RequestRateLimitStub()
+	} else {
+		return fake.requestRateLimitReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) RequestRateLimitCallCount() int {
+	fake.requestRateLimitMutex.RLock()
+	defer fake.requestRateLimitMutex.RUnlock()
+	return len(fake.requestRateLimitArgsForCall)
+}
+
+func (fake *FakeReadWriter) RequestRateLimitReturns(result1 uint) {
+	fake.RequestRateLimitStub = nil
+	fake.requestRateLimitReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeReadWriter) MaxConcurrentRequests() uint {
+	fake.maxConcurrentRequestsMutex.Lock()
+	fake.maxConcurrentRequestsArgsForCall = append(fake.maxConcurrentRequestsArgsForCall, struct{}{})
+	fake.maxConcurrentRequestsMutex.Unlock()
+	if fake.MaxConcurrentRequestsStub != nil {
+		return fake.MaxConcurrentRequestsStub()
+	} else {
+		return fake.maxConcurrentRequestsReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) MaxConcurrentRequestsCallCount() int {
+	fake.maxConcurrentRequestsMutex.RLock()
+	defer fake.maxConcurrentRequestsMutex.RUnlock()
+	return len(fake.maxConcurrentRequestsArgsForCall)
+}
+
+func (fake *FakeReadWriter) MaxConcurrentRequestsReturns(result1 uint) {
+	fake.MaxConcurrentRequestsStub = nil
+	fake.maxConcurrentRequestsReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeReadWriter) Proxy() string {
+	fake.proxyMutex.Lock()
+	fake.proxyArgsForCall = append(fake.proxyArgsForCall, struct{}{})
+	fake.proxyMutex.Unlock()
+	if fake.ProxyStub != nil {
+		return fake.ProxyStub()
+	} else {
+		return fake.proxyReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) ProxyCallCount() int {
+	fake.proxyMutex.RLock()
+	defer fake.proxyMutex.RUnlock()
+	return len(fake.proxyArgsForCall)
+}
+
+func (fake *FakeReadWriter) ProxyReturns(result1 string) {
+	fake.ProxyStub = nil
+	fake.proxyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) CacheTTL() uint {
+	fake.cacheTTLMutex.Lock()
+	fake.cacheTTLArgsForCall = append(fake.cacheTTLArgsForCall, struct{}{})
+	fake.cacheTTLMutex.Unlock()
+	if fake.CacheTTLStub != nil {
+		return fake.CacheTTLStub()
+	} else {
+		return fake.cacheTTLReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) CacheTTLCallCount() int {
+	fake.cacheTTLMutex.RLock()
+	defer fake.cacheTTLMutex.RUnlock()
+	return len(fake.cacheTTLArgsForCall)
+}
+
+func (fake *FakeReadWriter) CacheTTLReturns(result1 uint) {
+	fake.CacheTTLStub = nil
+	fake.cacheTTLReturns = struct {
+		result1 uint
+	}{result1}
+}
+
 func (fake *FakeReadWriter) Trace() string {
 	fake.traceMutex.Lock()
 	fake.traceArgsForCall = append(fake.traceArgsForCall, struct{}{})
@@ -1010,6 +1472,126 @@ func (fake *FakeReadWriter) PluginReposReturns(result1 []models.PluginRepo) {
 	}{result1}
 }
 
+func (fake *FakeReadWriter) PluginTrustPolicy() string {
+	fake.pluginTrustPolicyMutex.Lock()
+	fake.pluginTrustPolicyArgsForCall = append(fake.pluginTrustPolicyArgsForCall, struct{}{})
+	fake.pluginTrustPolicyMutex.Unlock()
+	if fake.PluginTrustPolicyStub != nil {
+		return fake.PluginTrustPolicyStub()
+	} else {
+		return fake.pluginTrustPolicyReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) PluginTrustPolicyCallCount() int {
+	fake.pluginTrustPolicyMutex.RLock()
+	defer fake.pluginTrustPolicyMutex.RUnlock()
+	return len(fake.pluginTrustPolicyArgsForCall)
+}
+
+func (fake *FakeReadWriter) PluginTrustPolicyReturns(result1 string) {
+	fake.PluginTrustPolicyStub = nil
+	fake.pluginTrustPolicyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) TrustedPluginKeys() []models.PluginKey {
+	fake.trustedPluginKeysMutex.Lock()
+	fake.trustedPluginKeysArgsForCall = append(fake.trustedPluginKeysArgsForCall, struct{}{})
+	fake.trustedPluginKeysMutex.Unlock()
+	if fake.TrustedPluginKeysStub != nil {
+		return fake.TrustedPluginKeysStub()
+	} else {
+		return fake.trustedPluginKeysReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) TrustedPluginKeysCallCount() int {
+	fake.trustedPluginKeysMutex.RLock()
+	defer fake.trustedPluginKeysMutex.RUnlock()
+	return len(fake.trustedPluginKeysArgsForCall)
+}
+
+func (fake *FakeReadWriter) TrustedPluginKeysReturns(result1 []models.PluginKey) {
+	fake.TrustedPluginKeysStub = nil
+	fake.trustedPluginKeysReturns = struct {
+		result1 []models.PluginKey
+	}{result1}
+}
+
+func (fake *FakeReadWriter) SecretBackend() string {
+	fake.secretBackendMutex.Lock()
+	fake.secretBackendArgsForCall = append(fake.secretBackendArgsForCall, struct{}{})
+	fake.secretBackendMutex.Unlock()
+	if fake.SecretBackendStub != nil {
+		return fake.SecretBackendStub()
+	} else {
+		return fake.secretBackendReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) SecretBackendCallCount() int {
+	fake.secretBackendMutex.RLock()
+	defer fake.secretBackendMutex.RUnlock()
+	return len(fake.secretBackendArgsForCall)
+}
+
+func (fake *FakeReadWriter) SecretBackendReturns(result1 string) {
+	fake.SecretBackendStub = nil
+	fake.secretBackendReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) CurrentContext() string {
+	fake.currentContextMutex.Lock()
+	fake.currentContextArgsForCall = append(fake.currentContextArgsForCall, struct{}{})
+	fake.currentContextMutex.Unlock()
+	if fake.CurrentContextStub != nil {
+		return fake.CurrentContextStub()
+	} else {
+		return fake.currentContextReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) CurrentContextCallCount() int {
+	fake.currentContextMutex.RLock()
+	defer fake.currentContextMutex.RUnlock()
+	return len(fake.currentContextArgsForCall)
+}
+
+func (fake *FakeReadWriter) CurrentContextReturns(result1 string) {
+	fake.CurrentContextStub = nil
+	fake.currentContextReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeReadWriter) Contexts() map[string]coreconfig.SavedContext {
+	fake.contextsMutex.Lock()
+	fake.contextsArgsForCall = append(fake.contextsArgsForCall, struct{}{})
+	fake.contextsMutex.Unlock()
+	if fake.ContextsStub != nil {
+		return fake.ContextsStub()
+	} else {
+		return fake.contextsReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) ContextsCallCount() int {
+	fake.contextsMutex.RLock()
+	defer fake.contextsMutex.RUnlock()
+	return len(fake.contextsArgsForCall)
+}
+
+func (fake *FakeReadWriter) ContextsReturns(result1 map[string]coreconfig.SavedContext) {
+	fake.ContextsStub = nil
+	fake.contextsReturns = struct {
+		result1 map[string]coreconfig.SavedContext
+	}{result1}
+}
+
 func (fake *FakeReadWriter) ClearSession() {
 	fake.clearSessionMutex.Lock()
 	fake.clearSessionArgsForCall = append(fake.clearSessionArgsForCall, struct{}{})
@@ -1278,6 +1860,75 @@ func (fake *FakeReadWriter) SetSSHOAuthClientArgsForCall(i int) string {
 	return fake.setSSHOAuthClientArgsForCall[i].arg1
 }
 
+func (fake *FakeReadWriter) SetUAAOAuthClient(arg1 string) {
+	fake.setUAAOAuthClientMutex.Lock()
+	fake.setUAAOAuthClientArgsForCall = append(fake.setUAAOAuthClientArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAOAuthClientMutex.Unlock()
+	if fake.SetUAAOAuthClientStub != nil {
+		fake.SetUAAOAuthClientStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetUAAOAuthClientCallCount() int {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return len(fake.setUAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetUAAOAuthClientArgsForCall(i int) string {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return fake.setUAAOAuthClientArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetUAAOAuthClientSecret(arg1 string) {
+	fake.setUAAOAuthClientSecretMutex.Lock()
+	fake.setUAAOAuthClientSecretArgsForCall = append(fake.setUAAOAuthClientSecretArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAOAuthClientSecretMutex.Unlock()
+	if fake.SetUAAOAuthClientSecretStub != nil {
+		fake.SetUAAOAuthClientSecretStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetUAAOAuthClientSecretCallCount() int {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.setUAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetUAAOAuthClientSecretArgsForCall(i int) string {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return fake.setUAAOAuthClientSecretArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetUAAGrantType(arg1 string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].arg1
+}
+
 func (fake *FakeReadWriter) SetRefreshToken(arg1 string) {
 	fake.setRefreshTokenMutex.Lock()
 	fake.setRefreshTokenArgsForCall = append(fake.setRefreshTokenArgsForCall, struct {
@@ -1370,6 +2021,75 @@ func (fake *FakeReadWriter) SetSSLDisabledArgsForCall(i int) bool {
 	return fake.setSSLDisabledArgsForCall[i].arg1
 }
 
+func (fake *FakeReadWriter) SetCACertFile(arg1 string) {
+	fake.setCACertFileMutex.Lock()
+	fake.setCACertFileArgsForCall = append(fake.setCACertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCACertFileMutex.Unlock()
+	if fake.SetCACertFileStub != nil {
+		fake.SetCACertFileStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetCACertFileCallCount() int {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return len(fake.setCACertFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetCACertFileArgsForCall(i int) string {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return fake.setCACertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetClientCertFile(arg1 string) {
+	fake.setClientCertFileMutex.Lock()
+	fake.setClientCertFileArgsForCall = append(fake.setClientCertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setClientCertFileMutex.Unlock()
+	if fake.SetClientCertFileStub != nil {
+		fake.SetClientCertFileStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetClientCertFileCallCount() int {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return len(fake.setClientCertFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetClientCertFileArgsForCall(i int) string {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return fake.setClientCertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetClientKeyFile(arg1 string) {
+	fake.setClientKeyFileMutex.Lock()
+	fake.setClientKeyFileArgsForCall = append(fake.setClientKeyFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setClientKeyFileMutex.Unlock()
+	if fake.SetClientKeyFileStub != nil {
+		fake.SetClientKeyFileStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetClientKeyFileCallCount() int {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return len(fake.setClientKeyFileArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetClientKeyFileArgsForCall(i int) string {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return fake.setClientKeyFileArgsForCall[i].arg1
+}
+
 func (fake *FakeReadWriter) SetAsyncTimeout(arg1 uint) {
 	fake.setAsyncTimeoutMutex.Lock()
 	fake.setAsyncTimeoutArgsForCall = append(fake.setAsyncTimeoutArgsForCall, struct {
@@ -1393,6 +2113,121 @@ func (fake *FakeReadWriter) SetAsyncTimeoutArgsForCall(i int) uint {
 	return fake.setAsyncTimeoutArgsForCall[i].arg1
 }
 
+func (fake *FakeReadWriter) SetRequestRetries(arg1 uint) {
+	fake.setRequestRetriesMutex.Lock()
+	fake.setRequestRetriesArgsForCall = append(fake.setRequestRetriesArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setRequestRetriesMutex.Unlock()
+	if fake.SetRequestRetriesStub != nil {
+		fake.SetRequestRetriesStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetRequestRetriesCallCount() int {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return len(fake.setRequestRetriesArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetRequestRetriesArgsForCall(i int) uint {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return fake.setRequestRetriesArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetRequestRateLimit(arg1 uint) {
+	fake.setRequestRateLimitMutex.Lock()
+	fake.setRequestRateLimitArgsForCall = append(fake.setRequestRateLimitArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setRequestRateLimitMutex.Unlock()
+	if fake.SetRequestRateLimitStub != nil {
+		fake.SetRequestRateLimitStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetRequestRateLimitCallCount() int {
+	fake.setRequestRateLimitMutex.RLock()
+	defer fake.setRequestRateLimitMutex.RUnlock()
+	return len(fake.setRequestRateLimitArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetRequestRateLimitArgsForCall(i int) uint {
+	fake.setRequestRateLimitMutex.RLock()
+	defer fake.setRequestRateLimitMutex.RUnlock()
+	return fake.setRequestRateLimitArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetMaxConcurrentRequests(arg1 uint) {
+	fake.setMaxConcurrentRequestsMutex.Lock()
+	fake.setMaxConcurrentRequestsArgsForCall = append(fake.setMaxConcurrentRequestsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setMaxConcurrentRequestsMutex.Unlock()
+	if fake.SetMaxConcurrentRequestsStub != nil {
+		fake.SetMaxConcurrentRequestsStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetMaxConcurrentRequestsCallCount() int {
+	fake.setMaxConcurrentRequestsMutex.RLock()
+	defer fake.setMaxConcurrentRequestsMutex.RUnlock()
+	return len(fake.setMaxConcurrentRequestsArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetMaxConcurrentRequestsArgsForCall(i int) uint {
+	fake.setMaxConcurrentRequestsMutex.RLock()
+	defer fake.setMaxConcurrentRequestsMutex.RUnlock()
+	return fake.setMaxConcurrentRequestsArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetProxy(arg1 string) {
+	fake.setProxyMutex.Lock()
+	fake.setProxyArgsForCall = append(fake.setProxyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setProxyMutex.Unlock()
+	if fake.SetProxyStub != nil {
+		fake.SetProxyStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetProxyCallCount() int {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return len(fake.setProxyArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetProxyArgsForCall(i int) string {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return fake.setProxyArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetCacheTTL(arg1 uint) {
+	fake.setCacheTTLMutex.Lock()
+	fake.setCacheTTLArgsForCall = append(fake.setCacheTTLArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setCacheTTLMutex.Unlock()
+	if fake.SetCacheTTLStub != nil {
+		fake.SetCacheTTLStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetCacheTTLCallCount() int {
+	fake.setCacheTTLMutex.RLock()
+	defer fake.setCacheTTLMutex.RUnlock()
+	return len(fake.setCacheTTLArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetCacheTTLArgsForCall(i int) uint {
+	fake.setCacheTTLMutex.RLock()
+	defer fake.setCacheTTLMutex.RUnlock()
+	return fake.setCacheTTLArgsForCall[i].arg1
+}
+
 func (fake *FakeReadWriter) SetTrace(arg1 string) {
 	fake.setTraceMutex.Lock()
 	fake.setTraceArgsForCall = append(fake.setTraceArgsForCall, struct {
@@ -1508,4 +2343,207 @@ func (fake *FakeReadWriter) UnSetPluginRepoArgsForCall(i int) int {
 	return fake.unSetPluginRepoArgsForCall[i].arg1
 }
 
+func (fake *FakeReadWriter) SetPluginRepoTrustPolicy(arg1 int, arg2 string) {
+	fake.setPluginRepoTrustPolicyMutex.Lock()
+	fake.setPluginRepoTrustPolicyArgsForCall = append(fake.setPluginRepoTrustPolicyArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.setPluginRepoTrustPolicyMutex.Unlock()
+	if fake.SetPluginRepoTrustPolicyStub != nil {
+		fake.SetPluginRepoTrustPolicyStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeReadWriter) SetPluginRepoTrustPolicyCallCount() int {
+	fake.setPluginRepoTrustPolicyMutex.RLock()
+	defer fake.setPluginRepoTrustPolicyMutex.RUnlock()
+	return len(fake.setPluginRepoTrustPolicyArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetPluginRepoTrustPolicyArgsForCall(i int) (int, string) {
+	fake.setPluginRepoTrustPolicyMutex.RLock()
+	defer fake.setPluginRepoTrustPolicyMutex.RUnlock()
+	return fake.setPluginRepoTrustPolicyArgsForCall[i].arg1, fake.setPluginRepoTrustPolicyArgsForCall[i].arg2
+}
+
+func (fake *FakeReadWriter) SetPluginTrustPolicy(arg1 string) {
+	fake.setPluginTrustPolicyMutex.Lock()
+	fake.setPluginTrustPolicyArgsForCall = append(fake.setPluginTrustPolicyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setPluginTrustPolicyMutex.Unlock()
+	if fake.SetPluginTrustPolicyStub != nil {
+		fake.SetPluginTrustPolicyStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetPluginTrustPolicyCallCount() int {
+	fake.setPluginTrustPolicyMutex.RLock()
+	defer fake.setPluginTrustPolicyMutex.RUnlock()
+	return len(fake.setPluginTrustPolicyArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetPluginTrustPolicyArgsForCall(i int) string {
+	fake.setPluginTrustPolicyMutex.RLock()
+	defer fake.setPluginTrustPolicyMutex.RUnlock()
+	return fake.setPluginTrustPolicyArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetTrustedPluginKey(arg1 models.PluginKey) {
+	fake.setTrustedPluginKeyMutex.Lock()
+	fake.setTrustedPluginKeyArgsForCall = append(fake.setTrustedPluginKeyArgsForCall, struct {
+		arg1 models.PluginKey
+	}{arg1})
+	fake.setTrustedPluginKeyMutex.Unlock()
+	if fake.SetTrustedPluginKeyStub != nil {
+		fake.SetTrustedPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetTrustedPluginKeyCallCount() int {
+	fake.setTrustedPluginKeyMutex.RLock()
+	defer fake.setTrustedPluginKeyMutex.RUnlock()
+	return len(fake.setTrustedPluginKeyArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetTrustedPluginKeyArgsForCall(i int) models.PluginKey {
+	fake.setTrustedPluginKeyMutex.RLock()
+	defer fake.setTrustedPluginKeyMutex.RUnlock()
+	return fake.setTrustedPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) UnSetTrustedPluginKey(arg1 string) {
+	fake.unSetTrustedPluginKeyMutex.Lock()
+	fake.unSetTrustedPluginKeyArgsForCall = append(fake.unSetTrustedPluginKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.unSetTrustedPluginKeyMutex.Unlock()
+	if fake.UnSetTrustedPluginKeyStub != nil {
+		fake.UnSetTrustedPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) UnSetTrustedPluginKeyCallCount() int {
+	fake.unSetTrustedPluginKeyMutex.RLock()
+	defer fake.unSetTrustedPluginKeyMutex.RUnlock()
+	return len(fake.unSetTrustedPluginKeyArgsForCall)
+}
+
+func (fake *FakeReadWriter) UnSetTrustedPluginKeyArgsForCall(i int) string {
+	fake.unSetTrustedPluginKeyMutex.RLock()
+	defer fake.unSetTrustedPluginKeyMutex.RUnlock()
+	return fake.unSetTrustedPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SetSecretBackend(arg1 string) {
+	fake.setSecretBackendMutex.Lock()
+	fake.setSecretBackendArgsForCall = append(fake.setSecretBackendArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setSecretBackendMutex.Unlock()
+	if fake.SetSecretBackendStub != nil {
+		fake.SetSecretBackendStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SetSecretBackendCallCount() int {
+	fake.setSecretBackendMutex.RLock()
+	defer fake.setSecretBackendMutex.RUnlock()
+	return len(fake.setSecretBackendArgsForCall)
+}
+
+func (fake *FakeReadWriter) SetSecretBackendArgsForCall(i int) string {
+	fake.setSecretBackendMutex.RLock()
+	defer fake.setSecretBackendMutex.RUnlock()
+	return fake.setSecretBackendArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) SaveContext(arg1 string) {
+	fake.saveContextMutex.Lock()
+	fake.saveContextArgsForCall = append(fake.saveContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.saveContextMutex.Unlock()
+	if fake.SaveContextStub != nil {
+		fake.SaveContextStub(arg1)
+	}
+}
+
+func (fake *FakeReadWriter) SaveContextCallCount() int {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return len(fake.saveContextArgsForCall)
+}
+
+func (fake *FakeReadWriter) SaveContextArgsForCall(i int) string {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return fake.saveContextArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) UseContext(arg1 string) error {
+	fake.useContextMutex.Lock()
+	fake.useContextArgsForCall = append(fake.useContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.useContextMutex.Unlock()
+	if fake.UseContextStub != nil {
+		return fake.UseContextStub(arg1)
+	} else {
+		return fake.useContextReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) UseContextCallCount() int {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return len(fake.useContextArgsForCall)
+}
+
+func (fake *FakeReadWriter) UseContextArgsForCall(i int) string {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return fake.useContextArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) UseContextReturns(result1 error) {
+	fake.UseContextStub = nil
+	fake.useContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeReadWriter) OverrideContext(arg1 string) error {
+	fake.overrideContextMutex.Lock()
+	fake.overrideContextArgsForCall = append(fake.overrideContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.overrideContextMutex.Unlock()
+	if fake.OverrideContextStub != nil {
+		return fake.OverrideContextStub(arg1)
+	} else {
+		return fake.overrideContextReturns.result1
+	}
+}
+
+func (fake *FakeReadWriter) OverrideContextCallCount() int {
+	fake.overrideContextMutex.RLock()
+	defer fake.overrideContextMutex.RUnlock()
+	return len(fake.overrideContextArgsForCall)
+}
+
+func (fake *FakeReadWriter) OverrideContextArgsForCall(i int) string {
+	fake.overrideContextMutex.RLock()
+	defer fake.overrideContextMutex.RUnlock()
+	return fake.overrideContextArgsForCall[i].arg1
+}
+
+func (fake *FakeReadWriter) OverrideContextReturns(result1 error) {
+	fake.OverrideContextStub = nil
+	fake.overrideContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ coreconfig.ReadWriter = new(FakeReadWriter)
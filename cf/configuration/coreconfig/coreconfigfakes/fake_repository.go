@@ -70,6 +70,24 @@ type FakeRepository struct {
 	sSHOAuthClientReturns     struct {
 		result1 string
 	}
+	UAAOAuthClientStub        func() string
+	uAAOAuthClientMutex       sync.RWMutex
+	uAAOAuthClientArgsForCall []struct{}
+	uAAOAuthClientReturns     struct {
+		result1 string
+	}
+	UAAOAuthClientSecretStub        func() string
+	uAAOAuthClientSecretMutex       sync.RWMutex
+	uAAOAuthClientSecretArgsForCall []struct{}
+	uAAOAuthClientSecretReturns     struct {
+		result1 string
+	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
 	RefreshTokenStub        func() string
 	refreshTokenMutex       sync.RWMutex
 	refreshTokenArgsForCall []struct{}
@@ -130,6 +148,24 @@ type FakeRepository struct {
 	isSSLDisabledReturns     struct {
 		result1 bool
 	}
+	CACertFileStub        func() string
+	cACertFileMutex       sync.RWMutex
+	cACertFileArgsForCall []struct{}
+	cACertFileReturns     struct {
+		result1 string
+	}
+	ClientCertFileStub        func() string
+	clientCertFileMutex       sync.RWMutex
+	clientCertFileArgsForCall []struct{}
+	clientCertFileReturns     struct {
+		result1 string
+	}
+	ClientKeyFileStub        func() string
+	clientKeyFileMutex       sync.RWMutex
+	clientKeyFileArgsForCall []struct{}
+	clientKeyFileReturns     struct {
+		result1 string
+	}
 	IsMinAPIVersionStub        func(semver.Version) bool
 	isMinAPIVersionMutex       sync.RWMutex
 	isMinAPIVersionArgsForCall []struct {
@@ -164,6 +200,36 @@ type FakeRepository struct {
 	asyncTimeoutReturns     struct {
 		result1 uint
 	}
+	RequestRetriesStub        func() uint
+	requestRetriesMutex       sync.RWMutex
+	requestRetriesArgsForCall []struct{}
+	requestRetriesReturns     struct {
+		result1 uint
+	}
+	RequestRateLimitStub        func() uint
+	requestRateLimitMutex       sync.RWMutex
+	requestRateLimitArgsForCall []struct{}
+	requestRateLimitReturns     struct {
+		result1 uint
+	}
+	MaxConcurrentRequestsStub        func() uint
+	maxConcurrentRequestsMutex       sync.RWMutex
+	maxConcurrentRequestsArgsForCall []struct{}
+	maxConcurrentRequestsReturns     struct {
+		result1 uint
+	}
+	ProxyStub        func() string
+	proxyMutex       sync.RWMutex
+	proxyArgsForCall []struct{}
+	proxyReturns     struct {
+		result1 string
+	}
+	CacheTTLStub        func() uint
+	cacheTTLMutex       sync.RWMutex
+	cacheTTLArgsForCall []struct{}
+	cacheTTLReturns     struct {
+		result1 uint
+	}
 	TraceStub        func() string
 	traceMutex       sync.RWMutex
 	traceArgsForCall []struct{}
@@ -188,6 +254,36 @@ type FakeRepository struct {
 	pluginReposReturns     struct {
 		result1 []models.PluginRepo
 	}
+	PluginTrustPolicyStub        func() string
+	pluginTrustPolicyMutex       sync.RWMutex
+	pluginTrustPolicyArgsForCall []struct{}
+	pluginTrustPolicyReturns     struct {
+		result1 string
+	}
+	TrustedPluginKeysStub        func() []models.PluginKey
+	trustedPluginKeysMutex       sync.RWMutex
+	trustedPluginKeysArgsForCall []struct{}
+	trustedPluginKeysReturns     struct {
+		result1 []models.PluginKey
+	}
+	SecretBackendStub        func() string
+	secretBackendMutex       sync.RWMutex
+	secretBackendArgsForCall []struct{}
+	secretBackendReturns     struct {
+		result1 string
+	}
+	CurrentContextStub        func() string
+	currentContextMutex       sync.RWMutex
+	currentContextArgsForCall []struct{}
+	currentContextReturns     struct {
+		result1 string
+	}
+	ContextsStub        func() map[string]coreconfig.SavedContext
+	contextsMutex       sync.RWMutex
+	contextsArgsForCall []struct{}
+	contextsReturns     struct {
+		result1 map[string]coreconfig.SavedContext
+	}
 	ClearSessionStub          func()
 	clearSessionMutex         sync.RWMutex
 	clearSessionArgsForCall   []struct{}
@@ -246,6 +342,21 @@ type FakeRepository struct {
 	setSSHOAuthClientArgsForCall []struct {
 		arg1 string
 	}
+	SetUAAOAuthClientStub        func(string)
+	setUAAOAuthClientMutex       sync.RWMutex
+	setUAAOAuthClientArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAOAuthClientSecretStub        func(string)
+	setUAAOAuthClientSecretMutex       sync.RWMutex
+	setUAAOAuthClientSecretArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAGrantTypeStub        func(string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		arg1 string
+	}
 	SetRefreshTokenStub        func(string)
 	setRefreshTokenMutex       sync.RWMutex
 	setRefreshTokenArgsForCall []struct {
@@ -266,11 +377,51 @@ type FakeRepository struct {
 	setSSLDisabledArgsForCall []struct {
 		arg1 bool
 	}
+	SetCACertFileStub        func(string)
+	setCACertFileMutex       sync.RWMutex
+	setCACertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientCertFileStub        func(string)
+	setClientCertFileMutex       sync.RWMutex
+	setClientCertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientKeyFileStub        func(string)
+	setClientKeyFileMutex       sync.RWMutex
+	setClientKeyFileArgsForCall []struct {
+		arg1 string
+	}
 	SetAsyncTimeoutStub        func(uint)
 	setAsyncTimeoutMutex       sync.RWMutex
 	setAsyncTimeoutArgsForCall []struct {
 		arg1 uint
 	}
+	SetRequestRetriesStub        func(uint)
+	setRequestRetriesMutex       sync.RWMutex
+	setRequestRetriesArgsForCall []struct {
+		arg1 uint
+	}
+	SetRequestRateLimitStub        func(uint)
+	setRequestRateLimitMutex       sync.RWMutex
+	setRequestRateLimitArgsForCall []struct {
+		arg1 uint
+	}
+	SetMaxConcurrentRequestsStub        func(uint)
+	setMaxConcurrentRequestsMutex       sync.RWMutex
+	setMaxConcurrentRequestsArgsForCall []struct {
+		arg1 uint
+	}
+	SetProxyStub        func(string)
+	setProxyMutex       sync.RWMutex
+	setProxyArgsForCall []struct {
+		arg1 string
+	}
+	SetCacheTTLStub        func(uint)
+	setCacheTTLMutex       sync.RWMutex
+	setCacheTTLArgsForCall []struct {
+		arg1 uint
+	}
 	SetTraceStub        func(string)
 	setTraceMutex       sync.RWMutex
 	setTraceArgsForCall []struct {
@@ -296,6 +447,53 @@ type FakeRepository struct {
 	unSetPluginRepoArgsForCall []struct {
 		arg1 int
 	}
+	SetPluginRepoTrustPolicyStub        func(int, string)
+	setPluginRepoTrustPolicyMutex       sync.RWMutex
+	setPluginRepoTrustPolicyArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	SetPluginTrustPolicyStub        func(string)
+	setPluginTrustPolicyMutex       sync.RWMutex
+	setPluginTrustPolicyArgsForCall []struct {
+		arg1 string
+	}
+	SetTrustedPluginKeyStub        func(models.PluginKey)
+	setTrustedPluginKeyMutex       sync.RWMutex
+	setTrustedPluginKeyArgsForCall []struct {
+		arg1 models.PluginKey
+	}
+	UnSetTrustedPluginKeyStub        func(string)
+	unSetTrustedPluginKeyMutex       sync.RWMutex
+	unSetTrustedPluginKeyArgsForCall []struct {
+		arg1 string
+	}
+	SetSecretBackendStub        func(string)
+	setSecretBackendMutex       sync.RWMutex
+	setSecretBackendArgsForCall []struct {
+		arg1 string
+	}
+	SaveContextStub        func(string)
+	saveContextMutex       sync.RWMutex
+	saveContextArgsForCall []struct {
+		arg1 string
+	}
+	UseContextStub        func(string) error
+	useContextMutex       sync.RWMutex
+	useContextArgsForCall []struct {
+		arg1 string
+	}
+	useContextReturns struct {
+		result1 error
+	}
+	OverrideContextStub        func(string) error
+	overrideContextMutex       sync.RWMutex
+	overrideContextArgsForCall []struct {
+		arg1 string
+	}
+	overrideContextReturns struct {
+		result1 error
+	}
 	CloseStub        func()
 	closeMutex       sync.RWMutex
 	closeArgsForCall []struct{}
@@ -541,6 +739,78 @@ func (fake *FakeRepository) SSHOAuthClientReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRepository) UAAOAuthClient() string {
+	fake.uAAOAuthClientMutex.Lock()
+	fake.uAAOAuthClientArgsForCall = append(fake.uAAOAuthClientArgsForCall, struct{}{})
+	fake.uAAOAuthClientMutex.Unlock()
+	if fake.UAAOAuthClientStub != nil {
+		return fake.UAAOAuthClientStub()
+	} else {
+		return fake.uAAOAuthClientReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAOAuthClientCallCount() int {
+	fake.uAAOAuthClientMutex.RLock()
+	defer fake.uAAOAuthClientMutex.RUnlock()
+	return len(fake.uAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeRepository) UAAOAuthClientReturns(result1 string) {
+	fake.UAAOAuthClientStub = nil
+	fake.uAAOAuthClientReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecret() string {
+	fake.uAAOAuthClientSecretMutex.Lock()
+	fake.uAAOAuthClientSecretArgsForCall = append(fake.uAAOAuthClientSecretArgsForCall, struct{}{})
+	fake.uAAOAuthClientSecretMutex.Unlock()
+	if fake.UAAOAuthClientSecretStub != nil {
+		return fake.UAAOAuthClientSecretStub()
+	} else {
+		return fake.uAAOAuthClientSecretReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecretCallCount() int {
+	fake.uAAOAuthClientSecretMutex.RLock()
+	defer fake.uAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.uAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecretReturns(result1 string) {
+	fake.UAAOAuthClientSecretStub = nil
+	fake.uAAOAuthClientSecretReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeRepository) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) RefreshToken() string {
 	fake.refreshTokenMutex.Lock()
 	fake.refreshTokenArgsForCall = append(fake.refreshTokenArgsForCall, struct{}{})
@@ -781,6 +1051,78 @@ func (fake *FakeRepository) IsSSLDisabledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CACertFile() string {
+	fake.cACertFileMutex.Lock()
+	fake.cACertFileArgsForCall = append(fake.cACertFileArgsForCall, struct{}{})
+	fake.cACertFileMutex.Unlock()
+	if fake.CACertFileStub != nil {
+		return fake.CACertFileStub()
+	} else {
+		return fake.cACertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CACertFileCallCount() int {
+	fake.cACertFileMutex.RLock()
+	defer fake.cACertFileMutex.RUnlock()
+	return len(fake.cACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) CACertFileReturns(result1 string) {
+	fake.CACertFileStub = nil
+	fake.cACertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientCertFile() string {
+	fake.clientCertFileMutex.Lock()
+	fake.clientCertFileArgsForCall = append(fake.clientCertFileArgsForCall, struct{}{})
+	fake.clientCertFileMutex.Unlock()
+	if fake.ClientCertFileStub != nil {
+		return fake.ClientCertFileStub()
+	} else {
+		return fake.clientCertFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientCertFileCallCount() int {
+	fake.clientCertFileMutex.RLock()
+	defer fake.clientCertFileMutex.RUnlock()
+	return len(fake.clientCertFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientCertFileReturns(result1 string) {
+	fake.ClientCertFileStub = nil
+	fake.clientCertFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) ClientKeyFile() string {
+	fake.clientKeyFileMutex.Lock()
+	fake.clientKeyFileArgsForCall = append(fake.clientKeyFileArgsForCall, struct{}{})
+	fake.clientKeyFileMutex.Unlock()
+	if fake.ClientKeyFileStub != nil {
+		return fake.ClientKeyFileStub()
+	} else {
+		return fake.clientKeyFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ClientKeyFileCallCount() int {
+	fake.clientKeyFileMutex.RLock()
+	defer fake.clientKeyFileMutex.RUnlock()
+	return len(fake.clientKeyFileArgsForCall)
+}
+
+func (fake *FakeRepository) ClientKeyFileReturns(result1 string) {
+	fake.ClientKeyFileStub = nil
+	fake.clientKeyFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) IsMinAPIVersion(arg1 semver.Version) bool {
 	fake.isMinAPIVersionMutex.Lock()
 	fake.isMinAPIVersionArgsForCall = append(fake.isMinAPIVersionArgsForCall, struct {
@@ -917,6 +1259,126 @@ func (fake *FakeRepository) AsyncTimeoutReturns(result1 uint) {
 	}{result1}
 }
 
+func (fake *FakeRepository) RequestRetries() uint {
+	fake.requestRetriesMutex.Lock()
+	fake.requestRetriesArgsForCall = append(fake.requestRetriesArgsForCall, struct{}{})
+	fake.requestRetriesMutex.Unlock()
+	if fake.RequestRetriesStub != nil {
+		return fake.RequestRetriesStub()
+	} else {
+		return fake.requestRetriesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) RequestRetriesCallCount() int {
+	fake.requestRetriesMutex.RLock()
+	defer fake.requestRetriesMutex.RUnlock()
+	return len(fake.requestRetriesArgsForCall)
+}
+
+func (fake *FakeRepository) RequestRetriesReturns(result1 uint) {
+	fake.RequestRetriesStub = nil
+	fake.requestRetriesReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeRepository) RequestRateLimit() uint {
+	fake.requestRateLimitMutex.Lock()
+	fake.requestRateLimitArgsForCall = append(fake.requestRateLimitArgsForCall, struct{}{})
+	fake.requestRateLimitMutex.Unlock()
+	if fake.RequestRateLimitStub != nil {
+		return fake.RequestRateLimitStub()
+	} else {
+		return fake.requestRateLimitReturns.result1
+	}
+}
+
+func (fake *FakeRepository) RequestRateLimitCallCount() int {
+	fake.requestRateLimitMutex.RLock()
+	defer fake.requestRateLimitMutex.RUnlock()
+	return len(fake.requestRateLimitArgsForCall)
+}
+
+func (fake *FakeRepository) RequestRateLimitReturns(result1 uint) {
+	fake.RequestRateLimitStub = nil
+	fake.requestRateLimitReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeRepository) MaxConcurrentRequests() uint {
+	fake.maxConcurrentRequestsMutex.Lock()
+	fake.maxConcurrentRequestsArgsForCall = append(fake.maxConcurrentRequestsArgsForCall, struct{}{})
+	fake.maxConcurrentRequestsMutex.Unlock()
+	if fake.MaxConcurrentRequestsStub != nil {
+		return fake.MaxConcurrentRequestsStub()
+	} else {
+		return fake.maxConcurrentRequestsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) MaxConcurrentRequestsCallCount() int {
+	fake.maxConcurrentRequestsMutex.RLock()
+	defer fake.maxConcurrentRequestsMutex.RUnlock()
+	return len(fake.maxConcurrentRequestsArgsForCall)
+}
+
+func (fake *FakeRepository) MaxConcurrentRequestsReturns(result1 uint) {
+	fake.MaxConcurrentRequestsStub = nil
+	fake.maxConcurrentRequestsReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeRepository) Proxy() string {
+	fake.proxyMutex.Lock()
+	fake.proxyArgsForCall = append(fake.proxyArgsForCall, struct{}{})
+	fake.proxyMutex.Unlock()
+	if fake.ProxyStub != nil {
+		return fake.ProxyStub()
+	} else {
+		return fake.proxyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ProxyCallCount() int {
+	fake.proxyMutex.RLock()
+	defer fake.proxyMutex.RUnlock()
+	return len(fake.proxyArgsForCall)
+}
+
+func (fake *FakeRepository) ProxyReturns(result1 string) {
+	fake.ProxyStub = nil
+	fake.proxyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) CacheTTL() uint {
+	fake.cacheTTLMutex.Lock()
+	fake.cacheTTLArgsForCall = append(fake.cacheTTLArgsForCall, struct{}{})
+	fake.cacheTTLMutex.Unlock()
+	if fake.CacheTTLStub != nil {
+		return fake.CacheTTLStub()
+	} else {
+		return fake.cacheTTLReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CacheTTLCallCount() int {
+	fake.cacheTTLMutex.RLock()
+	defer fake.cacheTTLMutex.RUnlock()
+	return len(fake.cacheTTLArgsForCall)
+}
+
+func (fake *FakeRepository) CacheTTLReturns(result1 uint) {
+	fake.CacheTTLStub = nil
+	fake.cacheTTLReturns = struct {
+		result1 uint
+	}{result1}
+}
+
 func (fake *FakeRepository) Trace() string {
 	fake.traceMutex.Lock()
 	fake.traceArgsForCall = append(fake.traceArgsForCall, struct{}{})
@@ -1013,6 +1475,126 @@ func (fake *FakeRepository) PluginReposReturns(result1 []models.PluginRepo) {
 	}{result1}
 }
 
+func (fake *FakeRepository) PluginTrustPolicy() string {
+	fake.pluginTrustPolicyMutex.Lock()
+	fake.pluginTrustPolicyArgsForCall = append(fake.pluginTrustPolicyArgsForCall, struct{}{})
+	fake.pluginTrustPolicyMutex.Unlock()
+	if fake.PluginTrustPolicyStub != nil {
+		return fake.PluginTrustPolicyStub()
+	} else {
+		return fake.pluginTrustPolicyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginTrustPolicyCallCount() int {
+	fake.pluginTrustPolicyMutex.RLock()
+	defer fake.pluginTrustPolicyMutex.RUnlock()
+	return len(fake.pluginTrustPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) PluginTrustPolicyReturns(result1 string) {
+	fake.PluginTrustPolicyStub = nil
+	fake.pluginTrustPolicyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) TrustedPluginKeys() []models.PluginKey {
+	fake.trustedPluginKeysMutex.Lock()
+	fake.trustedPluginKeysArgsForCall = append(fake.trustedPluginKeysArgsForCall, struct{}{})
+	fake.trustedPluginKeysMutex.Unlock()
+	if fake.TrustedPluginKeysStub != nil {
+		return fake.TrustedPluginKeysStub()
+	} else {
+		return fake.trustedPluginKeysReturns.result1
+	}
+}
+
+func (fake *FakeRepository) TrustedPluginKeysCallCount() int {
+	fake.trustedPluginKeysMutex.RLock()
+	defer fake.trustedPluginKeysMutex.RUnlock()
+	return len(fake.trustedPluginKeysArgsForCall)
+}
+
+func (fake *FakeRepository) TrustedPluginKeysReturns(result1 []models.PluginKey) {
+	fake.TrustedPluginKeysStub = nil
+	fake.trustedPluginKeysReturns = struct {
+		result1 []models.PluginKey
+	}{result1}
+}
+
+func (fake *FakeRepository) SecretBackend() string {
+	fake.secretBackendMutex.Lock()
+	fake.secretBackendArgsForCall = append(fake.secretBackendArgsForCall, struct{}{})
+	fake.secretBackendMutex.Unlock()
+	if fake.SecretBackendStub != nil {
+		return fake.SecretBackendStub()
+	} else {
+		return fake.secretBackendReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SecretBackendCallCount() int {
+	fake.secretBackendMutex.RLock()
+	defer fake.secretBackendMutex.RUnlock()
+	return len(fake.secretBackendArgsForCall)
+}
+
+func (fake *FakeRepository) SecretBackendReturns(result1 string) {
+	fake.SecretBackendStub = nil
+	fake.secretBackendReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) CurrentContext() string {
+	fake.currentContextMutex.Lock()
+	fake.currentContextArgsForCall = append(fake.currentContextArgsForCall, struct{}{})
+	fake.currentContextMutex.Unlock()
+	if fake.CurrentContextStub != nil {
+		return fake.CurrentContextStub()
+	} else {
+		return fake.currentContextReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CurrentContextCallCount() int {
+	fake.currentContextMutex.RLock()
+	defer fake.currentContextMutex.RUnlock()
+	return len(fake.currentContextArgsForCall)
+}
+
+func (fake *FakeRepository) CurrentContextReturns(result1 string) {
+	fake.CurrentContextStub = nil
+	fake.currentContextReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) Contexts() map[string]coreconfig.SavedContext {
+	fake.contextsMutex.Lock()
+	fake.contextsArgsForCall = append(fake.contextsArgsForCall, struct{}{})
+	fake.contextsMutex.Unlock()
+	if fake.ContextsStub != nil {
+		return fake.ContextsStub()
+	} else {
+		return fake.contextsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ContextsCallCount() int {
+	fake.contextsMutex.RLock()
+	defer fake.contextsMutex.RUnlock()
+	return len(fake.contextsArgsForCall)
+}
+
+func (fake *FakeRepository) ContextsReturns(result1 map[string]coreconfig.SavedContext) {
+	fake.ContextsStub = nil
+	fake.contextsReturns = struct {
+		result1 map[string]coreconfig.SavedContext
+	}{result1}
+}
+
 func (fake *FakeRepository) ClearSession() {
 	fake.clearSessionMutex.Lock()
 	fake.clearSessionArgsForCall = append(fake.clearSessionArgsForCall, struct{}{})
@@ -1281,6 +1863,75 @@ func (fake *FakeRepository) SetSSHOAuthClientArgsForCall(i int) string {
 	return fake.setSSHOAuthClientArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetUAAOAuthClient(arg1 string) {
+	fake.setUAAOAuthClientMutex.Lock()
+	fake.setUAAOAuthClientArgsForCall = append(fake.setUAAOAuthClientArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAOAuthClientMutex.Unlock()
+	if fake.SetUAAOAuthClientStub != nil {
+		fake.SetUAAOAuthClientStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientCallCount() int {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return len(fake.setUAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientArgsForCall(i int) string {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return fake.setUAAOAuthClientArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecret(arg1 string) {
+	fake.setUAAOAuthClientSecretMutex.Lock()
+	fake.setUAAOAuthClientSecretArgsForCall = append(fake.setUAAOAuthClientSecretArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAOAuthClientSecretMutex.Unlock()
+	if fake.SetUAAOAuthClientSecretStub != nil {
+		fake.SetUAAOAuthClientSecretStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecretCallCount() int {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.setUAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecretArgsForCall(i int) string {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return fake.setUAAOAuthClientSecretArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetUAAGrantType(arg1 string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetRefreshToken(arg1 string) {
 	fake.setRefreshTokenMutex.Lock()
 	fake.setRefreshTokenArgsForCall = append(fake.setRefreshTokenArgsForCall, struct {
@@ -1373,6 +2024,75 @@ func (fake *FakeRepository) SetSSLDisabledArgsForCall(i int) bool {
 	return fake.setSSLDisabledArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetCACertFile(arg1 string) {
+	fake.setCACertFileMutex.Lock()
+	fake.setCACertFileArgsForCall = append(fake.setCACertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setCACertFileMutex.Unlock()
+	if fake.SetCACertFileStub != nil {
+		fake.SetCACertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCACertFileCallCount() int {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return len(fake.setCACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetCACertFileArgsForCall(i int) string {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return fake.setCACertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetClientCertFile(arg1 string) {
+	fake.setClientCertFileMutex.Lock()
+	fake.setClientCertFileArgsForCall = append(fake.setClientCertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setClientCertFileMutex.Unlock()
+	if fake.SetClientCertFileStub != nil {
+		fake.SetClientCertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetClientCertFileCallCount() int {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return len(fake.setClientCertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientCertFileArgsForCall(i int) string {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return fake.setClientCertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetClientKeyFile(arg1 string) {
+	fake.setClientKeyFileMutex.Lock()
+	fake.setClientKeyFileArgsForCall = append(fake.setClientKeyFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setClientKeyFileMutex.Unlock()
+	if fake.SetClientKeyFileStub != nil {
+		fake.SetClientKeyFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetClientKeyFileCallCount() int {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return len(fake.setClientKeyFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientKeyFileArgsForCall(i int) string {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return fake.setClientKeyFileArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetAsyncTimeout(arg1 uint) {
 	fake.setAsyncTimeoutMutex.Lock()
 	fake.setAsyncTimeoutArgsForCall = append(fake.setAsyncTimeoutArgsForCall, struct {
@@ -1396,6 +2116,121 @@ func (fake *FakeRepository) SetAsyncTimeoutArgsForCall(i int) uint {
 	return fake.setAsyncTimeoutArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetRequestRetries(arg1 uint) {
+	fake.setRequestRetriesMutex.Lock()
+	fake.setRequestRetriesArgsForCall = append(fake.setRequestRetriesArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setRequestRetriesMutex.Unlock()
+	if fake.SetRequestRetriesStub != nil {
+		fake.SetRequestRetriesStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetRequestRetriesCallCount() int {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return len(fake.setRequestRetriesArgsForCall)
+}
+
+func (fake *FakeRepository) SetRequestRetriesArgsForCall(i int) uint {
+	fake.setRequestRetriesMutex.RLock()
+	defer fake.setRequestRetriesMutex.RUnlock()
+	return fake.setRequestRetriesArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetRequestRateLimit(arg1 uint) {
+	fake.setRequestRateLimitMutex.Lock()
+	fake.setRequestRateLimitArgsForCall = append(fake.setRequestRateLimitArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setRequestRateLimitMutex.Unlock()
+	if fake.SetRequestRateLimitStub != nil {
+		fake.SetRequestRateLimitStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetRequestRateLimitCallCount() int {
+	fake.setRequestRateLimitMutex.RLock()
+	defer fake.setRequestRateLimitMutex.RUnlock()
+	return len(fake.setRequestRateLimitArgsForCall)
+}
+
+func (fake *FakeRepository) SetRequestRateLimitArgsForCall(i int) uint {
+	fake.setRequestRateLimitMutex.RLock()
+	defer fake.setRequestRateLimitMutex.RUnlock()
+	return fake.setRequestRateLimitArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetMaxConcurrentRequests(arg1 uint) {
+	fake.setMaxConcurrentRequestsMutex.Lock()
+	fake.setMaxConcurrentRequestsArgsForCall = append(fake.setMaxConcurrentRequestsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setMaxConcurrentRequestsMutex.Unlock()
+	if fake.SetMaxConcurrentRequestsStub != nil {
+		fake.SetMaxConcurrentRequestsStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetMaxConcurrentRequestsCallCount() int {
+	fake.setMaxConcurrentRequestsMutex.RLock()
+	defer fake.setMaxConcurrentRequestsMutex.RUnlock()
+	return len(fake.setMaxConcurrentRequestsArgsForCall)
+}
+
+func (fake *FakeRepository) SetMaxConcurrentRequestsArgsForCall(i int) uint {
+	fake.setMaxConcurrentRequestsMutex.RLock()
+	defer fake.setMaxConcurrentRequestsMutex.RUnlock()
+	return fake.setMaxConcurrentRequestsArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetProxy(arg1 string) {
+	fake.setProxyMutex.Lock()
+	fake.setProxyArgsForCall = append(fake.setProxyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setProxyMutex.Unlock()
+	if fake.SetProxyStub != nil {
+		fake.SetProxyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetProxyCallCount() int {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return len(fake.setProxyArgsForCall)
+}
+
+func (fake *FakeRepository) SetProxyArgsForCall(i int) string {
+	fake.setProxyMutex.RLock()
+	defer fake.setProxyMutex.RUnlock()
+	return fake.setProxyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetCacheTTL(arg1 uint) {
+	fake.setCacheTTLMutex.Lock()
+	fake.setCacheTTLArgsForCall = append(fake.setCacheTTLArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.setCacheTTLMutex.Unlock()
+	if fake.SetCacheTTLStub != nil {
+		fake.SetCacheTTLStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCacheTTLCallCount() int {
+	fake.setCacheTTLMutex.RLock()
+	defer fake.setCacheTTLMutex.RUnlock()
+	return len(fake.setCacheTTLArgsForCall)
+}
+
+func (fake *FakeRepository) SetCacheTTLArgsForCall(i int) uint {
+	fake.setCacheTTLMutex.RLock()
+	defer fake.setCacheTTLMutex.RUnlock()
+	return fake.setCacheTTLArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetTrace(arg1 string) {
 	fake.setTraceMutex.Lock()
 	fake.setTraceArgsForCall = append(fake.setTraceArgsForCall, struct {
@@ -1511,6 +2346,209 @@ func (fake *FakeRepository) UnSetPluginRepoArgsForCall(i int) int {
 	return fake.unSetPluginRepoArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetPluginRepoTrustPolicy(arg1 int, arg2 string) {
+	fake.setPluginRepoTrustPolicyMutex.Lock()
+	fake.setPluginRepoTrustPolicyArgsForCall = append(fake.setPluginRepoTrustPolicyArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.setPluginRepoTrustPolicyMutex.Unlock()
+	if fake.SetPluginRepoTrustPolicyStub != nil {
+		fake.SetPluginRepoTrustPolicyStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeRepository) SetPluginRepoTrustPolicyCallCount() int {
+	fake.setPluginRepoTrustPolicyMutex.RLock()
+	defer fake.setPluginRepoTrustPolicyMutex.RUnlock()
+	return len(fake.setPluginRepoTrustPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginRepoTrustPolicyArgsForCall(i int) (int, string) {
+	fake.setPluginRepoTrustPolicyMutex.RLock()
+	defer fake.setPluginRepoTrustPolicyMutex.RUnlock()
+	return fake.setPluginRepoTrustPolicyArgsForCall[i].arg1, fake.setPluginRepoTrustPolicyArgsForCall[i].arg2
+}
+
+func (fake *FakeRepository) SetPluginTrustPolicy(arg1 string) {
+	fake.setPluginTrustPolicyMutex.Lock()
+	fake.setPluginTrustPolicyArgsForCall = append(fake.setPluginTrustPolicyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setPluginTrustPolicyMutex.Unlock()
+	if fake.SetPluginTrustPolicyStub != nil {
+		fake.SetPluginTrustPolicyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginTrustPolicyCallCount() int {
+	fake.setPluginTrustPolicyMutex.RLock()
+	defer fake.setPluginTrustPolicyMutex.RUnlock()
+	return len(fake.setPluginTrustPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginTrustPolicyArgsForCall(i int) string {
+	fake.setPluginTrustPolicyMutex.RLock()
+	defer fake.setPluginTrustPolicyMutex.RUnlock()
+	return fake.setPluginTrustPolicyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetTrustedPluginKey(arg1 models.PluginKey) {
+	fake.setTrustedPluginKeyMutex.Lock()
+	fake.setTrustedPluginKeyArgsForCall = append(fake.setTrustedPluginKeyArgsForCall, struct {
+		arg1 models.PluginKey
+	}{arg1})
+	fake.setTrustedPluginKeyMutex.Unlock()
+	if fake.SetTrustedPluginKeyStub != nil {
+		fake.SetTrustedPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetTrustedPluginKeyCallCount() int {
+	fake.setTrustedPluginKeyMutex.RLock()
+	defer fake.setTrustedPluginKeyMutex.RUnlock()
+	return len(fake.setTrustedPluginKeyArgsForCall)
+}
+
+func (fake *FakeRepository) SetTrustedPluginKeyArgsForCall(i int) models.PluginKey {
+	fake.setTrustedPluginKeyMutex.RLock()
+	defer fake.setTrustedPluginKeyMutex.RUnlock()
+	return fake.setTrustedPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UnSetTrustedPluginKey(arg1 string) {
+	fake.unSetTrustedPluginKeyMutex.Lock()
+	fake.unSetTrustedPluginKeyArgsForCall = append(fake.unSetTrustedPluginKeyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.unSetTrustedPluginKeyMutex.Unlock()
+	if fake.UnSetTrustedPluginKeyStub != nil {
+		fake.UnSetTrustedPluginKeyStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) UnSetTrustedPluginKeyCallCount() int {
+	fake.unSetTrustedPluginKeyMutex.RLock()
+	defer fake.unSetTrustedPluginKeyMutex.RUnlock()
+	return len(fake.unSetTrustedPluginKeyArgsForCall)
+}
+
+func (fake *FakeRepository) UnSetTrustedPluginKeyArgsForCall(i int) string {
+	fake.unSetTrustedPluginKeyMutex.RLock()
+	defer fake.unSetTrustedPluginKeyMutex.RUnlock()
+	return fake.unSetTrustedPluginKeyArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetSecretBackend(arg1 string) {
+	fake.setSecretBackendMutex.Lock()
+	fake.setSecretBackendArgsForCall = append(fake.setSecretBackendArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.setSecretBackendMutex.Unlock()
+	if fake.SetSecretBackendStub != nil {
+		fake.SetSecretBackendStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetSecretBackendCallCount() int {
+	fake.setSecretBackendMutex.RLock()
+	defer fake.setSecretBackendMutex.RUnlock()
+	return len(fake.setSecretBackendArgsForCall)
+}
+
+func (fake *FakeRepository) SetSecretBackendArgsForCall(i int) string {
+	fake.setSecretBackendMutex.RLock()
+	defer fake.setSecretBackendMutex.RUnlock()
+	return fake.setSecretBackendArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SaveContext(arg1 string) {
+	fake.saveContextMutex.Lock()
+	fake.saveContextArgsForCall = append(fake.saveContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.saveContextMutex.Unlock()
+	if fake.SaveContextStub != nil {
+		fake.SaveContextStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SaveContextCallCount() int {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return len(fake.saveContextArgsForCall)
+}
+
+func (fake *FakeRepository) SaveContextArgsForCall(i int) string {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return fake.saveContextArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseContext(arg1 string) error {
+	fake.useContextMutex.Lock()
+	fake.useContextArgsForCall = append(fake.useContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.useContextMutex.Unlock()
+	if fake.UseContextStub != nil {
+		return fake.UseContextStub(arg1)
+	} else {
+		return fake.useContextReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UseContextCallCount() int {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return len(fake.useContextArgsForCall)
+}
+
+func (fake *FakeRepository) UseContextArgsForCall(i int) string {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return fake.useContextArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseContextReturns(result1 error) {
+	fake.UseContextStub = nil
+	fake.useContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) OverrideContext(arg1 string) error {
+	fake.overrideContextMutex.Lock()
+	fake.overrideContextArgsForCall = append(fake.overrideContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.overrideContextMutex.Unlock()
+	if fake.OverrideContextStub != nil {
+		return fake.OverrideContextStub(arg1)
+	} else {
+		return fake.overrideContextReturns.result1
+	}
+}
+
+func (fake *FakeRepository) OverrideContextCallCount() int {
+	fake.overrideContextMutex.RLock()
+	defer fake.overrideContextMutex.RUnlock()
+	return len(fake.overrideContextArgsForCall)
+}
+
+func (fake *FakeRepository) OverrideContextArgsForCall(i int) string {
+	fake.overrideContextMutex.RLock()
+	defer fake.overrideContextMutex.RUnlock()
+	return fake.overrideContextArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) OverrideContextReturns(result1 error) {
+	fake.OverrideContextStub = nil
+	fake.overrideContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Close() {
 	fake.closeMutex.Lock()
 	fake.closeArgsForCall = append(fake.closeArgsForCall, struct{}{})
@@ -0,0 +1,295 @@
+package coreconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	"github.com/cloudfoundry/cli/cf/models"
+)
+
+const secretReferencePrefix = "cf-secret:"
+
+// SecretPersistor wraps a Persistor so that the tokens, client secrets and
+// plugin repository credentials in Data are kept in the secret backend named by Data.SecretBackend. The wrapped
+// persistor only sees references to them. Plaintext secrets found when loading
+// are moved into the backend.
+//
+// The keys of the secrets start with a hash of the path of the config file,
+// so that config files in different CF_HOMEs can share a backend. When the
+// wrapped persistor is a LockingPersistor, its lock is held while the backend
+// is written too.
+type SecretPersistor struct {
+	persistor configuration.Persistor
+	newStore  func(backend string) (secrets.Store, error)
+	scope     string
+
+	backend string
+	store   secrets.Store
+	stored  map[string]string
+}
+
+func NewSecretPersistor(persistor configuration.Persistor, configPath string, newStore func(backend string) (secrets.Store, error)) *SecretPersistor {
+	if absPath, err := filepath.Abs(configPath); err == nil {
+		configPath = absPath
+	}
+	sum := sha256.Sum256([]byte(configPath))
+
+	return &SecretPersistor{
+		persistor: persistor,
+		newStore:  newStore,
+		scope:     hex.EncodeToString(sum[:8]),
+		stored:    map[string]string{},
+	}
+}
+
+func (p *SecretPersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p *SecretPersistor) Delete() {
+	p.persistor.Delete()
+}
+
+func (p *SecretPersistor) Load(data configuration.DataInterface) error {
+	return p.withLock(func(persistor configuration.Persistor) error {
+		return p.load(persistor, data)
+	})
+}
+
+func (p *SecretPersistor) Save(data configuration.DataInterface) error {
+	return p.withLock(func(persistor configuration.Persistor) error {
+		return p.save(persistor, data)
+	})
+}
+
+// WithLock calls fn with a persistor that moves secrets like p does while the
+// lock of the wrapped persistor is held, if it has one
+func (p *SecretPersistor) WithLock(fn func(configuration.Persistor) error) error {
+	return p.withLock(func(persistor configuration.Persistor) error {
+		return fn(lockedSecretPersistor{SecretPersistor: p, persistor: persistor})
+	})
+}
+
+// lockedSecretPersistor is the persistor passed to the function given to
+// SecretPersistor.WithLock
+type lockedSecretPersistor struct {
+	*SecretPersistor
+	persistor configuration.Persistor
+}
+
+func (l lockedSecretPersistor) Load(data configuration.DataInterface) error {
+	return l.load(l.persistor, data)
+}
+
+func (l lockedSecretPersistor) Save(data configuration.DataInterface) error {
+	return l.save(l.persistor, data)
+}
+
+func (l lockedSecretPersistor) WithLock(fn func(configuration.Persistor) error) error {
+	return fn(l)
+}
+
+// withLock calls fn with the wrapped persistor, holding its lock if it has one
+func (p *SecretPersistor) withLock(fn func(configuration.Persistor) error) error {
+	if locking, ok := p.persistor.(configuration.LockingPersistor); ok {
+		return locking.WithLock(fn)
+	}
+	return fn(p.persistor)
+}
+
+func (p *SecretPersistor) load(persistor configuration.Persistor, data configuration.DataInterface) error {
+	err := persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	d, ok := data.(*Data)
+	if !ok {
+		return nil
+	}
+
+	store, err := p.storeFor(d.SecretBackend)
+	if err != nil {
+		return err
+	}
+
+	needsMigration := false
+	err = d.visitSecrets(func(key string, value *string) error {
+		if !strings.HasPrefix(*value, secretReferencePrefix) {
+			needsMigration = needsMigration || (store != nil && *value != "")
+			return nil
+		}
+
+		if store == nil {
+			*value = ""
+			return nil
+		}
+
+		reference := strings.TrimPrefix(*value, secretReferencePrefix)
+		secret, err := store.Get(reference)
+		if err == secrets.ErrNotFound {
+			*value = ""
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		*value = secret
+		if reference != p.key(key) {
+			// saved before keys were scoped; the secret is copied to the
+			// scoped key and the old one is left to other config files
+			needsMigration = true
+			return nil
+		}
+		p.stored[key] = secret
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if needsMigration {
+		return p.save(persistor, d)
+	}
+	return nil
+}
+
+func (p *SecretPersistor) save(persistor configuration.Persistor, data configuration.DataInterface) error {
+	d, ok := data.(*Data)
+	if !ok {
+		return persistor.Save(data)
+	}
+
+	store, err := p.storeFor(d.SecretBackend)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return persistor.Save(d)
+	}
+
+	persisted := *d
+	persisted.Contexts = make(map[string]SavedContext, len(d.Contexts))
+	for name, ctx := range d.Contexts {
+		persisted.Contexts[name] = ctx
+	}
+	persisted.PluginRepos = append([]models.PluginRepo(nil), d.PluginRepos...)
+
+	visited := map[string]bool{}
+	err = persisted.visitSecrets(func(key string, value *string) error {
+		visited[key] = true
+		if *value == "" {
+			if _, ok := p.stored[key]; ok {
+				err := store.Delete(p.key(key))
+				if err != nil {
+					return err
+				}
+				delete(p.stored, key)
+			}
+			return nil
+		}
+
+		if stored, ok := p.stored[key]; !ok || stored != *value {
+			err := store.Set(p.key(key), *value)
+			if err != nil {
+				return err
+			}
+			p.stored[key] = *value
+		}
+
+		*value = secretReferencePrefix + p.key(key)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// secrets of removed contexts and plugin repositories are not visited
+	for key := range p.stored {
+		if !visited[key] {
+			err = store.Delete(p.key(key))
+			if err != nil {
+				return err
+			}
+			delete(p.stored, key)
+		}
+	}
+
+	return persistor.Save(&persisted)
+}
+
+// key returns the key in the backend of the secret visited as name
+func (p *SecretPersistor) key(name string) string {
+	return p.scope + "/" + name
+}
+
+// storeFor returns the store for the backend, reusing the previous one while
+// the backend is unchanged
+func (p *SecretPersistor) storeFor(backend string) (secrets.Store, error) {
+	if p.store != nil && p.backend == backend {
+		return p.store, nil
+	}
+
+	store, err := p.newStore(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	p.backend = backend
+	p.store = store
+	p.stored = map[string]string{}
+	return store, nil
+}
+
+// visitSecrets calls visit with a pointer to every secret held in d, keyed by
+// a name that is stable across saves
+func (d *Data) visitSecrets(visit func(key string, value *string) error) error {
+	err := visitSessionSecrets("", &d.AccessToken, &d.RefreshToken, &d.UAAOAuthClientSecret, visit)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range ContextNames(d.Contexts) {
+		ctx := d.Contexts[name]
+		err = visitSessionSecrets("contexts/"+name+"/", &ctx.AccessToken, &ctx.RefreshToken, &ctx.UAAOAuthClientSecret, visit)
+		if err != nil {
+			return err
+		}
+		d.Contexts[name] = ctx
+	}
+
+	for i := range d.PluginRepos {
+		prefix := "plugin-repos/" + d.PluginRepos[i].Name + "/"
+
+		err = visit(prefix+"Password", &d.PluginRepos[i].Password)
+		if err != nil {
+			return err
+		}
+
+		err = visit(prefix+"Token", &d.PluginRepos[i].Token)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func visitSessionSecrets(prefix string, accessToken, refreshToken, clientSecret *string, visit func(key string, value *string) error) error {
+	err := visit(prefix+"AccessToken", accessToken)
+	if err != nil {
+		return err
+	}
+
+	err = visit(prefix+"RefreshToken", refreshToken)
+	if err != nil {
+		return err
+	}
+
+	return visit(prefix+"UAAOAuthClientSecret", clientSecret)
+}
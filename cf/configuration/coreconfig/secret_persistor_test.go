@@ -0,0 +1,259 @@
+package coreconfig_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/configuration"
+	"github.com/cloudfoundry/cli/cf/configuration/configurationfakes"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets/secretsfakes"
+	"github.com/cloudfoundry/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretPersistor", func() {
+	var (
+		diskPersistor *configurationfakes.FakePersistor
+		onDisk        *coreconfig.Data
+		store         *secrets.MemoryStore
+		storeErr      error
+		persistor     *coreconfig.SecretPersistor
+	)
+
+	const configPath = "/home/user/.cf/config.json"
+
+	var newStore = func(backend string) (secrets.Store, error) {
+		if backend == "" || backend == "plaintext" {
+			return nil, nil
+		}
+		return store, storeErr
+	}
+
+	var scoped = func(path, key string) string {
+		sum := sha256.Sum256([]byte(path))
+		return hex.EncodeToString(sum[:8]) + "/" + key
+	}
+
+	BeforeEach(func() {
+		onDisk = &coreconfig.Data{}
+		diskPersistor = new(configurationfakes.FakePersistor)
+		diskPersistor.LoadStub = func(data configuration.DataInterface) error {
+			*data.(*coreconfig.Data) = *onDisk
+			return nil
+		}
+		diskPersistor.SaveStub = func(data configuration.DataInterface) error {
+			saved := *data.(*coreconfig.Data)
+			onDisk = &saved
+			return nil
+		}
+
+		store = secrets.NewMemoryStore()
+		storeErr = nil
+		persistor = coreconfig.NewSecretPersistor(diskPersistor, configPath, newStore)
+	})
+
+	Context("with the plaintext backend", func() {
+		It("saves the tokens in the config file", func() {
+			Expect(persistor.Save(&coreconfig.Data{AccessToken: "bearer my-token"})).To(Succeed())
+			Expect(onDisk.AccessToken).To(Equal("bearer my-token"))
+		})
+	})
+
+	Context("with a secret backend", func() {
+		It("saves references to the secrets in the config file", func() {
+			data := &coreconfig.Data{
+				SecretBackend:        "agent",
+				Target:               "https://api.example.com",
+				AccessToken:          "bearer my-token",
+				RefreshToken:         "my-refresh-token",
+				UAAOAuthClientSecret: "my-client-secret",
+				Contexts: map[string]coreconfig.SavedContext{
+					"prod": {AccessToken: "bearer prod-token"},
+				},
+			}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(onDisk.Target).To(Equal("https://api.example.com"))
+			Expect(onDisk.AccessToken).To(Equal("cf-secret:" + scoped(configPath, "AccessToken")))
+			Expect(onDisk.RefreshToken).To(Equal("cf-secret:" + scoped(configPath, "RefreshToken")))
+			Expect(onDisk.UAAOAuthClientSecret).To(Equal("cf-secret:" + scoped(configPath, "UAAOAuthClientSecret")))
+			Expect(onDisk.Contexts["prod"].AccessToken).To(Equal("cf-secret:" + scoped(configPath, "contexts/prod/AccessToken")))
+			Expect(onDisk.Contexts["prod"].RefreshToken).To(BeEmpty())
+
+			Expect(store.Get(scoped(configPath, "AccessToken"))).To(Equal("bearer my-token"))
+			Expect(store.Get(scoped(configPath, "RefreshToken"))).To(Equal("my-refresh-token"))
+			Expect(store.Get(scoped(configPath, "UAAOAuthClientSecret"))).To(Equal("my-client-secret"))
+			Expect(store.Get(scoped(configPath, "contexts/prod/AccessToken"))).To(Equal("bearer prod-token"))
+
+			By("leaving the in-memory data untouched")
+			Expect(data.AccessToken).To(Equal("bearer my-token"))
+			Expect(data.Contexts["prod"].AccessToken).To(Equal("bearer prod-token"))
+		})
+
+		It("resolves the references when loading", func() {
+			onDisk = &coreconfig.Data{
+				SecretBackend: "agent",
+				AccessToken:   "cf-secret:" + scoped(configPath, "AccessToken"),
+				RefreshToken:  "cf-secret:" + scoped(configPath, "RefreshToken"),
+			}
+			Expect(store.Set(scoped(configPath, "AccessToken"), "bearer my-token")).To(Succeed())
+
+			data := coreconfig.NewData()
+			Expect(persistor.Load(data)).To(Succeed())
+
+			Expect(data.AccessToken).To(Equal("bearer my-token"))
+			Expect(data.RefreshToken).To(BeEmpty())
+			Expect(diskPersistor.SaveCallCount()).To(BeZero())
+		})
+
+		It("moves secrets saved under unscoped keys to scoped ones, leaving the old keys", func() {
+			onDisk = &coreconfig.Data{SecretBackend: "agent", AccessToken: "cf-secret:AccessToken"}
+			Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+			data := coreconfig.NewData()
+			Expect(persistor.Load(data)).To(Succeed())
+
+			Expect(data.AccessToken).To(Equal("bearer my-token"))
+			Expect(onDisk.AccessToken).To(Equal("cf-secret:" + scoped(configPath, "AccessToken")))
+			Expect(store.Get(scoped(configPath, "AccessToken"))).To(Equal("bearer my-token"))
+			Expect(store.Get("AccessToken")).To(Equal("bearer my-token"))
+		})
+
+		It("keeps the secrets of config files in different directories apart", func() {
+			otherDisk := &coreconfig.Data{}
+			otherDiskPersistor := new(configurationfakes.FakePersistor)
+			otherDiskPersistor.SaveStub = func(data configuration.DataInterface) error {
+				saved := *data.(*coreconfig.Data)
+				otherDisk = &saved
+				return nil
+			}
+			otherPersistor := coreconfig.NewSecretPersistor(otherDiskPersistor, "/home/other/.cf/config.json", newStore)
+
+			Expect(persistor.Save(&coreconfig.Data{
+				SecretBackend: "agent",
+				AccessToken:   "bearer my-token",
+				PluginRepos:   []models.PluginRepo{{Name: "private", Token: "my-repo-token"}},
+			})).To(Succeed())
+			Expect(otherPersistor.Save(&coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer other-token"})).To(Succeed())
+
+			Expect(otherDisk.AccessToken).To(Equal("cf-secret:" + scoped("/home/other/.cf/config.json", "AccessToken")))
+			Expect(store.Get(scoped(configPath, "AccessToken"))).To(Equal("bearer my-token"))
+			Expect(store.Get(scoped(configPath, "plugin-repos/private/Token"))).To(Equal("my-repo-token"))
+			Expect(store.Get(scoped("/home/other/.cf/config.json", "AccessToken"))).To(Equal("bearer other-token"))
+		})
+
+		It("moves plaintext secrets in an existing config file into the backend", func() {
+			onDisk = &coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer my-token"}
+
+			data := coreconfig.NewData()
+			Expect(persistor.Load(data)).To(Succeed())
+
+			Expect(data.AccessToken).To(Equal("bearer my-token"))
+			Expect(onDisk.AccessToken).To(Equal("cf-secret:" + scoped(configPath, "AccessToken")))
+			Expect(store.Get(scoped(configPath, "AccessToken"))).To(Equal("bearer my-token"))
+		})
+
+		It("removes secrets from the backend when they are cleared", func() {
+			data := &coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer my-token"}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			data.AccessToken = ""
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(onDisk.AccessToken).To(BeEmpty())
+			_, err := store.Get(scoped(configPath, "AccessToken"))
+			Expect(err).To(Equal(secrets.ErrNotFound))
+		})
+
+		It("keeps the credentials of plugin repositories in the backend", func() {
+			data := &coreconfig.Data{
+				SecretBackend: "agent",
+				PluginRepos: []models.PluginRepo{
+					{Name: "private", URL: "https://plugins.example.com", Username: "admin", Password: "secret"},
+				},
+			}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(onDisk.PluginRepos[0].Username).To(Equal("admin"))
+			Expect(onDisk.PluginRepos[0].Password).To(Equal("cf-secret:" + scoped(configPath, "plugin-repos/private/Password")))
+			Expect(onDisk.PluginRepos[0].Token).To(BeEmpty())
+			Expect(store.Get(scoped(configPath, "plugin-repos/private/Password"))).To(Equal("secret"))
+			Expect(data.PluginRepos[0].Password).To(Equal("secret"))
+		})
+
+		It("removes the secrets of removed plugin repositories from the backend", func() {
+			data := &coreconfig.Data{
+				SecretBackend: "agent",
+				PluginRepos:   []models.PluginRepo{{Name: "private", Token: "my-token"}},
+			}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			data.PluginRepos = nil
+			Expect(persistor.Save(data)).To(Succeed())
+
+			_, err := store.Get(scoped(configPath, "plugin-repos/private/Token"))
+			Expect(err).To(Equal(secrets.ErrNotFound))
+		})
+
+		It("writes the secrets back to the config file when switching to plaintext", func() {
+			data := &coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer my-token"}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			data.SecretBackend = "plaintext"
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(onDisk.AccessToken).To(Equal("bearer my-token"))
+		})
+
+		It("returns the error when the backend is unavailable", func() {
+			storeErr = errors.New("CF_SECRETS_AGENT_SOCK must be set")
+
+			err := persistor.Save(&coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer my-token"})
+			Expect(err).To(MatchError("CF_SECRETS_AGENT_SOCK must be set"))
+			Expect(diskPersistor.SaveCallCount()).To(BeZero())
+		})
+
+		It("writes the backend while holding the lock of a locking persistor", func() {
+			locked := false
+			lockedSets := 0
+
+			fakeStore := new(secretsfakes.FakeStore)
+			fakeStore.SetStub = func(key, value string) error {
+				if locked {
+					lockedSets++
+				}
+				return nil
+			}
+			diskPersistor.SaveStub = func(configuration.DataInterface) error {
+				Expect(locked).To(BeTrue())
+				return nil
+			}
+
+			persistor = coreconfig.NewSecretPersistor(&lockingPersistor{FakePersistor: diskPersistor, locked: &locked}, configPath, func(string) (secrets.Store, error) {
+				return fakeStore, nil
+			})
+			Expect(persistor.Save(&coreconfig.Data{SecretBackend: "agent", AccessToken: "bearer my-token"})).To(Succeed())
+
+			Expect(fakeStore.SetCallCount()).To(Equal(1))
+			Expect(lockedSets).To(Equal(1))
+			Expect(diskPersistor.SaveCallCount()).To(Equal(1))
+			Expect(locked).To(BeFalse())
+		})
+	})
+})
+
+type lockingPersistor struct {
+	*configurationfakes.FakePersistor
+	locked *bool
+}
+
+func (p *lockingPersistor) WithLock(fn func(configuration.Persistor) error) error {
+	*p.locked = true
+	defer func() { *p.locked = false }()
+	return fn(p.FakePersistor)
+}
@@ -0,0 +1,129 @@
+package pluginconfig
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// FindCommand returns the plugin command that has name as its name or one of
+// its aliases
+func FindCommand(pluginList map[string]PluginMetadata, name string) (plugin.Command, bool) {
+	for _, metadata := range pluginList {
+		for _, command := range metadata.Commands {
+			if command.Matches(name) {
+				return command, true
+			}
+		}
+	}
+	return plugin.Command{}, false
+}
+
+// NewFlagContext returns a flag context for the typed flags the plugin
+// command declared. It fails when a flag has no name, an unknown type or a
+// default that does not match its type.
+func NewFlagContext(command plugin.Command) (flags.FlagContext, error) {
+	fs := make(map[string]flags.FlagSet)
+
+	for _, f := range command.Flags {
+		key := f.Name
+		if key == "" {
+			key = f.ShortName
+		}
+		if key == "" {
+			return nil, errors.New(T("A flag of command {{.Command}} has no name", map[string]interface{}{"Command": command.Name}))
+		}
+
+		usage := flagUsage(f)
+
+		switch f.Type {
+		case plugin.FlagTypeBool:
+			fs[key] = &flags.BoolFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage}
+		case plugin.FlagTypeString:
+			fs[key] = &flags.StringFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage, Value: f.Default}
+		case plugin.FlagTypeInt:
+			value := 0
+			if f.Default != "" {
+				var err error
+				value, err = strconv.Atoi(f.Default)
+				if err != nil {
+					return nil, errors.New(T("The default of flag {{.Flag}} of command {{.Command}} must be an integer",
+						map[string]interface{}{"Flag": key, "Command": command.Name}))
+				}
+			}
+			fs[key] = &flags.IntFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage, Value: value}
+		case plugin.FlagTypeStringSlice:
+			var value []string
+			if f.Default != "" {
+				value = strings.Split(f.Default, ",")
+			}
+			fs[key] = &flags.StringSliceFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage, Value: value}
+		default:
+			return nil, errors.New(T("Flag {{.Flag}} of command {{.Command}} has unknown type '{{.Type}}'",
+				map[string]interface{}{"Flag": key, "Command": command.Name, "Type": string(f.Type)}))
+		}
+	}
+
+	return flags.NewFlagContext(fs), nil
+}
+
+// ValidateArgs checks the args of a plugin command against the flags it
+// declared. Commands that declare no flags parse their own args and are
+// not checked.
+func ValidateArgs(command plugin.Command, args []string) error {
+	if len(command.Flags) == 0 {
+		return nil
+	}
+
+	fc, err := NewFlagContext(command)
+	if err != nil {
+		return err
+	}
+
+	err = fc.Parse(args...)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, f := range command.Flags {
+		name := f.Name
+		if name == "" {
+			name = f.ShortName
+		}
+		if f.Required && !fc.IsSet(name) {
+			missing = append(missing, flagDisplayName(f))
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New(T("Missing required flags: {{.Flags}}", map[string]interface{}{"Flags": strings.Join(missing, ", ")}))
+	}
+
+	return nil
+}
+
+// flagUsage appends the default and whether the flag is required to its
+// usage, the way the usage of core command flags reads
+func flagUsage(f plugin.Flag) string {
+	usage := f.Usage
+	if f.Default != "" {
+		usage += " " + T("(Default: {{.Default}})", map[string]interface{}{"Default": f.Default})
+	}
+	if f.Required {
+		usage += " " + T("(Required)")
+	}
+	return strings.TrimSpace(usage)
+}
+
+func flagDisplayName(f plugin.Flag) string {
+	if f.Name != "" {
+		return fmt.Sprintf("--%s", f.Name)
+	}
+	return fmt.Sprintf("-%s", f.ShortName)
+}
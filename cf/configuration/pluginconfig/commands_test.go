@@ -0,0 +1,112 @@
+package pluginconfig_test
+
+import (
+	. "github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/plugin"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin commands", func() {
+	var command plugin.Command
+
+	BeforeEach(func() {
+		command = plugin.Command{
+			Name:    "deploy",
+			Alias:   "d",
+			Aliases: []string{"ship"},
+			Flags: []plugin.Flag{
+				{Name: "ticket", ShortName: "t", Type: plugin.FlagTypeString, Required: true, Usage: "Change ticket"},
+				{Name: "instances", Type: plugin.FlagTypeInt, Default: "2", Usage: "Number of instances"},
+				{ShortName: "f", Type: plugin.FlagTypeBool, Usage: "Force"},
+				{Name: "env", Type: plugin.FlagTypeStringSlice, Usage: "Environment variables"},
+			},
+		}
+	})
+
+	Describe("FindCommand", func() {
+		var pluginList map[string]PluginMetadata
+
+		BeforeEach(func() {
+			pluginList = map[string]PluginMetadata{
+				"Deployer": {Commands: []plugin.Command{command}},
+			}
+		})
+
+		It("finds a command by its name and all of its aliases", func() {
+			for _, name := range []string{"deploy", "d", "ship"} {
+				found, ok := FindCommand(pluginList, name)
+				Expect(ok).To(BeTrue())
+				Expect(found.Name).To(Equal("deploy"))
+			}
+		})
+
+		It("does not find unknown commands", func() {
+			_, ok := FindCommand(pluginList, "undeploy")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("NewFlagContext", func() {
+		It("shows the usage of the typed flags", func() {
+			fc, err := NewFlagContext(command)
+			Expect(err).NotTo(HaveOccurred())
+
+			usage := fc.ShowUsage(3)
+			Expect(usage).To(MatchRegexp(`--instances\s+Number of instances \(Default: 2\)`))
+			Expect(usage).To(MatchRegexp(`--ticket, -t\s+Change ticket \(Required\)`))
+			Expect(usage).To(MatchRegexp(`-f\s+Force`))
+		})
+
+		It("fails for a flag of unknown type", func() {
+			command.Flags = []plugin.Flag{{Name: "when", Type: "date"}}
+
+			_, err := NewFlagContext(command)
+			Expect(err).To(MatchError("Flag when of command deploy has unknown type 'date'"))
+		})
+
+		It("fails for an int flag with a default that is not an integer", func() {
+			command.Flags = []plugin.Flag{{Name: "instances", Type: plugin.FlagTypeInt, Default: "many"}}
+
+			_, err := NewFlagContext(command)
+			Expect(err).To(MatchError("The default of flag instances of command deploy must be an integer"))
+		})
+
+		It("fails for a flag without a name", func() {
+			command.Flags = []plugin.Flag{{Type: plugin.FlagTypeBool}}
+
+			_, err := NewFlagContext(command)
+			Expect(err).To(MatchError("A flag of command deploy has no name"))
+		})
+	})
+
+	Describe("ValidateArgs", func() {
+		It("accepts args that match the declared flags", func() {
+			err := ValidateArgs(command, []string{"my-app", "-t", "CHG-1", "--instances", "3", "-f", "--env", "A=1", "--env", "B=2"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects flags that were not declared", func() {
+			err := ValidateArgs(command, []string{"my-app", "-t", "CHG-1", "--force"})
+			Expect(err).To(MatchError("Invalid flag: --force"))
+		})
+
+		It("rejects values of the wrong type", func() {
+			err := ValidateArgs(command, []string{"my-app", "-t", "CHG-1", "--instances", "many"})
+			Expect(err).To(MatchError("Value for flag 'instances' must be an integer"))
+		})
+
+		It("rejects args without the required flags", func() {
+			err := ValidateArgs(command, []string{"my-app"})
+			Expect(err).To(MatchError("Missing required flags: --ticket"))
+		})
+
+		It("does not check commands without declared flags", func() {
+			command.Flags = nil
+
+			err := ValidateArgs(command, []string{"my-app", "--anything"})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -14,6 +14,11 @@ type PluginMetadata struct {
 	Location string
 	Version  plugin.VersionType
 	Commands []plugin.Command
+	Hooks    []plugin.Hook
+
+	// AuthenticatedRpc is set for plugins that send the token of the RPC
+	// server, which are run with an authenticated server
+	AuthenticatedRpc bool
 }
 
 func NewData() *PluginData {
@@ -0,0 +1,37 @@
+// +build !windows
+
+package safefile
+
+import (
+	"os"
+	"syscall"
+)
+
+// Lock blocks until an exclusive advisory lock for path is held and returns a
+// function that releases it
+func Lock(path string) (func(), error) {
+	file, err := os.OpenFile(LockPath(path), os.O_RDWR|os.O_CREATE, 0600)
+	if os.IsPermission(err) {
+		// a read-only directory can still be read without a lock
+		return func() {}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			break
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_ = syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+		file.Close()
+	}, nil
+}
@@ -0,0 +1,42 @@
+// +build windows
+
+package safefile
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const lockfileExclusiveLock = 0x00000002
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+// Lock blocks until an exclusive lock for path is held and returns a function
+// that releases it
+func Lock(path string) (func(), error) {
+	file, err := os.OpenFile(LockPath(path), os.O_RDWR|os.O_CREATE, 0600)
+	if os.IsPermission(err) {
+		// a read-only directory can still be read without a lock
+		return func() {}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	overlapped := new(syscall.Overlapped)
+	r1, _, err := procLockFileEx.Call(file.Fd(), lockfileExclusiveLock, 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+	if r1 == 0 {
+		file.Close()
+		return nil, err
+	}
+
+	return func() {
+		_, _, _ = procUnlockFileEx.Call(file.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(overlapped)))
+		file.Close()
+	}, nil
+}
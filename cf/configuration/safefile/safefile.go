@@ -0,0 +1,47 @@
+// Package safefile reads and writes files that several cf processes share.
+// Writers hold an advisory lock on a sibling file, and files are replaced
+// atomically so that readers never observe a partial write.
+package safefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LockPath returns the path of the file locked by Lock for path
+func LockPath(path string) string {
+	return path + ".lock"
+}
+
+// WriteFile replaces the file at path with data by writing a temporary file
+// in the same directory and renaming it over the original
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmpFile, err := ioutil.TempFile(dir, "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if err == nil {
+		err = tmpFile.Chmod(perm)
+	}
+	closeErr := tmpFile.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
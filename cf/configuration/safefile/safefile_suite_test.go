@@ -0,0 +1,13 @@
+package safefile_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSafefile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Safefile Suite")
+}
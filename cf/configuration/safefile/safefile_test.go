@@ -0,0 +1,70 @@
+package safefile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/safefile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("safefile", func() {
+	var (
+		tmpDir string
+		path   string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "safefile")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(tmpDir, "config.json")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("WriteFile", func() {
+		It("replaces the file without leaving temporary files behind", func() {
+			Expect(ioutil.WriteFile(path, []byte("first"), 0644)).To(Succeed())
+			Expect(safefile.WriteFile(path, []byte("second"), 0600)).To(Succeed())
+
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("second"))
+
+			info, err := os.Stat(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+			leftovers, err := filepath.Glob(filepath.Join(tmpDir, ".config.json.tmp*"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leftovers).To(BeEmpty())
+		})
+	})
+
+	Describe("Lock", func() {
+		It("waits until the holder releases the lock", func() {
+			unlock, err := safefile.Lock(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			acquired := make(chan struct{})
+			go func() {
+				defer GinkgoRecover()
+				unlockAgain, err := safefile.Lock(path)
+				Expect(err).NotTo(HaveOccurred())
+				close(acquired)
+				unlockAgain()
+			}()
+
+			Consistently(acquired, 100*time.Millisecond).ShouldNot(BeClosed())
+			unlock()
+			Eventually(acquired).Should(BeClosed())
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+)
+
+const agentTimeout = 10 * time.Second
+
+// AgentRequest is a single request sent to a secrets agent. Each connection
+// carries one JSON encoded request followed by one JSON encoded AgentResponse.
+type AgentRequest struct {
+	Op    string `json:"op"`
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type AgentResponse struct {
+	Value    string `json:"value,omitempty"`
+	NotFound bool   `json:"not_found,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AgentStore keeps secrets in a separate agent process listening on a unix
+// socket, in the manner of ssh-agent
+type AgentStore struct {
+	socket string
+}
+
+func NewAgentStore(socket string) AgentStore {
+	return AgentStore{socket: socket}
+}
+
+func (s AgentStore) Get(key string) (string, error) {
+	return s.do(AgentRequest{Op: "get", Key: key})
+}
+
+func (s AgentStore) Set(key, value string) error {
+	_, err := s.do(AgentRequest{Op: "set", Key: key, Value: value})
+	return err
+}
+
+func (s AgentStore) Delete(key string) error {
+	_, err := s.do(AgentRequest{Op: "delete", Key: key})
+	return err
+}
+
+func (s AgentStore) do(request AgentRequest) (string, error) {
+	conn, err := net.DialTimeout("unix", s.socket, agentTimeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(agentTimeout))
+
+	err = json.NewEncoder(conn).Encode(request)
+	if err != nil {
+		return "", err
+	}
+
+	response := AgentResponse{}
+	err = json.NewDecoder(conn).Decode(&response)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case response.NotFound:
+		return "", ErrNotFound
+	case response.Error != "":
+		return "", errors.New(response.Error)
+	default:
+		return response.Value, nil
+	}
+}
+
+// ServeAgent answers AgentStore requests on listener using store until the
+// listener is closed
+func ServeAgent(listener net.Listener, store Store) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go serveAgentConn(conn, store)
+	}
+}
+
+func serveAgentConn(conn net.Conn, store Store) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(agentTimeout))
+
+	request := AgentRequest{}
+	err := json.NewDecoder(conn).Decode(&request)
+	if err != nil {
+		return
+	}
+
+	response := AgentResponse{}
+	switch request.Op {
+	case "get":
+		response.Value, err = store.Get(request.Key)
+	case "set":
+		err = store.Set(request.Key, request.Value)
+	case "delete":
+		err = store.Delete(request.Key)
+	default:
+		err = errors.New("unknown operation " + request.Op)
+	}
+
+	if err == ErrNotFound {
+		response.NotFound = true
+	} else if err != nil {
+		response.Error = err.Error()
+	}
+
+	_ = json.NewEncoder(conn).Encode(response)
+}
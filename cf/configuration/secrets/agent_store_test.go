@@ -0,0 +1,78 @@
+package secrets_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets/secretsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AgentStore", func() {
+	var (
+		tmpDir   string
+		socket   string
+		listener net.Listener
+		backing  secrets.Store
+		store    secrets.AgentStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "agent-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		socket = filepath.Join(tmpDir, "agent.sock")
+		listener, err = net.Listen("unix", socket)
+		Expect(err).NotTo(HaveOccurred())
+
+		backing = secrets.NewMemoryStore()
+		store = secrets.NewAgentStore(socket)
+	})
+
+	JustBeforeEach(func() {
+		go secrets.ServeAgent(listener, backing)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("stores, retrieves and deletes secrets through the agent", func() {
+		_, err := store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+		Expect(store.Get("AccessToken")).To(Equal("bearer my-token"))
+		Expect(backing.Get("AccessToken")).To(Equal("bearer my-token"))
+
+		Expect(store.Delete("AccessToken")).To(Succeed())
+		_, err = store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+	})
+
+	Context("when the agent's store fails", func() {
+		BeforeEach(func() {
+			fakeStore := new(secretsfakes.FakeStore)
+			fakeStore.SetReturns(errors.New("agent is read only"))
+			backing = fakeStore
+		})
+
+		It("returns the agent's error", func() {
+			Expect(store.Set("AccessToken", "bearer my-token")).To(MatchError("agent is read only"))
+		})
+	})
+
+	It("returns an error when the agent is not running", func() {
+		store = secrets.NewAgentStore(filepath.Join(tmpDir, "missing.sock"))
+		_, err := store.Get("AccessToken")
+		Expect(err).To(HaveOccurred())
+	})
+})
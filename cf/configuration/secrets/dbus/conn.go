@@ -0,0 +1,151 @@
+// Package dbus is a minimal D-Bus client, sufficient for talking to the
+// freedesktop.org Secret Service over a unix socket.
+package dbus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SessionBusAddress returns the address of the session bus from the
+// environment
+func SessionBusAddress() string {
+	return os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+}
+
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mutex  sync.Mutex
+	serial uint32
+}
+
+// Dial connects to the bus at address (e.g. "unix:path=/run/user/1000/bus"),
+// authenticates as the current user and registers with the bus
+func Dial(address string) (*Conn, error) {
+	network, socket, err := parseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	netConn, err := net.Dial(network, socket)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conn{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+	}
+
+	err = c.authenticate()
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	_, err = c.Call("org.freedesktop.DBus", "/org/freedesktop/DBus", "org.freedesktop.DBus", "Hello", "")
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func parseAddress(address string) (string, string, error) {
+	// a bus address may list several alternatives separated by ';'
+	for _, candidate := range strings.Split(address, ";") {
+		if !strings.HasPrefix(candidate, "unix:") {
+			continue
+		}
+
+		for _, param := range strings.Split(strings.TrimPrefix(candidate, "unix:"), ",") {
+			switch {
+			case strings.HasPrefix(param, "path="):
+				return "unix", strings.TrimPrefix(param, "path="), nil
+			case strings.HasPrefix(param, "abstract="):
+				return "unix", "@" + strings.TrimPrefix(param, "abstract="), nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("dbus: unsupported bus address %q", address)
+}
+
+func (c *Conn) authenticate() error {
+	uid := fmt.Sprintf("%x", strconv.Itoa(os.Getuid()))
+	_, err := fmt.Fprintf(c.conn, "\x00AUTH EXTERNAL %s\r\n", uid)
+	if err != nil {
+		return err
+	}
+
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("dbus: authentication rejected: %s", strings.TrimSpace(line))
+	}
+
+	_, err = fmt.Fprint(c.conn, "BEGIN\r\n")
+	return err
+}
+
+// Call invokes a method and waits for its reply, returning the reply body
+func (c *Conn) Call(destination string, path ObjectPath, iface, member string, sig Signature, args ...interface{}) ([]interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.serial++
+	call := &Message{
+		Type:        TypeMethodCall,
+		Serial:      c.serial,
+		Path:        path,
+		Interface:   iface,
+		Member:      member,
+		Destination: destination,
+		Signature:   sig,
+		Body:        args,
+	}
+
+	_, err := call.WriteTo(c.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		reply, err := ReadMessage(c.reader)
+		if err != nil {
+			return nil, err
+		}
+
+		if reply.ReplySerial != call.Serial {
+			// signals and unrelated replies are not of interest
+			continue
+		}
+
+		switch reply.Type {
+		case TypeMethodReturn:
+			return reply.Body, nil
+		case TypeError:
+			dbusErr := &Error{Name: reply.ErrorName}
+			if len(reply.Body) > 0 {
+				dbusErr.Message, _ = reply.Body[0].(string)
+			}
+			return nil, dbusErr
+		default:
+			return nil, errors.New("dbus: unexpected reply type")
+		}
+	}
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
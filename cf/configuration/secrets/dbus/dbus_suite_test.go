@@ -0,0 +1,13 @@
+package dbus_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestDbus(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dbus Suite")
+}
@@ -0,0 +1,467 @@
+package dbus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ObjectPath is a D-Bus object path ('o')
+type ObjectPath string
+
+// Signature is a D-Bus type signature ('g')
+type Signature string
+
+// Variant is a D-Bus variant ('v'); Sig is the signature of Value
+type Variant struct {
+	Sig   Signature
+	Value interface{}
+}
+
+// MakeVariant wraps a string, bool, uint32, ObjectPath or map[string]string
+// in a Variant with the matching signature
+func MakeVariant(value interface{}) Variant {
+	switch value.(type) {
+	case string:
+		return Variant{Sig: "s", Value: value}
+	case bool:
+		return Variant{Sig: "b", Value: value}
+	case uint32:
+		return Variant{Sig: "u", Value: value}
+	case ObjectPath:
+		return Variant{Sig: "o", Value: value}
+	case map[string]string:
+		return Variant{Sig: "a{ss}", Value: value}
+	default:
+		panic(fmt.Sprintf("dbus: cannot make variant of %T", value))
+	}
+}
+
+func alignment(typeCode byte) int {
+	switch typeCode {
+	case 'y', 'g', 'v':
+		return 1
+	case 'n', 'q':
+		return 2
+	case 'x', 't', 'd', '(', '{':
+		return 8
+	default:
+		return 4
+	}
+}
+
+// nextType splits the first complete type off a signature
+func nextType(sig string) (string, string, error) {
+	if sig == "" {
+		return "", "", errors.New("dbus: empty signature")
+	}
+
+	switch sig[0] {
+	case 'a':
+		elem, rest, err := nextType(sig[1:])
+		if err != nil {
+			return "", "", err
+		}
+		return "a" + elem, rest, nil
+	case '(', '{':
+		closer := byte(')')
+		if sig[0] == '{' {
+			closer = '}'
+		}
+		depth := 0
+		for i := 0; i < len(sig); i++ {
+			switch sig[i] {
+			case '(', '{':
+				depth++
+			case ')', '}':
+				depth--
+				if depth == 0 {
+					if sig[i] != closer {
+						return "", "", fmt.Errorf("dbus: malformed signature %q", sig)
+					}
+					return sig[:i+1], sig[i+1:], nil
+				}
+			}
+		}
+		return "", "", fmt.Errorf("dbus: malformed signature %q", sig)
+	default:
+		return sig[:1], sig[1:], nil
+	}
+}
+
+func splitTypes(sig string) ([]string, error) {
+	types := []string{}
+	for sig != "" {
+		var t string
+		var err error
+		t, sig, err = nextType(sig)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+type encoder struct {
+	buf    *bytes.Buffer
+	offset int
+}
+
+func newEncoder(offset int) *encoder {
+	return &encoder{buf: new(bytes.Buffer), offset: offset}
+}
+
+func (e *encoder) pos() int {
+	return e.offset + e.buf.Len()
+}
+
+func (e *encoder) align(n int) {
+	for e.pos()%n != 0 {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.align(4)
+	_ = binary.Write(e.buf, binary.LittleEndian, v)
+}
+
+func (e *encoder) str(v string) {
+	e.uint32(uint32(len(v)))
+	e.buf.WriteString(v)
+	e.buf.WriteByte(0)
+}
+
+func (e *encoder) encodeAll(sig string, values []interface{}) error {
+	types, err := splitTypes(sig)
+	if err != nil {
+		return err
+	}
+	if len(types) != len(values) {
+		return fmt.Errorf("dbus: signature %q does not match %d values", sig, len(values))
+	}
+
+	for i, t := range types {
+		err = e.encode(t, values[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encode(sig string, value interface{}) error {
+	switch sig[0] {
+	case 'y':
+		v, ok := value.(byte)
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.buf.WriteByte(v)
+	case 'b':
+		v, ok := value.(bool)
+		if !ok {
+			return typeError(sig, value)
+		}
+		if v {
+			e.uint32(1)
+		} else {
+			e.uint32(0)
+		}
+	case 'u':
+		v, ok := value.(uint32)
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.uint32(v)
+	case 'i':
+		v, ok := value.(int32)
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.uint32(uint32(v))
+	case 's':
+		v, ok := value.(string)
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.str(v)
+	case 'o':
+		switch v := value.(type) {
+		case ObjectPath:
+			e.str(string(v))
+		case string:
+			e.str(v)
+		default:
+			return typeError(sig, value)
+		}
+	case 'g':
+		v, ok := value.(Signature)
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.buf.WriteByte(byte(len(v)))
+		e.buf.WriteString(string(v))
+		e.buf.WriteByte(0)
+	case 'v':
+		v, ok := value.(Variant)
+		if !ok {
+			return typeError(sig, value)
+		}
+		err := e.encode("g", v.Sig)
+		if err != nil {
+			return err
+		}
+		return e.encode(string(v.Sig), v.Value)
+	case '(':
+		v, ok := value.([]interface{})
+		if !ok {
+			return typeError(sig, value)
+		}
+		e.align(8)
+		return e.encodeAll(sig[1:len(sig)-1], v)
+	case 'a':
+		return e.encodeArray(sig, value)
+	default:
+		return fmt.Errorf("dbus: unsupported type %q", sig)
+	}
+	return nil
+}
+
+func (e *encoder) encodeArray(sig string, value interface{}) error {
+	elemSig := sig[1:]
+	rv := reflect.ValueOf(value)
+
+	e.uint32(0)
+	lengthAt := e.buf.Len() - 4
+	e.align(alignment(elemSig[0]))
+	start := e.buf.Len()
+
+	if elemSig[0] == '{' {
+		if rv.Kind() != reflect.Map {
+			return typeError(sig, value)
+		}
+		types, err := splitTypes(elemSig[1 : len(elemSig)-1])
+		if err != nil || len(types) != 2 {
+			return fmt.Errorf("dbus: malformed dict signature %q", sig)
+		}
+
+		if rv.Type().Key().Kind() != reflect.String {
+			return typeError(sig, value)
+		}
+
+		keys := []string{}
+		for _, key := range rv.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			e.align(8)
+			err = e.encode(types[0], key)
+			if err != nil {
+				return err
+			}
+			err = e.encode(types[1], rv.MapIndex(reflect.ValueOf(key).Convert(rv.Type().Key())).Interface())
+			if err != nil {
+				return err
+			}
+		}
+	} else {
+		if rv.Kind() != reflect.Slice {
+			return typeError(sig, value)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			err := e.encode(elemSig, rv.Index(i).Interface())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	binary.LittleEndian.PutUint32(e.buf.Bytes()[lengthAt:], uint32(e.buf.Len()-start))
+	return nil
+}
+
+func typeError(sig string, value interface{}) error {
+	return fmt.Errorf("dbus: cannot encode %T as %q", value, sig)
+}
+
+type decoder struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+func (d *decoder) align(n int) error {
+	for d.pos%n != 0 {
+		d.pos++
+	}
+	if d.pos > len(d.data) {
+		return errors.New("dbus: message truncated")
+	}
+	return nil
+}
+
+func (d *decoder) next(n int) ([]byte, error) {
+	if d.pos+n > len(d.data) {
+		return nil, errors.New("dbus: message truncated")
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+	err := d.align(4)
+	if err != nil {
+		return 0, err
+	}
+	b, err := d.next(4)
+	if err != nil {
+		return 0, err
+	}
+	return d.order.Uint32(b), nil
+}
+
+func (d *decoder) str() (string, error) {
+	n, err := d.uint32()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.next(int(n) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(b[:n]), nil
+}
+
+func (d *decoder) decodeAll(sig string) ([]interface{}, error) {
+	types, err := splitTypes(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		v, err := d.decode(t)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// decode returns byte, bool, uint32, int32, string, ObjectPath, Signature,
+// Variant, []byte (for 'ay'), map[string]interface{} (for dicts),
+// []interface{} (for other arrays and structs)
+func (d *decoder) decode(sig string) (interface{}, error) {
+	switch sig[0] {
+	case 'y':
+		b, err := d.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case 'b':
+		v, err := d.uint32()
+		return v != 0, err
+	case 'u':
+		return d.uint32()
+	case 'i':
+		v, err := d.uint32()
+		return int32(v), err
+	case 's':
+		return d.str()
+	case 'o':
+		v, err := d.str()
+		return ObjectPath(v), err
+	case 'g':
+		n, err := d.next(1)
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.next(int(n[0]) + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Signature(b[:n[0]]), nil
+	case 'v':
+		s, err := d.decode("g")
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode(string(s.(Signature)))
+		return Variant{Sig: s.(Signature), Value: v}, err
+	case '(':
+		err := d.align(8)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeAll(sig[1 : len(sig)-1])
+	case 'a':
+		return d.decodeArray(sig)
+	default:
+		return nil, fmt.Errorf("dbus: unsupported type %q", sig)
+	}
+}
+
+func (d *decoder) decodeArray(sig string) (interface{}, error) {
+	elemSig := sig[1:]
+
+	n, err := d.uint32()
+	if err != nil {
+		return nil, err
+	}
+	err = d.align(alignment(elemSig[0]))
+	if err != nil {
+		return nil, err
+	}
+	end := d.pos + int(n)
+	if end > len(d.data) {
+		return nil, errors.New("dbus: message truncated")
+	}
+
+	switch {
+	case elemSig == "y":
+		b, _ := d.next(int(n))
+		return append([]byte{}, b...), nil
+	case elemSig[0] == '{':
+		types, err := splitTypes(elemSig[1 : len(elemSig)-1])
+		if err != nil || len(types) != 2 {
+			return nil, fmt.Errorf("dbus: malformed dict signature %q", sig)
+		}
+		dict := map[string]interface{}{}
+		for d.pos < end {
+			err = d.align(8)
+			if err != nil {
+				return nil, err
+			}
+			key, err := d.decode(types[0])
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.decode(types[1])
+			if err != nil {
+				return nil, err
+			}
+			dict[fmt.Sprint(key)] = value
+		}
+		return dict, nil
+	default:
+		values := []interface{}{}
+		for d.pos < end {
+			v, err := d.decode(elemSig)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	}
+}
@@ -0,0 +1,196 @@
+package dbus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Message types
+const (
+	TypeMethodCall   byte = 1
+	TypeMethodReturn byte = 2
+	TypeError        byte = 3
+	TypeSignal       byte = 4
+)
+
+const (
+	fieldPath        byte = 1
+	fieldInterface   byte = 2
+	fieldMember      byte = 3
+	fieldErrorName   byte = 4
+	fieldReplySerial byte = 5
+	fieldDestination byte = 6
+	fieldSender      byte = 7
+	fieldSignature   byte = 8
+
+	maxMessageSize = 128 * 1024 * 1024
+)
+
+// Message is a single D-Bus message. Body holds values as described by the
+// decode rules in marshal.go and must match Signature.
+type Message struct {
+	Type        byte
+	Flags       byte
+	Serial      uint32
+	Path        ObjectPath
+	Interface   string
+	Member      string
+	ErrorName   string
+	ReplySerial uint32
+	Destination string
+	Sender      string
+	Signature   Signature
+	Body        []interface{}
+}
+
+// Error is returned when a method call is answered with an error message
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return fmt.Sprintf("%s: %s", e.Name, e.Message)
+}
+
+// WriteTo writes the message in little endian wire format
+func (m *Message) WriteTo(w io.Writer) (int64, error) {
+	body := newEncoder(0)
+	if m.Signature != "" {
+		err := body.encodeAll(string(m.Signature), m.Body)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	fields := map[byte]Variant{}
+	if m.Path != "" {
+		fields[fieldPath] = Variant{Sig: "o", Value: m.Path}
+	}
+	if m.Interface != "" {
+		fields[fieldInterface] = Variant{Sig: "s", Value: m.Interface}
+	}
+	if m.Member != "" {
+		fields[fieldMember] = Variant{Sig: "s", Value: m.Member}
+	}
+	if m.ErrorName != "" {
+		fields[fieldErrorName] = Variant{Sig: "s", Value: m.ErrorName}
+	}
+	if m.ReplySerial != 0 {
+		fields[fieldReplySerial] = Variant{Sig: "u", Value: m.ReplySerial}
+	}
+	if m.Destination != "" {
+		fields[fieldDestination] = Variant{Sig: "s", Value: m.Destination}
+	}
+	if m.Sender != "" {
+		fields[fieldSender] = Variant{Sig: "s", Value: m.Sender}
+	}
+	if m.Signature != "" {
+		fields[fieldSignature] = Variant{Sig: "g", Value: m.Signature}
+	}
+
+	headerFields := []interface{}{}
+	for code := fieldPath; code <= fieldSignature; code++ {
+		if v, ok := fields[code]; ok {
+			headerFields = append(headerFields, []interface{}{code, v})
+		}
+	}
+
+	header := newEncoder(0)
+	header.buf.Write([]byte{'l', m.Type, m.Flags, 1})
+	header.uint32(uint32(body.buf.Len()))
+	header.uint32(m.Serial)
+	err := header.encode("a(yv)", headerFields)
+	if err != nil {
+		return 0, err
+	}
+	header.align(8)
+
+	n, err := w.Write(append(header.buf.Bytes(), body.buf.Bytes()...))
+	return int64(n), err
+}
+
+// ReadMessage reads a single message in either byte order
+func ReadMessage(r io.Reader) (*Message, error) {
+	fixed := make([]byte, 16)
+	_, err := io.ReadFull(r, fixed)
+	if err != nil {
+		return nil, err
+	}
+
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("dbus: invalid byte order %q", fixed[0])
+	}
+
+	bodyLength := order.Uint32(fixed[4:8])
+	fieldsLength := order.Uint32(fixed[12:16])
+	headerLength := 16 + int(fieldsLength)
+	padding := (8 - headerLength%8) % 8
+	if uint64(headerLength)+uint64(padding)+uint64(bodyLength) > maxMessageSize {
+		return nil, errors.New("dbus: message too large")
+	}
+
+	data := make([]byte, headerLength+padding+int(bodyLength))
+	copy(data, fixed)
+	_, err = io.ReadFull(r, data[16:])
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Message{
+		Type:   fixed[1],
+		Flags:  fixed[2],
+		Serial: order.Uint32(fixed[8:12]),
+	}
+
+	d := &decoder{data: data[:headerLength], pos: 12, order: order}
+	rawFields, err := d.decode("a(yv)")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, raw := range rawFields.([]interface{}) {
+		field := raw.([]interface{})
+		value := field[1].(Variant).Value
+
+		switch field[0].(byte) {
+		case fieldPath:
+			m.Path, _ = value.(ObjectPath)
+		case fieldInterface:
+			m.Interface, _ = value.(string)
+		case fieldMember:
+			m.Member, _ = value.(string)
+		case fieldErrorName:
+			m.ErrorName, _ = value.(string)
+		case fieldReplySerial:
+			m.ReplySerial, _ = value.(uint32)
+		case fieldDestination:
+			m.Destination, _ = value.(string)
+		case fieldSender:
+			m.Sender, _ = value.(string)
+		case fieldSignature:
+			m.Signature, _ = value.(Signature)
+		}
+	}
+
+	if m.Signature != "" {
+		d = &decoder{data: data[headerLength+padding:], order: order}
+		m.Body, err = d.decodeAll(string(m.Signature))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
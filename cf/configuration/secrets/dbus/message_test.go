@@ -0,0 +1,107 @@
+package dbus_test
+
+import (
+	"bytes"
+
+	. "github.com/cloudfoundry/cli/cf/configuration/secrets/dbus"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	roundTrip := func(m *Message) *Message {
+		buf := new(bytes.Buffer)
+		_, err := m.WriteTo(buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		decoded, err := ReadMessage(buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buf.Len()).To(BeZero())
+		return decoded
+	}
+
+	It("round trips the header fields of a method call", func() {
+		decoded := roundTrip(&Message{
+			Type:        TypeMethodCall,
+			Serial:      7,
+			Path:        "/org/freedesktop/secrets",
+			Interface:   "org.freedesktop.Secret.Service",
+			Member:      "SearchItems",
+			Destination: "org.freedesktop.secrets",
+		})
+
+		Expect(decoded.Type).To(Equal(TypeMethodCall))
+		Expect(decoded.Serial).To(Equal(uint32(7)))
+		Expect(decoded.Path).To(Equal(ObjectPath("/org/freedesktop/secrets")))
+		Expect(decoded.Interface).To(Equal("org.freedesktop.Secret.Service"))
+		Expect(decoded.Member).To(Equal("SearchItems"))
+		Expect(decoded.Destination).To(Equal("org.freedesktop.secrets"))
+		Expect(decoded.Signature).To(BeEmpty())
+		Expect(decoded.Body).To(BeEmpty())
+	})
+
+	It("round trips dictionaries, structs, variants and byte arrays", func() {
+		decoded := roundTrip(&Message{
+			Type:        TypeMethodReturn,
+			Serial:      2,
+			ReplySerial: 1,
+			Signature:   "a{sv}(oayays)ba{ss}ao",
+			Body: []interface{}{
+				map[string]Variant{
+					"label":      MakeVariant("my label"),
+					"attributes": MakeVariant(map[string]string{"b-key": "b", "a-key": "a"}),
+				},
+				[]interface{}{ObjectPath("/session/1"), []byte{}, []byte("s3cret"), "text/plain"},
+				true,
+				map[string]string{},
+				[]ObjectPath{"/item/1", "/item/2"},
+			},
+		})
+
+		Expect(decoded.ReplySerial).To(Equal(uint32(1)))
+		Expect(decoded.Signature).To(Equal(Signature("a{sv}(oayays)ba{ss}ao")))
+		Expect(decoded.Body).To(Equal([]interface{}{
+			map[string]interface{}{
+				"label":      Variant{Sig: "s", Value: "my label"},
+				"attributes": Variant{Sig: "a{ss}", Value: map[string]interface{}{"a-key": "a", "b-key": "b"}},
+			},
+			[]interface{}{ObjectPath("/session/1"), []byte{}, []byte("s3cret"), "text/plain"},
+			true,
+			map[string]interface{}{},
+			[]interface{}{ObjectPath("/item/1"), ObjectPath("/item/2")},
+		}))
+	})
+
+	It("decodes big endian messages", func() {
+		message := []byte{
+			'B', TypeMethodReturn, 0, 1,
+			0, 0, 0, 9, // body length
+			0, 0, 0, 2, // serial
+			0, 0, 0, 15, // header fields length
+			5, 1, 'u', 0, 0, 0, 0, 1, // reply serial
+			8, 1, 'g', 0, 1, 's', 0, // signature
+			0,
+			0, 0, 0, 4, 'p', 'o', 'n', 'g', 0,
+		}
+
+		decoded, err := ReadMessage(bytes.NewReader(message))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded.ReplySerial).To(Equal(uint32(1)))
+		Expect(decoded.Body).To(Equal([]interface{}{"pong"}))
+	})
+
+	It("returns an error when the body does not match the signature", func() {
+		_, err := (&Message{Type: TypeMethodCall, Signature: "s", Body: []interface{}{uint32(1)}}).WriteTo(new(bytes.Buffer))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error for a truncated message", func() {
+		buf := new(bytes.Buffer)
+		_, err := (&Message{Type: TypeMethodCall, Serial: 1, Signature: "s", Body: []interface{}{"hello"}}).WriteTo(buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = ReadMessage(bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+		Expect(err).To(HaveOccurred())
+	})
+})
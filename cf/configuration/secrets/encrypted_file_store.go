@@ -0,0 +1,239 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/configuration/safefile"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const (
+	keyDerivationIterations = 100000
+	keyLength               = 32
+	saltLength              = 16
+)
+
+var ErrWrongPassphrase error = wrongPassphraseError{}
+
+// wrongPassphraseError is translated when it is shown, since T is not set up
+// when package variables are initialised
+type wrongPassphraseError struct{}
+
+func (wrongPassphraseError) Error() string {
+	return T("Unable to decrypt the secrets file; check CF_SECRETS_PASSPHRASE")
+}
+
+type encryptedFile struct {
+	Salt       []byte
+	Nonce      []byte
+	Ciphertext []byte
+}
+
+// EncryptedFileStore keeps all secrets in a single file encrypted with
+// AES-256-GCM, using a key derived from a passphrase with PBKDF2-SHA256.
+// Changes are made under a lock shared with other processes, and the file is
+// replaced atomically.
+type EncryptedFileStore struct {
+	path       string
+	passphrase string
+
+	mutex sync.Mutex
+	salt  []byte
+	key   []byte
+}
+
+func NewEncryptedFileStore(path string, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+func (s *EncryptedFileStore) Get(key string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secrets[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *EncryptedFileStore) Set(key, value string) error {
+	return s.update(func(secrets map[string]string) bool {
+		secrets[key] = value
+		return true
+	})
+}
+
+func (s *EncryptedFileStore) Delete(key string) error {
+	return s.update(func(secrets map[string]string) bool {
+		if _, ok := secrets[key]; !ok {
+			return false
+		}
+
+		delete(secrets, key)
+		return true
+	})
+}
+
+// update loads the secrets, lets change modify them and saves them when it
+// returns true, keeping other processes out in between
+func (s *EncryptedFileStore) update(change func(map[string]string) bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(s.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := safefile.Lock(s.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if !change(secrets) {
+		return nil
+	}
+	return s.save(secrets)
+}
+
+func (s *EncryptedFileStore) load() (map[string]string, error) {
+	secrets := map[string]string{}
+
+	contents, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := encryptedFile{}
+	err = json.Unmarshal(contents, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := s.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	err = json.Unmarshal(plaintext, &secrets)
+	return secrets, err
+}
+
+func (s *EncryptedFileStore) save(secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	salt := s.salt
+	if salt == nil {
+		salt = make([]byte, saltLength)
+		_, err = io.ReadFull(rand.Reader, salt)
+		if err != nil {
+			return err
+		}
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(encryptedFile{
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return safefile.WriteFile(s.path, contents, 0600)
+}
+
+// cipher derives the key for the salt, reusing the last derived key as
+// derivation is deliberately slow
+func (s *EncryptedFileStore) cipher(salt []byte) (cipher.AEAD, error) {
+	if s.key == nil || !hmac.Equal(salt, s.salt) {
+		s.key = pbkdf2SHA256([]byte(s.passphrase), salt, keyDerivationIterations, keyLength)
+		s.salt = salt
+	}
+
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2SHA256 implements PBKDF2 (RFC 2898) with HMAC-SHA256
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+
+	derived := make([]byte, 0, blocks*hashLen)
+	counter := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter, uint32(block))
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+		u := prf.Sum(nil)
+		t := append([]byte{}, u...)
+
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+
+		derived = append(derived, t...)
+	}
+
+	return derived[:keyLen]
+}
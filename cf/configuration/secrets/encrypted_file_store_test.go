@@ -0,0 +1,106 @@
+package secrets_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptedFileStore", func() {
+	var (
+		tmpDir string
+		path   string
+		store  *secrets.EncryptedFileStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "encrypted-file-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		path = filepath.Join(tmpDir, ".cf", "secrets.enc")
+		store = secrets.NewEncryptedFileStore(path, "correct horse battery staple")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("returns ErrNotFound before anything is stored", func() {
+		_, err := store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+	})
+
+	It("stores, retrieves and deletes secrets", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+		Expect(store.Set("RefreshToken", "my-refresh-token")).To(Succeed())
+
+		Expect(store.Get("AccessToken")).To(Equal("bearer my-token"))
+		Expect(store.Get("RefreshToken")).To(Equal("my-refresh-token"))
+
+		Expect(store.Delete("AccessToken")).To(Succeed())
+		_, err := store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+		Expect(store.Get("RefreshToken")).To(Equal("my-refresh-token"))
+	})
+
+	It("does not write the secrets in plaintext", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("my-token"))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("can be read by another store with the same passphrase", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+		other := secrets.NewEncryptedFileStore(path, "correct horse battery staple")
+		Expect(other.Get("AccessToken")).To(Equal("bearer my-token"))
+	})
+
+	It("returns an error when the passphrase is wrong", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+		other := secrets.NewEncryptedFileStore(path, "wrong passphrase")
+		_, err := other.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrWrongPassphrase))
+		Expect(other.Set("AccessToken", "bearer other-token")).To(Equal(secrets.ErrWrongPassphrase))
+	})
+
+	It("keeps the secrets set by stores writing at the same time", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				other := secrets.NewEncryptedFileStore(path, "correct horse battery staple")
+				Expect(other.Set(fmt.Sprintf("writer-%d", i), "secret")).To(Succeed())
+			}(i)
+		}
+		wg.Wait()
+
+		for i := 0; i < 8; i++ {
+			Expect(store.Get(fmt.Sprintf("writer-%d", i))).To(Equal("secret"))
+		}
+
+		leftovers, err := filepath.Glob(filepath.Join(filepath.Dir(path), ".secrets.enc.tmp*"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leftovers).To(BeEmpty())
+	})
+})
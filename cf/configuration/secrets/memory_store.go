@@ -0,0 +1,40 @@
+package secrets
+
+import "sync"
+
+// MemoryStore keeps secrets in memory; it backs the agent served by ServeAgent
+type MemoryStore struct {
+	secrets map[string]string
+	mutex   sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{secrets: map[string]string{}}
+}
+
+func (s *MemoryStore) Get(key string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	value, ok := s.secrets[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *MemoryStore) Set(key, value string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.secrets[key] = value
+	return nil
+}
+
+func (s *MemoryStore) Delete(key string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.secrets, key)
+	return nil
+}
@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets/dbus"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const (
+	secretServiceName         = "org.freedesktop.secrets"
+	secretServicePath         = dbus.ObjectPath("/org/freedesktop/secrets")
+	secretServiceInterface    = "org.freedesktop.Secret.Service"
+	secretItemInterface       = "org.freedesktop.Secret.Item"
+	secretCollectionInterface = "org.freedesktop.Secret.Collection"
+	defaultCollectionPath     = dbus.ObjectPath("/org/freedesktop/secrets/aliases/default")
+	noPrompt                  = dbus.ObjectPath("/")
+	secretServiceAttribute    = "cf-cli-key"
+	secretServiceLabelPrefix  = "Cloud Foundry CLI: "
+)
+
+var (
+	ErrKeyringLocked         error = keyringLockedError{}
+	errUnexpectedSecretReply error = unexpectedSecretReplyError{}
+)
+
+// keyringLockedError and unexpectedSecretReplyError are translated when they
+// are shown, since T is not set up when package variables are initialised
+type keyringLockedError struct{}
+
+func (keyringLockedError) Error() string {
+	return T("The default keyring is locked; unlock it and try again")
+}
+
+type unexpectedSecretReplyError struct{}
+
+func (unexpectedSecretReplyError) Error() string {
+	return T("Unexpected reply from the Secret Service")
+}
+
+// SecretServiceStore keeps secrets in the freedesktop.org Secret Service
+// (GNOME Keyring, KWallet) over D-Bus. Items are found by a cf-cli-key
+// attribute in the default collection.
+type SecretServiceStore struct {
+	address string
+}
+
+func NewSecretServiceStore(busAddress string) SecretServiceStore {
+	return SecretServiceStore{address: busAddress}
+}
+
+type secretServiceSession struct {
+	conn *dbus.Conn
+	path dbus.ObjectPath
+}
+
+func (s SecretServiceStore) Get(key string) (string, error) {
+	session, err := s.openSession()
+	if err != nil {
+		return "", err
+	}
+	defer session.conn.Close()
+
+	items, err := session.search(key)
+	if err != nil {
+		return "", err
+	}
+	if len(items) == 0 {
+		return "", ErrNotFound
+	}
+
+	reply, err := session.conn.Call(secretServiceName, items[0], secretItemInterface, "GetSecret", "o", session.path)
+	if err != nil {
+		return "", err
+	}
+	if len(reply) != 1 {
+		return "", errUnexpectedSecretReply
+	}
+
+	// the secret is a (session, parameters, value, content type) struct
+	secret, ok := reply[0].([]interface{})
+	if !ok || len(secret) != 4 {
+		return "", errUnexpectedSecretReply
+	}
+	value, _ := secret[2].([]byte)
+
+	return string(value), nil
+}
+
+func (s SecretServiceStore) Set(key, value string) error {
+	session, err := s.openSession()
+	if err != nil {
+		return err
+	}
+	defer session.conn.Close()
+
+	properties := map[string]dbus.Variant{
+		"org.freedesktop.Secret.Item.Label":      dbus.MakeVariant(secretServiceLabelPrefix + key),
+		"org.freedesktop.Secret.Item.Attributes": dbus.MakeVariant(map[string]string{secretServiceAttribute: key}),
+	}
+	secret := []interface{}{session.path, []byte{}, []byte(value), "text/plain"}
+
+	reply, err := session.conn.Call(secretServiceName, defaultCollectionPath, secretCollectionInterface, "CreateItem", "a{sv}(oayays)b", properties, secret, true)
+	if err != nil {
+		return err
+	}
+	if len(reply) != 2 {
+		return errUnexpectedSecretReply
+	}
+
+	if prompt, _ := reply[1].(dbus.ObjectPath); prompt != noPrompt {
+		return ErrKeyringLocked
+	}
+	return nil
+}
+
+func (s SecretServiceStore) Delete(key string) error {
+	session, err := s.openSession()
+	if err != nil {
+		return err
+	}
+	defer session.conn.Close()
+
+	items, err := session.search(key)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		reply, err := session.conn.Call(secretServiceName, item, secretItemInterface, "Delete", "")
+		if err != nil {
+			return err
+		}
+		if len(reply) != 1 {
+			return errUnexpectedSecretReply
+		}
+
+		if prompt, _ := reply[0].(dbus.ObjectPath); prompt != noPrompt {
+			return ErrKeyringLocked
+		}
+	}
+	return nil
+}
+
+// openSession connects to the bus and opens an unencrypted ("plain")
+// session; the session bus is only reachable by the current user
+func (s SecretServiceStore) openSession() (*secretServiceSession, error) {
+	conn, err := dbus.Dial(s.address)
+	if err != nil {
+		return nil, errors.New(T("Unable to connect to the Secret Service: {{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	reply, err := conn.Call(secretServiceName, secretServicePath, secretServiceInterface, "OpenSession", "sv", "plain", dbus.MakeVariant(""))
+	if err != nil {
+		conn.Close()
+		return nil, errors.New(T("Unable to open a Secret Service session: {{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+	if len(reply) != 2 {
+		conn.Close()
+		return nil, errUnexpectedSecretReply
+	}
+
+	path, _ := reply[1].(dbus.ObjectPath)
+	return &secretServiceSession{conn: conn, path: path}, nil
+}
+
+// search returns the unlocked items stored under key
+func (session *secretServiceSession) search(key string) ([]dbus.ObjectPath, error) {
+	reply, err := session.conn.Call(secretServiceName, secretServicePath, secretServiceInterface, "SearchItems", "a{ss}", map[string]string{secretServiceAttribute: key})
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 2 {
+		return nil, errUnexpectedSecretReply
+	}
+
+	unlocked, _ := reply[0].([]interface{})
+	locked, _ := reply[1].([]interface{})
+	if len(unlocked) == 0 && len(locked) > 0 {
+		return nil, ErrKeyringLocked
+	}
+
+	items := []dbus.ObjectPath{}
+	for _, item := range unlocked {
+		if path, ok := item.(dbus.ObjectPath); ok {
+			items = append(items, path)
+		}
+	}
+	return items, nil
+}
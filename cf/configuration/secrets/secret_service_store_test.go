@@ -0,0 +1,259 @@
+package secrets_test
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+	"github.com/cloudfoundry/cli/cf/configuration/secrets/dbus"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretServiceStore", func() {
+	var (
+		tmpDir  string
+		service *fakeSecretService
+		store   secrets.SecretServiceStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "secret-service-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		socket := filepath.Join(tmpDir, "bus")
+		service, err = newFakeSecretService(socket)
+		Expect(err).NotTo(HaveOccurred())
+
+		store = secrets.NewSecretServiceStore("unix:path=" + socket)
+	})
+
+	AfterEach(func() {
+		service.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("returns ErrNotFound before anything is stored", func() {
+		_, err := store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+	})
+
+	It("stores secrets as labelled items in the default collection", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+
+		Expect(service.items).To(HaveLen(1))
+		for _, item := range service.items {
+			Expect(item.collection).To(Equal(dbus.ObjectPath("/org/freedesktop/secrets/aliases/default")))
+			Expect(item.label).To(Equal("Cloud Foundry CLI: AccessToken"))
+			Expect(item.attributes).To(Equal(map[string]interface{}{"cf-cli-key": "AccessToken"}))
+			Expect(string(item.secret)).To(Equal("bearer my-token"))
+		}
+	})
+
+	It("stores, retrieves, replaces and deletes secrets", func() {
+		Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+		Expect(store.Set("RefreshToken", "my-refresh-token")).To(Succeed())
+		Expect(store.Get("AccessToken")).To(Equal("bearer my-token"))
+
+		Expect(store.Set("AccessToken", "bearer new-token")).To(Succeed())
+		Expect(store.Get("AccessToken")).To(Equal("bearer new-token"))
+		Expect(service.items).To(HaveLen(2))
+
+		Expect(store.Delete("AccessToken")).To(Succeed())
+		_, err := store.Get("AccessToken")
+		Expect(err).To(Equal(secrets.ErrNotFound))
+		Expect(store.Get("RefreshToken")).To(Equal("my-refresh-token"))
+	})
+
+	Context("when the keyring is locked", func() {
+		BeforeEach(func() {
+			Expect(store.Set("AccessToken", "bearer my-token")).To(Succeed())
+			service.locked = true
+		})
+
+		It("returns ErrKeyringLocked", func() {
+			_, err := store.Get("AccessToken")
+			Expect(err).To(Equal(secrets.ErrKeyringLocked))
+			Expect(store.Set("AccessToken", "bearer new-token")).To(Equal(secrets.ErrKeyringLocked))
+		})
+	})
+
+	It("returns an error when the bus cannot be reached", func() {
+		store = secrets.NewSecretServiceStore("unix:path=" + filepath.Join(tmpDir, "missing"))
+		_, err := store.Get("AccessToken")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unable to connect to the Secret Service"))
+	})
+})
+
+type fakeSecretItem struct {
+	collection dbus.ObjectPath
+	label      string
+	attributes map[string]interface{}
+	secret     []byte
+}
+
+// fakeSecretService is a D-Bus peer that answers the Hello call of the bus and
+// the parts of the Secret Service API used by SecretServiceStore
+type fakeSecretService struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	items    map[dbus.ObjectPath]*fakeSecretItem
+	nextItem int
+	locked   bool
+}
+
+func newFakeSecretService(socket string) (*fakeSecretService, error) {
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &fakeSecretService{
+		listener: listener,
+		items:    map[dbus.ObjectPath]*fakeSecretItem{},
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go service.serve(conn)
+		}
+	}()
+
+	return service, nil
+}
+
+func (s *fakeSecretService) Close() {
+	s.listener.Close()
+}
+
+func (s *fakeSecretService) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	auth, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(auth, "\x00AUTH EXTERNAL ") {
+		return
+	}
+	fmt.Fprint(conn, "OK 6c6f63616c2d7374616e642d696e2d6275\r\n")
+
+	begin, err := reader.ReadString('\n')
+	if err != nil || begin != "BEGIN\r\n" {
+		return
+	}
+
+	serial := uint32(0)
+	for {
+		call, err := dbus.ReadMessage(reader)
+		if err != nil {
+			return
+		}
+
+		serial++
+		reply := &dbus.Message{
+			Type:        dbus.TypeMethodReturn,
+			Serial:      serial,
+			ReplySerial: call.Serial,
+		}
+
+		s.mutex.Lock()
+		reply.Signature, reply.Body = s.handle(call)
+		s.mutex.Unlock()
+
+		if reply.Signature == "" && reply.Body != nil {
+			reply.Type = dbus.TypeError
+			reply.ErrorName = "org.freedesktop.DBus.Error.UnknownMethod"
+			reply.Signature = "s"
+		}
+
+		_, err = reply.WriteTo(conn)
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeSecretService) handle(call *dbus.Message) (dbus.Signature, []interface{}) {
+	switch call.Interface + "." + call.Member {
+	case "org.freedesktop.DBus.Hello":
+		return "s", []interface{}{":1.42"}
+
+	case "org.freedesktop.Secret.Service.OpenSession":
+		return "vo", []interface{}{dbus.MakeVariant(""), dbus.ObjectPath("/org/freedesktop/secrets/session/1")}
+
+	case "org.freedesktop.Secret.Service.SearchItems":
+		matches := s.search(call.Body[0].(map[string]interface{}))
+		if s.locked {
+			return "aoao", []interface{}{[]dbus.ObjectPath{}, matches}
+		}
+		return "aoao", []interface{}{matches, []dbus.ObjectPath{}}
+
+	case "org.freedesktop.Secret.Collection.CreateItem":
+		if s.locked {
+			return "oo", []interface{}{dbus.ObjectPath("/"), dbus.ObjectPath("/org/freedesktop/secrets/prompt/1")}
+		}
+
+		properties := call.Body[0].(map[string]interface{})
+		attributes := properties["org.freedesktop.Secret.Item.Attributes"].(dbus.Variant).Value.(map[string]interface{})
+		secret := call.Body[1].([]interface{})
+
+		item := &fakeSecretItem{
+			collection: call.Path,
+			label:      properties["org.freedesktop.Secret.Item.Label"].(dbus.Variant).Value.(string),
+			attributes: attributes,
+			secret:     secret[2].([]byte),
+		}
+
+		path := dbus.ObjectPath("")
+		if existing := s.search(attributes); len(existing) > 0 && call.Body[2].(bool) {
+			path = existing[0]
+		} else {
+			s.nextItem++
+			path = dbus.ObjectPath(fmt.Sprintf("/org/freedesktop/secrets/collection/login/%d", s.nextItem))
+		}
+		s.items[path] = item
+
+		return "oo", []interface{}{path, dbus.ObjectPath("/")}
+
+	case "org.freedesktop.Secret.Item.GetSecret":
+		item := s.items[call.Path]
+		return "(oayays)", []interface{}{[]interface{}{call.Body[0].(dbus.ObjectPath), []byte{}, item.secret, "text/plain"}}
+
+	case "org.freedesktop.Secret.Item.Delete":
+		delete(s.items, call.Path)
+		return "o", []interface{}{dbus.ObjectPath("/")}
+
+	default:
+		return "", []interface{}{"unknown method " + call.Member}
+	}
+}
+
+func (s *fakeSecretService) search(attributes map[string]interface{}) []dbus.ObjectPath {
+	matches := []dbus.ObjectPath{}
+
+	for path, item := range s.items {
+		matched := true
+		for key, value := range attributes {
+			if item.attributes[key] != value {
+				matched = false
+			}
+		}
+		if matched {
+			matches = append(matches, path)
+		}
+	}
+
+	return matches
+}
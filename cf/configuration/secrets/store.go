@@ -0,0 +1,66 @@
+// Package secrets provides the backends that the CLI can keep access tokens,
+// refresh tokens and client secrets in instead of the plaintext config file.
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets/dbus"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const (
+	PlaintextBackend     = "plaintext"
+	EncryptedFileBackend = "encrypted-file"
+	AgentBackend         = "agent"
+	SecretServiceBackend = "secret-service"
+)
+
+// Backends lists the names accepted by NewStore
+var Backends = []string{PlaintextBackend, EncryptedFileBackend, AgentBackend, SecretServiceBackend}
+
+// ErrNotFound is returned by Get when no secret is stored under the key
+var ErrNotFound = errors.New("secret not found")
+
+//go:generate counterfeiter . Store
+
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// NewStore returns the store for the named backend. The plaintext backend has
+// no store, so nil is returned for it.
+//
+// The encrypted file is kept in configDir and its passphrase is read from
+// CF_SECRETS_PASSPHRASE. The agent listens on the socket named by
+// CF_SECRETS_AGENT_SOCK. The Secret Service is reached on the D-Bus session bus.
+func NewStore(backend string, configDir string) (Store, error) {
+	switch backend {
+	case "", PlaintextBackend:
+		return nil, nil
+	case EncryptedFileBackend:
+		passphrase := os.Getenv("CF_SECRETS_PASSPHRASE")
+		if passphrase == "" {
+			return nil, errors.New(T("CF_SECRETS_PASSPHRASE must be set to use the encrypted-file secret backend"))
+		}
+		return NewEncryptedFileStore(filepath.Join(configDir, "secrets.enc"), passphrase), nil
+	case AgentBackend:
+		socket := os.Getenv("CF_SECRETS_AGENT_SOCK")
+		if socket == "" {
+			return nil, errors.New(T("CF_SECRETS_AGENT_SOCK must be set to use the agent secret backend"))
+		}
+		return NewAgentStore(socket), nil
+	case SecretServiceBackend:
+		address := dbus.SessionBusAddress()
+		if address == "" {
+			return nil, errors.New(T("DBUS_SESSION_BUS_ADDRESS must be set to use the secret-service secret backend"))
+		}
+		return NewSecretServiceStore(address), nil
+	default:
+		return nil, errors.New(T("Unknown secret backend '{{.Backend}}'", map[string]interface{}{"Backend": backend}))
+	}
+}
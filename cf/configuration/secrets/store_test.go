@@ -0,0 +1,73 @@
+package secrets_test
+
+import (
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/configuration/secrets"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewStore", func() {
+	var originalEnv map[string]string
+
+	BeforeEach(func() {
+		originalEnv = map[string]string{}
+		for _, name := range []string{"CF_SECRETS_PASSPHRASE", "CF_SECRETS_AGENT_SOCK", "DBUS_SESSION_BUS_ADDRESS"} {
+			originalEnv[name] = os.Getenv(name)
+			os.Setenv(name, "")
+		}
+	})
+
+	AfterEach(func() {
+		for name, value := range originalEnv {
+			os.Setenv(name, value)
+		}
+	})
+
+	It("returns no store for the plaintext backend", func() {
+		store, err := secrets.NewStore("plaintext", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeNil())
+
+		store, err = secrets.NewStore("", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeNil())
+	})
+
+	It("returns an encrypted file store when a passphrase is set", func() {
+		_, err := secrets.NewStore("encrypted-file", "/home/user/.cf")
+		Expect(err).To(MatchError(ContainSubstring("CF_SECRETS_PASSPHRASE")))
+
+		os.Setenv("CF_SECRETS_PASSPHRASE", "passphrase")
+		store, err := secrets.NewStore("encrypted-file", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(&secrets.EncryptedFileStore{}))
+	})
+
+	It("returns an agent store when an agent socket is set", func() {
+		_, err := secrets.NewStore("agent", "/home/user/.cf")
+		Expect(err).To(MatchError(ContainSubstring("CF_SECRETS_AGENT_SOCK")))
+
+		os.Setenv("CF_SECRETS_AGENT_SOCK", "/tmp/agent.sock")
+		store, err := secrets.NewStore("agent", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(secrets.AgentStore{}))
+	})
+
+	It("returns a Secret Service store when a session bus is available", func() {
+		_, err := secrets.NewStore("secret-service", "/home/user/.cf")
+		Expect(err).To(MatchError(ContainSubstring("DBUS_SESSION_BUS_ADDRESS")))
+
+		os.Setenv("DBUS_SESSION_BUS_ADDRESS", "unix:path=/run/user/1000/bus")
+		store, err := secrets.NewStore("secret-service", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeAssignableToTypeOf(secrets.SecretServiceStore{}))
+	})
+
+	It("returns an error for an unknown backend", func() {
+		_, err := secrets.NewStore("vault", "/home/user/.cf")
+		Expect(err).To(MatchError("Unknown secret backend 'vault'"))
+	})
+})
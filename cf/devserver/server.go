@@ -0,0 +1,766 @@
+package devserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	APIVersion         = "2.54.0"
+	defaultPerPage     = 50
+	notFoundCode       = 10000
+	invalidRequestCode = 10001
+)
+
+// RequestLogger is told about every request the server answers, along with
+// the status it answered with
+type RequestLogger func(request *http.Request, status int)
+
+// Server is a stand-in for a Cloud Controller and its UAA, keeping
+// organizations, spaces, apps, routes, services and the other /v2 resources
+// the api repositories use in memory. Any user name and password are
+// accepted, so that scripts and plugins can run real commands against it
+// without a foundation.
+type Server struct {
+	mutex       sync.Mutex
+	collections map[string][]*resource
+	jobs        map[string]bool
+	nextGUID    int
+	logRequest  RequestLogger
+}
+
+type resource struct {
+	guid      string
+	createdAt string
+	entity    map[string]interface{}
+}
+
+// response is what a route answers with; a nil body is sent as an empty
+// response
+type response struct {
+	status int
+	body   interface{}
+}
+
+// inlineChildren are the lists inlined in a resource when a request asks for
+// inline-relations-depth=1, keyed by the collection of the resource
+var inlineChildren = map[string][]string{
+	"organizations": {"spaces", "private_domains"},
+	"spaces":        {"apps", "routes", "service_instances"},
+	"services":      {"service_plans"},
+}
+
+// uniqueWithin names, for the collections whose names must be unique, the
+// field the name must be unique within and the error the Cloud Controller
+// answers with
+var uniqueWithin = map[string]struct {
+	scope     string
+	code      int
+	errorCode string
+}{
+	"organizations":     {"", 30002, "CF-OrganizationNameTaken"},
+	"spaces":            {"organization_guid", 40002, "CF-SpaceNameTaken"},
+	"apps":              {"space_guid", 100002, "CF-AppNameTaken"},
+	"service_instances": {"space_guid", 60002, "CF-ServiceInstanceNameTaken"},
+	"shared_domains":    {"", 130003, "CF-DomainNameTaken"},
+	"private_domains":   {"", 130003, "CF-DomainNameTaken"},
+}
+
+// defaults are merged into the entities of new resources
+var defaults = map[string]map[string]interface{}{
+	"apps": {
+		"state":      "STOPPED",
+		"instances":  1,
+		"memory":     1024,
+		"disk_quota": 1024,
+		"diego":      true,
+	},
+	"spaces": {
+		"allow_ssh": true,
+	},
+}
+
+func NewServer(logRequest RequestLogger) *Server {
+	server := &Server{
+		collections: map[string][]*resource{},
+		jobs:        map[string]bool{},
+		logRequest:  logRequest,
+	}
+
+	quota := server.create("quota_definitions", map[string]interface{}{
+		"name":                       "default",
+		"non_basic_services_allowed": true,
+		"total_services":             100,
+		"total_routes":               1000,
+		"memory_limit":               10240,
+		"instance_memory_limit":      -1,
+	})
+	org := server.create("organizations", map[string]interface{}{
+		"name":                  "dev-org",
+		"status":                "active",
+		"quota_definition_guid": quota.guid,
+	})
+	server.create("spaces", map[string]interface{}{
+		"name":              "dev-space",
+		"organization_guid": org.guid,
+	})
+	server.create("shared_domains", map[string]interface{}{"name": "dev.local"})
+	server.create("stacks", map[string]interface{}{"name": "cflinuxfs2", "description": "Cloud Foundry Linux-based filesystem"})
+	service := server.create("services", map[string]interface{}{
+		"label":       "dev-db",
+		"description": "In-memory database for development",
+		"active":      true,
+		"bindable":    true,
+	})
+	server.create("service_plans", map[string]interface{}{
+		"name":         "free",
+		"description":  "Free plan",
+		"free":         true,
+		"public":       true,
+		"active":       true,
+		"service_guid": service.guid,
+	})
+
+	return server
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	res := s.route(request)
+	if s.logRequest != nil {
+		s.logRequest(request, res.status)
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(res.status)
+	if res.body != nil {
+		body, _ := json.Marshal(res.body)
+		_, _ = writer.Write(body)
+	}
+}
+
+func (s *Server) route(request *http.Request) response {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	self := "http://" + request.Host
+
+	switch request.URL.Path {
+	case "/login":
+		return s.login(self)
+	case "/oauth/token":
+		return s.token(request)
+	case "/v2/info":
+		return s.info(self)
+	}
+
+	if !strings.HasPrefix(request.URL.Path, "/v2/") {
+		return notFound(request)
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(request.URL.Path, "/v2/"), "/"), "/")
+
+	switch {
+	case len(segments) == 3 && segments[0] == "config" && segments[1] == "feature_flags" && request.Method == "GET":
+		return response{http.StatusOK, map[string]interface{}{"name": segments[2], "enabled": true}}
+	case len(segments) == 2 && segments[0] == "jobs" && request.Method == "GET":
+		return s.job(segments[1], request)
+	case len(segments) == 3 && segments[0] == "spaces" && segments[2] == "summary" && request.Method == "GET":
+		return s.spaceSummary(segments[1], request)
+	case len(segments) == 3 && segments[0] == "apps" && request.Method == "GET":
+		switch segments[2] {
+		case "summary", "instances", "stats":
+			return s.appDetails(segments[1], segments[2], request)
+		}
+	}
+
+	collection := segments[0]
+	switch len(segments) {
+	case 1:
+		switch request.Method {
+		case "GET":
+			return s.list(collection, s.collections[collection], request)
+		case "POST":
+			return s.createFromRequest(collection, request)
+		}
+	case 2:
+		existing := s.find(collection, segments[1])
+		if existing == nil {
+			return notFound(request)
+		}
+
+		switch request.Method {
+		case "GET":
+			return response{http.StatusOK, s.render(collection, existing, inlineDepth(request))}
+		case "PUT":
+			return s.update(collection, existing, request)
+		case "DELETE":
+			return s.delete(collection, existing, request)
+		}
+	case 3, 4:
+		parent := s.find(collection, segments[1])
+		if parent == nil {
+			return notFound(request)
+		}
+
+		child := segments[2]
+		if len(segments) == 3 && request.Method == "GET" {
+			// every service is offered in every space
+			if child == "services" {
+				return s.list(child, s.collections[child], request)
+			}
+			return s.list(child, s.children(collection, parent.guid, child), request)
+		}
+
+		// associations such as roles and bound routes are accepted and
+		// not recorded
+		switch request.Method {
+		case "PUT":
+			return response{http.StatusCreated, s.render(collection, parent, 0)}
+		case "DELETE":
+			return response{http.StatusNoContent, nil}
+		}
+	}
+
+	return notFound(request)
+}
+
+func (s *Server) info(self string) response {
+	return response{http.StatusOK, map[string]interface{}{
+		"name":                     "dev-server",
+		"description":              "Local stand-in Cloud Controller",
+		"api_version":              APIVersion,
+		"authorization_endpoint":   self,
+		"token_endpoint":           self,
+		"logging_endpoint":         "",
+		"doppler_logging_endpoint": "",
+	}}
+}
+
+func (s *Server) login(self string) response {
+	return response{http.StatusOK, map[string]interface{}{
+		"links": map[string]string{"uaa": self, "login": self},
+		"prompts": map[string][]string{
+			"username": {"text", "Email"},
+			"password": {"password", "Password"},
+		},
+	}}
+}
+
+// token issues an unsigned token for whoever asks, so the CLI can read the
+// user name from it
+func (s *Server) token(request *http.Request) response {
+	if request.Method != "POST" {
+		return notFound(request)
+	}
+	_ = request.ParseForm()
+
+	username := request.PostForm.Get("username")
+	if request.PostForm.Get("grant_type") == "refresh_token" {
+		username = strings.TrimPrefix(request.PostForm.Get("refresh_token"), "dev-refresh-token-")
+	}
+	if username == "" {
+		username = "dev-user"
+	}
+
+	return response{http.StatusOK, map[string]interface{}{
+		"access_token":  accessToken(username),
+		"token_type":    "bearer",
+		"refresh_token": "dev-refresh-token-" + username,
+		"expires_in":    43199,
+		"scope":         "cloud_controller.read cloud_controller.write openid",
+	}}
+}
+
+func accessToken(username string) string {
+	encode := func(data []byte) string {
+		return strings.TrimRight(base64.StdEncoding.EncodeToString(data), "=")
+	}
+
+	claims, _ := json.Marshal(map[string]string{
+		"user_name": username,
+		"user_id":   "dev-user-" + username,
+		"email":     username,
+	})
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode(claims) + "."
+}
+
+// job answers for the jobs started by asynchronous deletes, which always
+// finish straight away
+func (s *Server) job(guid string, request *http.Request) response {
+	if !s.jobs[guid] {
+		return notFound(request)
+	}
+
+	return response{http.StatusOK, jobResource(guid)}
+}
+
+func jobResource(guid string) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"guid":       guid,
+			"url":        "/v2/jobs/" + guid,
+			"created_at": timestamp(),
+		},
+		"entity": map[string]interface{}{
+			"guid":   guid,
+			"status": "finished",
+		},
+	}
+}
+
+func (s *Server) spaceSummary(guid string, request *http.Request) response {
+	space := s.find("spaces", guid)
+	if space == nil {
+		return notFound(request)
+	}
+
+	apps := []interface{}{}
+	for _, app := range s.children("spaces", guid, "apps") {
+		apps = append(apps, appSummary(app))
+	}
+
+	services := []interface{}{}
+	for _, instance := range s.children("spaces", guid, "service_instances") {
+		summary := copyEntity(instance.entity)
+		summary["guid"] = instance.guid
+		summary["bound_app_count"] = 0
+		if plan := s.find("service_plans", stringField(instance.entity, "service_plan_guid")); plan != nil {
+			planSummary := copyEntity(plan.entity)
+			planSummary["guid"] = plan.guid
+			if service := s.find("services", stringField(plan.entity, "service_guid")); service != nil {
+				serviceSummary := copyEntity(service.entity)
+				serviceSummary["guid"] = service.guid
+				planSummary["service"] = serviceSummary
+			}
+			summary["service_plan"] = planSummary
+		}
+		services = append(services, summary)
+	}
+
+	return response{http.StatusOK, map[string]interface{}{
+		"guid":     space.guid,
+		"name":     space.entity["name"],
+		"apps":     apps,
+		"services": services,
+	}}
+}
+
+func appSummary(app *resource) map[string]interface{} {
+	summary := copyEntity(app.entity)
+	summary["guid"] = app.guid
+	summary["urls"] = []string{}
+	summary["routes"] = []interface{}{}
+	summary["available_domains"] = []interface{}{}
+	summary["running_instances"] = 0
+	if summary["state"] == "STARTED" {
+		summary["running_instances"] = summary["instances"]
+	}
+	return summary
+}
+
+// appDetails answers for the summary of an app and for its instances, which
+// are all running while the app is started
+func (s *Server) appDetails(guid, detail string, request *http.Request) response {
+	app := s.find("apps", guid)
+	if app == nil {
+		return notFound(request)
+	}
+
+	if detail == "summary" {
+		return response{http.StatusOK, appSummary(app)}
+	}
+
+	if app.entity["state"] != "STARTED" {
+		return response{http.StatusBadRequest, map[string]interface{}{
+			"code":        220001,
+			"description": "Instances error: App is stopped",
+			"error_code":  "CF-InstancesError",
+		}}
+	}
+
+	instances, _ := strconv.Atoi(fmt.Sprint(app.entity["instances"]))
+	memory, _ := strconv.Atoi(fmt.Sprint(app.entity["memory"]))
+	diskQuota, _ := strconv.Atoi(fmt.Sprint(app.entity["disk_quota"]))
+
+	details := map[string]interface{}{}
+	for i := 0; i < instances; i++ {
+		if detail == "instances" {
+			details[strconv.Itoa(i)] = map[string]interface{}{
+				"state": "RUNNING",
+				"since": float64(time.Now().Unix()),
+			}
+		} else {
+			details[strconv.Itoa(i)] = map[string]interface{}{
+				"state": "RUNNING",
+				"stats": map[string]interface{}{
+					"mem_quota":  memory * 1024 * 1024,
+					"disk_quota": diskQuota * 1024 * 1024,
+					"usage":      map[string]interface{}{"cpu": 0, "disk": 0, "mem": 0},
+				},
+			}
+		}
+	}
+
+	return response{http.StatusOK, details}
+}
+
+// list answers with a page of resources matching the q filters of the
+// request, with the next_url and total_pages of a Cloud Controller list
+func (s *Server) list(collection string, resources []*resource, request *http.Request) response {
+	query := request.URL.Query()
+
+	matching := []*resource{}
+	for _, r := range resources {
+		if s.matches(r, query["q"]) {
+			matching = append(matching, r)
+		}
+	}
+
+	perPage, err := strconv.Atoi(query.Get("results-per-page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	totalPages := (len(matching) + perPage - 1) / perPage
+	if totalPages == 0 {
+		totalPages = 1
+	}
+
+	rendered := []interface{}{}
+	for i := (page - 1) * perPage; i < len(matching) && i < page*perPage; i++ {
+		rendered = append(rendered, s.render(collection, matching[i], inlineDepth(request)))
+	}
+
+	var nextURL interface{}
+	if page < totalPages {
+		query.Set("page", strconv.Itoa(page+1))
+		query.Set("results-per-page", strconv.Itoa(perPage))
+		nextURL = request.URL.Path + "?" + query.Encode()
+	}
+
+	return response{http.StatusOK, map[string]interface{}{
+		"total_results": len(matching),
+		"total_pages":   totalPages,
+		"prev_url":      nil,
+		"next_url":      nextURL,
+		"resources":     rendered,
+	}}
+}
+
+// matches applies the q filters the repositories use: "field:value" and
+// "field IN a,b". Fields a resource does not have are looked up on the
+// resources it belongs to, so routes can be filtered by organization_guid.
+func (s *Server) matches(r *resource, filters []string) bool {
+	for _, filter := range filters {
+		var field string
+		var values []string
+
+		if i := strings.Index(filter, " IN "); i >= 0 {
+			field, values = filter[:i], strings.Split(filter[i+len(" IN "):], ",")
+		} else if i := strings.Index(filter, ":"); i >= 0 {
+			field, values = filter[:i], []string{filter[i+1:]}
+		} else {
+			continue
+		}
+
+		value, ok := s.field(r, field)
+		if !ok || !contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) field(r *resource, field string) (string, bool) {
+	if field == "guid" {
+		return r.guid, true
+	}
+
+	if value, ok := r.entity[field]; ok {
+		return fmt.Sprint(value), true
+	}
+
+	for key := range r.entity {
+		if !strings.HasSuffix(key, "_guid") {
+			continue
+		}
+
+		owner := s.findBySingular(strings.TrimSuffix(key, "_guid"), stringField(r.entity, key))
+		if owner == nil {
+			continue
+		}
+		if value, ok := owner.entity[field]; ok {
+			return fmt.Sprint(value), true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) createFromRequest(collection string, request *http.Request) response {
+	entity := map[string]interface{}{}
+	body, _ := ioutil.ReadAll(request.Body)
+	if len(body) > 0 {
+		err := json.Unmarshal(body, &entity)
+		if err != nil {
+			return invalidRequest(err.Error())
+		}
+	}
+
+	if rule, unique := uniqueWithin[collection]; unique {
+		for _, other := range s.collections[collection] {
+			if other.entity["name"] == entity["name"] && (rule.scope == "" || other.entity[rule.scope] == entity[rule.scope]) {
+				return response{http.StatusBadRequest, map[string]interface{}{
+					"code":        rule.code,
+					"description": fmt.Sprintf("The name is taken: %v", entity["name"]),
+					"error_code":  rule.errorCode,
+				}}
+			}
+		}
+	}
+
+	for key, value := range defaults[collection] {
+		if _, set := entity[key]; !set {
+			entity[key] = value
+		}
+	}
+
+	return response{http.StatusCreated, s.render(collection, s.create(collection, entity), 0)}
+}
+
+func (s *Server) create(collection string, entity map[string]interface{}) *resource {
+	s.nextGUID++
+	r := &resource{
+		guid:      fmt.Sprintf("%s-%08d-0000-0000-0000-000000000000", strings.Replace(singular(collection), "_", "-", -1), s.nextGUID),
+		createdAt: timestamp(),
+		entity:    entity,
+	}
+	s.collections[collection] = append(s.collections[collection], r)
+	return r
+}
+
+func (s *Server) update(collection string, r *resource, request *http.Request) response {
+	changes := map[string]interface{}{}
+	body, _ := ioutil.ReadAll(request.Body)
+	if len(body) > 0 {
+		err := json.Unmarshal(body, &changes)
+		if err != nil {
+			return invalidRequest(err.Error())
+		}
+	}
+
+	for key, value := range changes {
+		r.entity[key] = value
+	}
+
+	return response{http.StatusCreated, s.render(collection, r, 0)}
+}
+
+// delete removes a resource and, when asked to be recursive, everything
+// that belongs to it. Asynchronous deletes answer with a job that has
+// already finished.
+func (s *Server) delete(collection string, r *resource, request *http.Request) response {
+	if len(s.owned(collection, r.guid)) > 0 && request.URL.Query().Get("recursive") != "true" {
+		return response{http.StatusBadRequest, map[string]interface{}{
+			"code":        10006,
+			"description": fmt.Sprintf("Please delete the associations for %s first", collection),
+			"error_code":  "CF-AssociationNotEmpty",
+		}}
+	}
+
+	s.remove(collection, r)
+
+	if request.URL.Query().Get("async") == "true" {
+		s.nextGUID++
+		guid := fmt.Sprintf("job-%08d-0000-0000-0000-000000000000", s.nextGUID)
+		s.jobs[guid] = true
+		return response{http.StatusAccepted, jobResource(guid)}
+	}
+	return response{http.StatusNoContent, nil}
+}
+
+func (s *Server) remove(collection string, r *resource) {
+	for _, o := range s.owned(collection, r.guid) {
+		s.remove(o.collection, o.resource)
+	}
+
+	kept := []*resource{}
+	for _, other := range s.collections[collection] {
+		if other != r {
+			kept = append(kept, other)
+		}
+	}
+	s.collections[collection] = kept
+}
+
+type ownedResource struct {
+	collection string
+	resource   *resource
+}
+
+// owned returns the resources of every collection that belong to a resource
+// through their <singular>_guid field
+func (s *Server) owned(collection, guid string) []ownedResource {
+	key := singular(collection) + "_guid"
+
+	owned := []ownedResource{}
+	for _, name := range s.collectionNames() {
+		for _, r := range s.collections[name] {
+			if stringField(r.entity, key) == guid || (collection == "organizations" && stringField(r.entity, "owning_organization_guid") == guid) {
+				owned = append(owned, ownedResource{name, r})
+			}
+		}
+	}
+	return owned
+}
+
+// children returns the resources of childCollection that belong to a
+// resource
+func (s *Server) children(collection, guid, childCollection string) []*resource {
+	children := []*resource{}
+	for _, o := range s.owned(collection, guid) {
+		if o.collection == childCollection {
+			children = append(children, o.resource)
+		}
+	}
+	return children
+}
+
+func (s *Server) collectionNames() []string {
+	names := []string{}
+	for name := range s.collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Server) find(collection, guid string) *resource {
+	for _, r := range s.collections[collection] {
+		if r.guid == guid {
+			return r
+		}
+	}
+	return nil
+}
+
+// findBySingular finds the resource a <singular>_guid field refers to
+func (s *Server) findBySingular(name, guid string) *resource {
+	if name == "domain" {
+		if r := s.find("shared_domains", guid); r != nil {
+			return r
+		}
+		return s.find("private_domains", guid)
+	}
+	if name == "owning_organization" {
+		name = "organization"
+	}
+	return s.find(name+"s", guid)
+}
+
+// render turns a resource into the metadata and entity of the Cloud
+// Controller, inlining the resources it refers to and its children when
+// depth is at least 1
+func (s *Server) render(collection string, r *resource, depth int) map[string]interface{} {
+	entity := copyEntity(r.entity)
+
+	if depth > 0 {
+		for key := range r.entity {
+			if !strings.HasSuffix(key, "_guid") {
+				continue
+			}
+
+			name := strings.TrimSuffix(key, "_guid")
+			if owner := s.findBySingular(name, stringField(r.entity, key)); owner != nil {
+				entity[name] = s.render(name+"s", owner, 0)
+			}
+		}
+
+		for _, child := range inlineChildren[collection] {
+			inlined := []interface{}{}
+			for _, c := range s.children(collection, r.guid, child) {
+				inlined = append(inlined, s.render(child, c, 0))
+			}
+			entity[inlineName(child)] = inlined
+		}
+	}
+
+	return map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"guid":       r.guid,
+			"url":        "/v2/" + collection + "/" + r.guid,
+			"created_at": r.createdAt,
+			"updated_at": nil,
+		},
+		"entity": entity,
+	}
+}
+
+// inlineName is the entity field an inlined list is sent in
+func inlineName(child string) string {
+	if child == "private_domains" {
+		return "domains"
+	}
+	return child
+}
+
+func inlineDepth(request *http.Request) int {
+	depth, _ := strconv.Atoi(request.URL.Query().Get("inline-relations-depth"))
+	return depth
+}
+
+func singular(collection string) string {
+	return strings.TrimSuffix(collection, "s")
+}
+
+func copyEntity(entity map[string]interface{}) map[string]interface{} {
+	copied := map[string]interface{}{}
+	for key, value := range entity {
+		copied[key] = value
+	}
+	return copied
+}
+
+func stringField(entity map[string]interface{}, key string) string {
+	value, _ := entity[key].(string)
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func notFound(request *http.Request) response {
+	return response{http.StatusNotFound, map[string]interface{}{
+		"code":        notFoundCode,
+		"description": fmt.Sprintf("Unknown request %s %s", request.Method, request.URL.RequestURI()),
+		"error_code":  "CF-NotFound",
+	}}
+}
+
+func invalidRequest(description string) response {
+	return response{http.StatusBadRequest, map[string]interface{}{
+		"code":        invalidRequestCode,
+		"description": description,
+		"error_code":  "CF-InvalidRequest",
+	}}
+}
@@ -0,0 +1,182 @@
+package devserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/api/jobs"
+	"github.com/cloudfoundry/cli/cf/api/organizations"
+	"github.com/cloudfoundry/cli/cf/api/spaces"
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
+	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+
+	. "github.com/cloudfoundry/cli/cf/devserver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		ts      *httptest.Server
+		config  coreconfig.ReadWriter
+		orgRepo organizations.CloudControllerOrganizationRepository
+		logged  []string
+	)
+
+	BeforeEach(func() {
+		logged = []string{}
+		ts = httptest.NewServer(NewServer(func(request *http.Request, status int) {
+			logged = append(logged, request.Method+" "+request.URL.Path)
+		}))
+
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetAPIEndpoint(ts.URL)
+		orgRepo = organizations.NewCloudControllerOrganizationRepository(config, cloudcontrollergateway.NewTestCloudControllerGateway(config))
+	})
+
+	AfterEach(func() {
+		ts.Close()
+	})
+
+	get := func(path string) (int, map[string]interface{}) {
+		response, err := http.Get(ts.URL + path)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		body := map[string]interface{}{}
+		data, _ := ioutil.ReadAll(response.Body)
+		_ = json.Unmarshal(data, &body)
+		return response.StatusCode, body
+	}
+
+	send := func(method, path, body string) int {
+		request, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		return response.StatusCode
+	}
+
+	It("points the CLI at itself for logging in", func() {
+		status, info := get("/v2/info")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(info["authorization_endpoint"]).To(Equal(ts.URL))
+		Expect(info["token_endpoint"]).To(Equal(ts.URL))
+		Expect(logged).To(ContainElement("GET /v2/info"))
+	})
+
+	It("issues a token for any user", func() {
+		response, err := http.PostForm(ts.URL+"/oauth/token", map[string][]string{
+			"grant_type": {"password"},
+			"username":   {"someone"},
+			"password":   {"anything"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		token := map[string]interface{}{}
+		Expect(json.NewDecoder(response.Body).Decode(&token)).To(Succeed())
+		Expect(token["access_token"]).NotTo(BeEmpty())
+		Expect(token["refresh_token"]).To(Equal("dev-refresh-token-someone"))
+	})
+
+	It("starts with a dev org", func() {
+		org, err := orgRepo.FindByName("dev-org")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(org.Name).To(Equal("dev-org"))
+		Expect(org.Spaces).To(HaveLen(1))
+		Expect(org.Spaces[0].Name).To(Equal("dev-space"))
+	})
+
+	It("keeps the orgs it is asked to create", func() {
+		Expect(orgRepo.Create(models.Organization{OrganizationFields: models.OrganizationFields{Name: "new-org"}})).To(Succeed())
+
+		org, err := orgRepo.FindByName("new-org")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(org.GUID).NotTo(BeEmpty())
+
+		err = orgRepo.Create(models.Organization{OrganizationFields: models.OrganizationFields{Name: "new-org"}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.(errors.HTTPError).ErrorCode()).To(Equal(errors.OrganizationNameTaken))
+	})
+
+	It("answers for missing resources with not found", func() {
+		_, err := orgRepo.FindByName("no-such-org")
+		Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+
+		status, body := get("/v2/apps/no-such-guid")
+		Expect(status).To(Equal(http.StatusNotFound))
+		Expect(body["error_code"]).To(Equal("CF-NotFound"))
+	})
+
+	It("splits lists into pages", func() {
+		for _, name := range []string{"org-a", "org-b", "org-c"} {
+			Expect(orgRepo.Create(models.Organization{OrganizationFields: models.OrganizationFields{Name: name}})).To(Succeed())
+		}
+
+		status, page := get("/v2/organizations?results-per-page=2")
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(page["total_results"]).To(BeNumerically("==", 4))
+		Expect(page["total_pages"]).To(BeNumerically("==", 2))
+		Expect(page["next_url"]).To(ContainSubstring("page=2"))
+
+		orgs, err := orgRepo.ListOrgs(0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orgs).To(HaveLen(4))
+	})
+
+	It("refuses to delete an org that still has spaces unless asked to recurse", func() {
+		org, err := orgRepo.FindByName("dev-org")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(send("DELETE", "/v2/organizations/"+org.GUID, "")).To(Equal(http.StatusBadRequest))
+		Expect(orgRepo.Delete(org.GUID)).To(Succeed())
+
+		status, _ := get("/v2/spaces/" + org.Spaces[0].GUID)
+		Expect(status).To(Equal(http.StatusNotFound))
+	})
+
+	It("finishes asynchronous deletes in a job straight away", func() {
+		spaceRepo := spaces.NewCloudControllerSpaceRepository(config, cloudcontrollergateway.NewTestCloudControllerGateway(config))
+		jobRepo := jobs.NewCloudControllerJobRepository(config, cloudcontrollergateway.NewTestCloudControllerGateway(config))
+
+		org, err := orgRepo.FindByName("dev-org")
+		Expect(err).NotTo(HaveOccurred())
+		space, err := spaceRepo.FindByNameInOrg("dev-space", org.GUID)
+		Expect(err).NotTo(HaveOccurred())
+
+		jobURL, err := spaceRepo.DeleteWithoutWaiting(space.GUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobURL).To(HavePrefix("/v2/jobs/"))
+
+		job, err := jobRepo.Get(strings.TrimPrefix(jobURL, "/v2/jobs/"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Status).To(Equal("finished"))
+	})
+
+	It("reports a stopped app as having no instances", func() {
+		status, space := get("/v2/spaces?q=name:dev-space")
+		Expect(status).To(Equal(http.StatusOK))
+		spaceGUID := space["resources"].([]interface{})[0].(map[string]interface{})["metadata"].(map[string]interface{})["guid"].(string)
+
+		Expect(send("POST", "/v2/apps", `{"name":"my-app","space_guid":"`+spaceGUID+`"}`)).To(Equal(http.StatusCreated))
+
+		status, summary := get("/v2/spaces/" + spaceGUID + "/summary")
+		Expect(status).To(Equal(http.StatusOK))
+		apps := summary["apps"].([]interface{})
+		Expect(apps).To(HaveLen(1))
+		app := apps[0].(map[string]interface{})
+		Expect(app["name"]).To(Equal("my-app"))
+		Expect(app["running_instances"]).To(BeNumerically("==", 0))
+
+		status, body := get("/v2/apps/" + app["guid"].(string) + "/instances")
+		Expect(status).To(Equal(http.StatusBadRequest))
+		Expect(body["error_code"]).To(Equal("CF-InstancesError"))
+	})
+})
@@ -108,6 +108,7 @@ func newAppPresenter() appPresenter {
 				}, {
 					presentCommand("api"),
 					presentCommand("auth"),
+					presentCommand("context"),
 				},
 			},
 		}, {
@@ -363,8 +364,11 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("curl"),
 					presentCommand("config"),
+					presentCommand("job"),
 					presentCommand("oauth-token"),
 					presentCommand("ssh-code"),
+					presentCommand("replay"),
+					presentCommand("dev-server"),
 				},
 			},
 		}, {
@@ -375,6 +379,13 @@ func newAppPresenter() appPresenter {
 					presentCommand("remove-plugin-repo"),
 					presentCommand("list-plugin-repos"),
 					presentCommand("repo-plugins"),
+					presentCommand("mirror-plugin-repo"),
+				},
+				{
+					presentCommand("add-plugin-key"),
+					presentCommand("remove-plugin-key"),
+					presentCommand("plugin-keys"),
+					presentCommand("set-plugin-trust-policy"),
 				},
 			},
 		}, {
@@ -383,6 +394,7 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("plugins"),
 					presentCommand("install-plugin"),
+					presentCommand("update-plugin"),
 					presentCommand("uninstall-plugin"),
 				},
 			},
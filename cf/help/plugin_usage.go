@@ -0,0 +1,51 @@
+package help
+
+import (
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+// PluginCommandUsage returns the help of a plugin command in the layout of
+// the help of core commands
+func PluginCommandUsage(command plugin.Command) string {
+	output := T("NAME:") + "\n"
+	output += "   " + command.Name + " - " + command.HelpText + "\n"
+
+	if aliases := command.AllAliases(); len(aliases) > 0 {
+		output += "\n" + T("ALIAS:") + "\n"
+		output += "   " + strings.Join(aliases, ", ") + "\n"
+	}
+
+	output += "\n" + T("USAGE:") + "\n"
+	output += "   " + command.UsageDetails.Usage + "\n"
+
+	var flagUsage string
+	if len(command.Flags) > 0 {
+		fc, err := pluginconfig.NewFlagContext(command)
+		if err == nil {
+			flagUsage = fc.ShowUsage(3) + "\n"
+		}
+	}
+
+	if flagUsage != "" || len(command.UsageDetails.Options) > 0 {
+		output += "\n" + T("OPTIONS:") + "\n"
+		output += flagUsage
+
+		//find longest name length
+		l := 0
+		for n := range command.UsageDetails.Options {
+			if len(n) > l {
+				l = len(n)
+			}
+		}
+
+		for n, f := range command.UsageDetails.Options {
+			output += "   -" + n + strings.Repeat(" ", 7+(l-len(n))) + f + "\n"
+		}
+	}
+
+	return output
+}
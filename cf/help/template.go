@@ -17,16 +17,23 @@ func GetHelpTemplate() string {
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
    CF_COLOR=false                     ` + T("Do not colorize output") + `
+   CF_CONTEXT=name                    ` + T("Run commands against a saved login context") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
    CF_PLUGIN_HOME=path/to/dir/        ` + T("Override path to default plugin config directory") + `
+   CF_SECRETS_AGENT_SOCK=path/to/sock ` + T("Socket of the agent used by the 'agent' secret backend") + `
+   CF_SECRETS_PASSPHRASE=passphrase   ` + T("Passphrase for the 'encrypted-file' secret backend") + `
    CF_STAGING_TIMEOUT=15              ` + T("Max wait time for buildpack staging, in minutes") + `
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
    CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file") + `
+   CF_TRACE_FORMAT=har                ` + T("Write trace files in HTTP Archive (HAR) format") + `
    https_proxy=proxy.example.com:8080 ` + T("Enable HTTP proxying for API requests") + `
 
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
+   --context NAME                     ` + T("Run the command against a saved login context") + `
    --help, -h                         ` + T("Show help") + `
+   --timings                          ` + T("Print a summary of the time spent in each API endpoint and local operation") + `
+   --trace-file path/to/trace.har     ` + T("Record API requests to a file, in HAR format if it ends in .har") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
 `
 }
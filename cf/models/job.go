@@ -0,0 +1,8 @@
+package models
+
+type Job struct {
+	GUID      string
+	Status    string
+	CreatedAt string
+	Error     string
+}
@@ -1,6 +1,29 @@
 package models
 
+// Trust policies decide what happens to plugin binaries that are not signed
+// by a trusted key
+const (
+	PluginTrustRequireSigned = "require-signed"
+	PluginTrustWarn          = "warn"
+	PluginTrustAllow         = "allow"
+)
+
+var PluginTrustPolicies = []string{PluginTrustRequireSigned, PluginTrustWarn, PluginTrustAllow}
+
 type PluginRepo struct {
-	Name string
-	URL  string
+	Name        string
+	URL         string
+	TrustPolicy string
+
+	// Username and Password, or Token, are sent to repositories behind
+	// basic or bearer token authentication
+	Username string `json:",omitempty"`
+	Password string `json:",omitempty"`
+	Token    string `json:",omitempty"`
+}
+
+// PluginKey is a public key trusted to sign plugin binaries, in PEM format
+type PluginKey struct {
+	Name      string
+	PublicKey string
 }
@@ -13,6 +13,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -26,7 +27,9 @@ import (
 const (
 	JobFinished            = "finished"
 	JobFailed              = "failed"
-	DefaultPollingThrottle = 5 * time.Second
+	DefaultPollingThrottle = 1 * time.Second
+	MaxPollingThrottle     = 15 * time.Second
+	DefaultPageConcurrency = 4
 )
 
 type JobResource struct {
@@ -44,6 +47,14 @@ type AsyncResource struct {
 	}
 }
 
+// warningsMutex guards the warnings of every gateway, since the pages of a
+// list are fetched at the same time
+var warningsMutex sync.Mutex
+
+// refreshMutex serializes refreshing the auth token across gateways, which
+// share the token in the config
+var refreshMutex sync.Mutex
+
 type apiErrorHandler func(statusCode int, body []byte) error
 
 type tokenRefresher interface {
@@ -60,10 +71,14 @@ type Gateway struct {
 	errHandler      apiErrorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
+	RetryBackoff    time.Duration
+	PageConcurrency int
 	trustedCerts    []tls.Certificate
 	config          coreconfig.Reader
 	warnings        *[]string
+	limiter         *requestLimiter
 	Clock           func() time.Time
+	ResponseCache   *ResponseCache
 	transport       *http.Transport
 	ui              terminal.UI
 	logger          trace.Printer
@@ -74,7 +89,10 @@ func newGateway(errHandler apiErrorHandler, config coreconfig.Reader, ui termina
 		errHandler:      errHandler,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
+		RetryBackoff:    DefaultRetryBackoff,
+		PageConcurrency: DefaultPageConcurrency,
 		warnings:        &[]string{},
+		limiter:         newRequestLimiter(config),
 		Clock:           time.Now,
 		ui:              ui,
 		logger:          logger,
@@ -141,34 +159,150 @@ func (gateway Gateway) DeleteResource(endpoint, apiURL string) error {
 	return gateway.createUpdateOrDeleteResource("DELETE", endpoint, apiURL, nil, false, &AsyncResource{})
 }
 
+// ListPaginatedResources calls cb with every resource of a paginated list,
+// in order, until cb returns false. Once the first page gives the number of
+// pages, up to PageConcurrency of the pages after the one being read are
+// fetched at the same time.
 func (gateway Gateway) ListPaginatedResources(
 	target string,
 	path string,
 	resource interface{},
 	cb func(interface{}) bool,
 ) error {
-	for path != "" {
-		pagination := NewPaginatedResources(resource)
+	pagination := NewPaginatedResources(resource)
+	apiErr := gateway.getCachedResource(fmt.Sprintf("%s%s", target, path), &pagination)
+	if apiErr != nil {
+		return apiErr
+	}
+
+	pageURLs, ok := pagination.RemainingPageURLs()
+	if !ok || gateway.PageConcurrency < 2 {
+		return gateway.listPagesInSequence(target, pagination, resource, cb)
+	}
+
+	pages := make([]chan pageResult, len(pageURLs))
+	fetch := func(i int) {
+		pages[i] = make(chan pageResult, 1)
+		go func(url string, result chan<- pageResult) {
+			page := NewPaginatedResources(resource)
+			err := gateway.getCachedResource(url, &page)
+			result <- pageResult{page: page, err: err}
+		}(fmt.Sprintf("%s%s", target, pageURLs[i]), pages[i])
+	}
 
-		apiErr := gateway.GetResource(fmt.Sprintf("%s%s", target, path), &pagination)
-		if apiErr != nil {
-			return apiErr
+	for i := 0; i < len(pageURLs) && i < gateway.PageConcurrency; i++ {
+		fetch(i)
+	}
+
+	for i := -1; i < len(pageURLs); i++ {
+		if i >= 0 {
+			result := <-pages[i]
+			if result.err != nil {
+				return result.err
+			}
+			pagination = result.page
+
+			if next := i + gateway.PageConcurrency; next < len(pageURLs) {
+				fetch(next)
+			}
 		}
 
-		resources, err := pagination.Resources()
+		more, err := eachResource(pagination, cb)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type pageResult struct {
+	page PaginatedResources
+	err  error
+}
+
+func (gateway Gateway) listPagesInSequence(target string, pagination PaginatedResources, resource interface{}, cb func(interface{}) bool) error {
+	for {
+		more, err := eachResource(pagination, cb)
+		if err != nil || !more || pagination.NextURL == "" {
+			return err
+		}
+
+		nextURL := pagination.NextURL
+		pagination = NewPaginatedResources(resource)
+		err = gateway.getCachedResource(fmt.Sprintf("%s%s", target, nextURL), &pagination)
 		if err != nil {
-			return fmt.Errorf("%s: %s", T("Error parsing JSON"), err.Error())
+			return err
 		}
+	}
+}
 
-		for _, resource := range resources {
-			if !cb(resource) {
-				return nil
-			}
+// eachResource calls cb with the resources of a page, returning false once
+// cb has asked to stop
+func eachResource(pagination PaginatedResources, cb func(interface{}) bool) (bool, error) {
+	resources, err := pagination.Resources()
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", T("Error parsing JSON"), err.Error())
+	}
+
+	for _, resource := range resources {
+		if !cb(resource) {
+			return false, nil
 		}
+	}
+
+	return true, nil
+}
 
-		path = pagination.NextURL
+// getCachedResource is GetResource for the pages of a list, answered from the
+// response cache while its entry is fresh and revalidated with the entry's
+// ETag once it is not
+func (gateway Gateway) getCachedResource(url string, resource interface{}) error {
+	if !gateway.ResponseCache.enabled() || !cacheable(url) {
+		return gateway.GetResource(url, resource)
 	}
 
+	entry, cached := gateway.ResponseCache.get(url)
+	if cached && gateway.Clock().Sub(entry.StoredAt) < gateway.ResponseCache.ttl() {
+		return json.Unmarshal(entry.Body, &resource)
+	}
+
+	request, err := gateway.NewRequest("GET", url, gateway.config.AccessToken(), nil)
+	if err != nil {
+		return err
+	}
+	if cached && entry.ETag != "" {
+		request.HTTPReq.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	rawResponse, err := gateway.doRequestHandlingAuth(request)
+	if rawResponse != nil && rawResponse.StatusCode == http.StatusNotModified && cached {
+		_ = rawResponse.Body.Close()
+		entry.StoredAt = gateway.Clock()
+		gateway.ResponseCache.put(entry)
+		return json.Unmarshal(entry.Body, &resource)
+	}
+	if err != nil {
+		return err
+	}
+	defer rawResponse.Body.Close()
+
+	body, err := ioutil.ReadAll(rawResponse.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error reading response"), err.Error())
+	}
+
+	err = json.Unmarshal(body, &resource)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Invalid JSON response from server"), err.Error())
+	}
+
+	gateway.ResponseCache.put(cacheEntry{
+		URL:      url,
+		ETag:     rawResponse.Header.Get("ETag"),
+		Body:     body,
+		StoredAt: gateway.Clock(),
+	})
 	return nil
 }
 
@@ -333,13 +467,60 @@ func (gateway Gateway) PerformPollingRequestForJSONResponse(endpoint string, req
 }
 
 func (gateway Gateway) Warnings() []string {
+	warningsMutex.Lock()
+	defer warningsMutex.Unlock()
 	return *gateway.warnings
 }
 
+// DeleteResourceWithoutWaiting asks for a resource to be deleted
+// asynchronously and returns the URL of the job doing it without waiting for
+// the job to finish, or "" when the resource was deleted straight away
+func (gateway Gateway) DeleteResourceWithoutWaiting(endpoint, apiURL string) (string, error) {
+	request, err := gateway.NewRequest("DELETE", endpoint+apiURL, gateway.config.AccessToken(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	query := request.HTTPReq.URL.Query()
+	query.Add("async", "true")
+	request.HTTPReq.URL.RawQuery = query.Encode()
+
+	bytes, _, _, err := gateway.performRequestForResponseBytes(request)
+	if err != nil {
+		return "", err
+	}
+
+	asyncResource := &AsyncResource{}
+	if strings.TrimSpace(string(bytes)) == "" || json.Unmarshal(bytes, asyncResource) != nil {
+		return "", nil
+	}
+
+	if !strings.Contains(asyncResource.Metadata.URL, "/jobs/") {
+		return "", nil
+	}
+	return asyncResource.Metadata.URL, nil
+}
+
+// WaitForJob polls the job at jobURL until it finishes, for no longer than
+// the async timeout in the config
+func (gateway Gateway) WaitForJob(jobURL string) error {
+	return gateway.waitForJob(jobURL, gateway.config.AccessToken(), gateway.AsyncTimeout())
+}
+
+// waitForJob polls a job, starting at PollingThrottle and backing off
+// gradually up to MaxPollingThrottle, and shows how long the job has been
+// running while it waits
 func (gateway Gateway) waitForJob(jobURL, accessToken string, timeout time.Duration) error {
 	startTime := gateway.Clock()
+	throttle := gateway.PollingThrottle
+	progressWidth := 0
+	defer func() {
+		gateway.clearJobProgress(progressWidth)
+	}()
+
 	for true {
-		if gateway.Clock().Sub(startTime) > timeout && timeout != 0 {
+		elapsed := gateway.Clock().Sub(startTime)
+		if elapsed > timeout && timeout != 0 {
 			return errors.NewAsyncTimeoutError(jobURL)
 		}
 		var request *Request
@@ -357,13 +538,57 @@ func (gateway Gateway) waitForJob(jobURL, accessToken string, timeout time.Durat
 			return errors.New(response.Entity.ErrorDetails.Description)
 		}
 
+		progressWidth = gateway.showJobProgress(response.Entity.Status, elapsed, progressWidth)
+
 		accessToken = request.HTTPReq.Header.Get("Authorization")
 
-		time.Sleep(gateway.PollingThrottle)
+		time.Sleep(throttle)
+		throttle = nextPollingThrottle(throttle)
 	}
 	return nil
 }
 
+func nextPollingThrottle(throttle time.Duration) time.Duration {
+	if throttle >= MaxPollingThrottle {
+		return throttle
+	}
+
+	throttle = throttle * 3 / 2
+	if throttle > MaxPollingThrottle {
+		return MaxPollingThrottle
+	}
+	return throttle
+}
+
+// showJobProgress overwrites the progress line, which is width columns wide,
+// when stdout is a terminal, and returns the width of the new line
+func (gateway Gateway) showJobProgress(status string, elapsed time.Duration, width int) int {
+	if gateway.ui == nil || !terminal.StdoutIsTerminal {
+		return 0
+	}
+
+	progress := T("Job {{.Status}} ({{.Elapsed}} elapsed)...", map[string]interface{}{
+		"Status":  status,
+		"Elapsed": elapsed / time.Second * time.Second,
+	})
+	progressWidth := terminal.VisibleSize(progress)
+	if progressWidth < width {
+		progress += strings.Repeat(" ", width-progressWidth)
+		progressWidth = width
+	}
+
+	gateway.ui.PrintCapturingNoOutput("\r%s", progress)
+	return progressWidth
+}
+
+func (gateway Gateway) clearJobProgress(width int) {
+	if width == 0 {
+		return
+	}
+
+	gateway.ui.PrintCapturingNoOutput("\r%s\r", strings.Repeat(" ", width))
+}
+
 func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response, error) {
 	httpReq := request.HTTPReq
 
@@ -371,8 +596,12 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 		httpReq.Body = ioutil.NopCloser(request.SeekableBody)
 	}
 
+	if httpReq.Method != "GET" && httpReq.Method != "HEAD" {
+		gateway.ResponseCache.Invalidate()
+	}
+
 	// perform request
-	rawResponse, err := gateway.doRequestAndHandlerError(request)
+	rawResponse, err := gateway.doRequestWithRetries(request)
 	if err == nil || gateway.authenticator == nil {
 		return rawResponse, err
 	}
@@ -381,7 +610,7 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	case *errors.InvalidTokenError:
 		// refresh the auth token
 		var newToken string
-		newToken, err = gateway.authenticator.RefreshAuthToken()
+		newToken, err = gateway.refreshAuthToken(httpReq.Header.Get("Authorization"))
 		if err != nil {
 			return rawResponse, err
 		}
@@ -394,13 +623,31 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 		}
 
 		// make the request again
-		rawResponse, err = gateway.doRequestAndHandlerError(request)
+		rawResponse, err = gateway.doRequestWithRetries(request)
 	}
 
 	return rawResponse, err
 }
 
+// refreshAuthToken returns the token to retry a request with after the
+// server rejected usedToken. Requests failing at the same time wait for a
+// single refresh and reuse the token it got.
+func (gateway Gateway) refreshAuthToken(usedToken string) (string, error) {
+	refreshMutex.Lock()
+	defer refreshMutex.Unlock()
+
+	currentToken := gateway.config.AccessToken()
+	if usedToken != "" && currentToken != "" && currentToken != usedToken {
+		return currentToken, nil
+	}
+
+	return gateway.authenticator.RefreshAuthToken()
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
+	release := gateway.limiter.acquire()
+	defer release()
+
 	rawResponse, err := gateway.doRequest(request.HTTPReq)
 	if err != nil {
 		return rawResponse, WrapNetworkErrors(request.HTTPReq.URL.Host, err)
@@ -421,10 +668,14 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	var err error
 
 	if gateway.transport == nil {
-		makeHTTPTransport(&gateway)
+		err = makeHTTPTransport(&gateway)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+	dumper := NewRequestDumper(gateway.logger)
+	httpClient := NewHTTPClient(gateway.transport, dumper)
 
 	httpClient.DumpRequest(request)
 
@@ -438,6 +689,7 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	}
 
 	if err != nil {
+		dumper.DumpFailure(request, err)
 		return response, err
 	}
 
@@ -447,21 +699,30 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	rawWarnings := response.Header[header]
 	for _, rawWarning := range rawWarnings {
 		warning, _ := url.QueryUnescape(rawWarning)
+		warningsMutex.Lock()
 		*gateway.warnings = append(*gateway.warnings, warning)
+		warningsMutex.Unlock()
 	}
 
 	return response, err
 }
 
-func makeHTTPTransport(gateway *Gateway) {
+func makeHTTPTransport(gateway *Gateway) error {
+	tlsConfig, err := NewTLSConfigFromConfig(gateway.config, gateway.trustedCerts)
+	if err != nil {
+		gateway.transport = nil
+		return err
+	}
+
 	gateway.transport = &http.Transport{
 		Dial:            (&net.Dialer{Timeout: 5 * time.Second}).Dial,
-		TLSClientConfig: NewTLSConfig(gateway.trustedCerts, gateway.config.IsSSLDisabled()),
-		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: tlsConfig,
+		Proxy:           ProxyFromConfig(gateway.config),
 	}
+	return nil
 }
 
 func (gateway *Gateway) SetTrustedCerts(certificates []tls.Certificate) {
 	gateway.trustedCerts = certificates
-	makeHTTPTransport(gateway)
+	_ = makeHTTPTransport(gateway)
 }
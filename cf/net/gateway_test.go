@@ -2,15 +2,18 @@ package net_test
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf"
@@ -19,11 +22,15 @@ import (
 	"github.com/cloudfoundry/cli/cf/errors"
 	. "github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/net/netfakes"
+	"github.com/cloudfoundry/cli/cf/terminal"
+	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/cloudfoundry/cli/cf/trace/tracefakes"
 	"github.com/cloudfoundry/cli/testhelpers/cloudcontrollergateway"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
 	testnet "github.com/cloudfoundry/cli/testhelpers/net"
 	testterm "github.com/cloudfoundry/cli/testhelpers/terminal"
+
+	. "github.com/cloudfoundry/cli/testhelpers/matchers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
@@ -195,6 +202,583 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("retrying requests", func() {
+		var logger *tracefakes.FakePrinter
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			config.SetAPIEndpoint(ccServer.URL())
+			config.SetRequestRetries(2)
+
+			logger = new(tracefakes.FakePrinter)
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, logger)
+			ccGateway.RetryBackoff = time.Millisecond
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		It("retries GET requests that fail with a 5xx response", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusBadGateway, "bad gateway"),
+				ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"),
+				ghttp.RespondWith(http.StatusOK, `{"name":"my-app"}`),
+			)
+
+			response := struct{ Name string }{}
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps/my-app", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequestForJSONResponse(request, &response)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Name).To(Equal("my-app"))
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("resends the body of PUT requests", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusBadGateway, "bad gateway"),
+				ghttp.CombineHandlers(
+					ghttp.VerifyBody([]byte(`{"name":"new-name"}`)),
+					ghttp.RespondWith(http.StatusCreated, `{}`),
+				),
+			)
+
+			err := ccGateway.UpdateResource(config.APIEndpoint(), "/v2/apps/my-app", strings.NewReader(`{"name":"new-name"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("gives up after the configured number of retries", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"),
+				ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"),
+				ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"),
+			)
+
+			request, _ := ccGateway.NewRequest("DELETE", config.APIEndpoint()+"/v2/apps/my-app", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).To(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("does not retry POST requests", func() {
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"))
+
+			request, _ := ccGateway.NewRequest("POST", config.APIEndpoint()+"/v2/apps", config.AccessToken(), strings.NewReader("{}"))
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).To(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("does not retry 4xx responses", func() {
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusNotFound, `{"code": 100004, "description": "not found"}`))
+
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps/missing", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).To(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("does not retry when retries are not configured", func() {
+			config.SetRequestRetries(0)
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable"))
+
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).To(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("waits for the duration in the Retry-After header", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, "unavailable", http.Header{"Retry-After": []string{"1"}}),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			)
+
+			started := time.Now()
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(time.Since(started)).To(BeNumerically(">=", time.Second))
+		})
+
+		It("retries network errors", func() {
+			request, _ := ccGateway.NewRequest("GET", "http://127.0.0.1:1/v2/apps", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+
+			Expect(err).To(HaveOccurred())
+			Expect(tracedOutput(logger)).To(MatchRegexp(`(?s)RETRYING:.*retry 1 of 2.*RETRYING:.*retry 2 of 2`))
+		})
+
+		It("traces each retry", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusBadGateway, "bad gateway"),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			)
+
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tracedOutput(logger)).To(MatchRegexp(`RETRYING:.*GET .*/v2/apps in .* \(retry 1 of 2\): 502 Bad Gateway`))
+		})
+	})
+
+	Describe("recording HAR traces", func() {
+		var (
+			tmpDir  string
+			harPath string
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "gateway-har")
+			Expect(err).NotTo(HaveOccurred())
+			harPath = filepath.Join(tmpDir, "trace.har")
+
+			ccServer = ghttp.NewServer()
+			ccServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyJSON(`{"name":"my-app"}`),
+				ghttp.RespondWith(http.StatusCreated, `{"metadata":{"guid":"app-guid"}}`),
+			))
+			config.SetAPIEndpoint(ccServer.URL())
+
+			recorder, err := trace.NewHARRecorder(harPath)
+			Expect(err).NotTo(HaveOccurred())
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, trace.CombinePrinters([]trace.Printer{recorder}))
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+			os.RemoveAll(tmpDir)
+		})
+
+		It("records the request and the response", func() {
+			request, _ := ccGateway.NewRequest("POST", config.APIEndpoint()+"/v2/apps", "bearer my-token", strings.NewReader(`{"name":"my-app"}`))
+			_, err := ccGateway.PerformRequest(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			har, err := trace.ReadHAR(harPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(har.Log.Entries).To(HaveLen(1))
+
+			entry := har.Log.Entries[0]
+			Expect(entry.Request.URL).To(Equal(ccServer.URL() + "/v2/apps"))
+			Expect(entry.Request.PostData.Text).To(Equal(`{"name":"my-app"}`))
+			Expect(entry.Request.Headers).To(ContainElement(trace.HARNameValue{Name: "Authorization", Value: "[PRIVATE DATA HIDDEN]"}))
+			Expect(entry.Response.Status).To(Equal(http.StatusCreated))
+			Expect(entry.Response.Content.Text).To(Equal(`{"metadata":{"guid":"app-guid"}}`))
+		})
+	})
+
+	Describe("proxying requests", func() {
+		var proxyServer *ghttp.Server
+
+		BeforeEach(func() {
+			proxyServer = ghttp.NewServer()
+			proxyServer.AppendHandlers(ghttp.CombineHandlers(
+				func(writer http.ResponseWriter, request *http.Request) {
+					Expect(request.URL.String()).To(Equal("http://api.example.com/v2/apps"))
+				},
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+			config.SetProxy(proxyServer.URL())
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
+		})
+
+		AfterEach(func() {
+			proxyServer.Close()
+		})
+
+		It("sends requests through the proxy from the config", func() {
+			request, _ := ccGateway.NewRequest("GET", "http://api.example.com/v2/apps", config.AccessToken(), nil)
+			_, err := ccGateway.PerformRequest(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proxyServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("limiting requests", func() {
+		var logger *tracefakes.FakePrinter
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			config.SetAPIEndpoint(ccServer.URL())
+			logger = new(tracefakes.FakePrinter)
+		})
+
+		JustBeforeEach(func() {
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, logger)
+			ccGateway.RetryBackoff = time.Millisecond
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		Context("when a rate limit is configured", func() {
+			BeforeEach(func() {
+				config.SetRequestRateLimit(5)
+				ccServer.RouteToHandler("GET", "/v2/apps", ghttp.RespondWith(http.StatusOK, `{}`))
+			})
+
+			It("spaces out requests beyond the burst", func() {
+				started := time.Now()
+				for i := 0; i < 7; i++ {
+					request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps", config.AccessToken(), nil)
+					_, err := ccGateway.PerformRequest(request)
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(time.Since(started)).To(BeNumerically(">=", 300*time.Millisecond))
+			})
+		})
+
+		Context("when a maximum number of concurrent requests is configured", func() {
+			var (
+				mutex       sync.Mutex
+				inFlight    int
+				maxInFlight int
+			)
+
+			BeforeEach(func() {
+				config.SetMaxConcurrentRequests(2)
+				inFlight, maxInFlight = 0, 0
+				ccServer.RouteToHandler("GET", "/v2/apps", func(w http.ResponseWriter, r *http.Request) {
+					mutex.Lock()
+					inFlight++
+					if inFlight > maxInFlight {
+						maxInFlight = inFlight
+					}
+					mutex.Unlock()
+
+					time.Sleep(20 * time.Millisecond)
+
+					mutex.Lock()
+					inFlight--
+					mutex.Unlock()
+				})
+			})
+
+			It("does not exceed the maximum", func() {
+				var wg sync.WaitGroup
+				for i := 0; i < 6; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/apps", config.AccessToken(), nil)
+						_, err := ccGateway.PerformRequest(request)
+						Expect(err).NotTo(HaveOccurred())
+					}()
+				}
+				wg.Wait()
+
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(6))
+				Expect(maxInFlight).To(Equal(2))
+			})
+		})
+
+		Context("when the server responds with 429", func() {
+			BeforeEach(func() {
+				ccServer.AppendHandlers(
+					ghttp.RespondWith(http.StatusTooManyRequests, "slow down", http.Header{"Retry-After": []string{"0"}}),
+					ghttp.RespondWith(http.StatusTooManyRequests, "slow down", http.Header{"Retry-After": []string{"0"}}),
+					ghttp.RespondWith(http.StatusCreated, `{}`),
+				)
+			})
+
+			It("retries the request and slows down for the rest of the run", func() {
+				request, _ := ccGateway.NewRequest("POST", config.APIEndpoint()+"/v2/apps", config.AccessToken(), strings.NewReader("{}"))
+				_, err := ccGateway.PerformRequest(request)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(3))
+				Expect(tracedOutput(logger)).To(MatchRegexp(`(?s)RATE LIMITED:.*slowing down to 10 requests per second.*RATE LIMITED:.*slowing down to 5 requests per second`))
+			})
+		})
+	})
+
+	Describe("listing paginated resources", func() {
+		type listedApp struct {
+			Entity struct {
+				Name string
+			}
+		}
+
+		var (
+			mutex    sync.Mutex
+			inFlight int
+			maxCalls int
+			requests []string
+		)
+
+		page := func(number, totalPages int) http.HandlerFunc {
+			return func(writer http.ResponseWriter, request *http.Request) {
+				mutex.Lock()
+				requests = append(requests, request.URL.RequestURI())
+				inFlight++
+				if inFlight > maxCalls {
+					maxCalls = inFlight
+				}
+				mutex.Unlock()
+
+				// later pages answer first, so responses arrive out of order
+				time.Sleep(time.Duration(totalPages-number) * 10 * time.Millisecond)
+
+				nextURL := ""
+				if number < totalPages {
+					nextURL = fmt.Sprintf("/v2/apps?order-direction=asc&page=%d&results-per-page=2", number+1)
+				}
+				fmt.Fprintf(writer, `{"total_pages":%d,"next_url":"%s","resources":[{"entity":{"name":"app-%d-a"}},{"entity":{"name":"app-%d-b"}}]}`,
+					totalPages, nextURL, number, number)
+
+				mutex.Lock()
+				inFlight--
+				mutex.Unlock()
+			}
+		}
+
+		listApps := func(limit int) ([]string, error) {
+			names := []string{}
+			err := ccGateway.ListPaginatedResources(config.APIEndpoint(), "/v2/apps?order-direction=asc&results-per-page=2", listedApp{}, func(resource interface{}) bool {
+				names = append(names, resource.(listedApp).Entity.Name)
+				return len(names) < limit
+			})
+			return names, err
+		}
+
+		BeforeEach(func() {
+			inFlight, maxCalls, requests = 0, 0, []string{}
+
+			ccServer = ghttp.NewServer()
+			ccServer.AllowUnhandledRequests = true
+			config.SetAPIEndpoint(ccServer.URL())
+
+			ccServer.RouteToHandler("GET", "/v2/apps", func(writer http.ResponseWriter, request *http.Request) {
+				number := 1
+				fmt.Sscan(request.URL.Query().Get("page"), &number)
+				page(number, 6)(writer, request)
+			})
+
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
+			ccGateway.PageConcurrency = 3
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		It("fetches the pages after the first at the same time", func() {
+			_, err := listApps(100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(6))
+			Expect(maxCalls).To(Equal(3))
+			Expect(requests).To(ContainElement("/v2/apps?order-direction=asc&page=6&results-per-page=2"))
+		})
+
+		It("passes the resources to the callback in order", func() {
+			names, err := listApps(100)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{
+				"app-1-a", "app-1-b", "app-2-a", "app-2-b", "app-3-a", "app-3-b",
+				"app-4-a", "app-4-b", "app-5-a", "app-5-b", "app-6-a", "app-6-b",
+			}))
+		})
+
+		It("stops fetching pages once the callback returns false", func() {
+			names, err := listApps(3)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal([]string{"app-1-a", "app-1-b", "app-2-a"}))
+			Expect(len(requests)).To(BeNumerically("<=", 5))
+		})
+
+		It("returns the error of a page that could not be fetched", func() {
+			ccServer.RouteToHandler("GET", "/v2/apps", func(writer http.ResponseWriter, request *http.Request) {
+				number := 1
+				fmt.Sscan(request.URL.Query().Get("page"), &number)
+				if number == 4 {
+					writer.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(writer, `{"code":10000,"description":"page not found","error_code":"CF-NotFound"}`)
+					return
+				}
+				page(number, 6)(writer, request)
+			})
+
+			names, err := listApps(100)
+			Expect(err).To(HaveOccurred())
+			Expect(names).To(HaveLen(6))
+		})
+
+		Context("when only one page is fetched at a time", func() {
+			BeforeEach(func() {
+				ccGateway.PageConcurrency = 1
+			})
+
+			It("follows next_url", func() {
+				names, err := listApps(100)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names).To(HaveLen(12))
+				Expect(maxCalls).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("caching lists", func() {
+		type listedOrg struct {
+			Entity struct {
+				Name string
+			}
+		}
+
+		var (
+			cacheDir string
+			names    []string
+		)
+
+		login := func(tokenInfo coreconfig.TokenInfo) {
+			accessToken, err := testconfig.EncodeAccessToken(tokenInfo)
+			Expect(err).NotTo(HaveOccurred())
+			config.SetAccessToken(accessToken)
+		}
+
+		listOrgs := func() {
+			names = []string{}
+			err := ccGateway.ListPaginatedResources(config.APIEndpoint(), "/v2/organizations", listedOrg{}, func(resource interface{}) bool {
+				names = append(names, resource.(listedOrg).Entity.Name)
+				return true
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			var err error
+			cacheDir, err = ioutil.TempDir("", "response-cache")
+			Expect(err).NotTo(HaveOccurred())
+
+			ccServer = ghttp.NewServer()
+			config.SetAPIEndpoint(ccServer.URL())
+			config.SetCacheTTL(60)
+			login(coreconfig.TokenInfo{UserGUID: "user-1"})
+
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"resources":[{"entity":{"name":"org-1"}}]}`, http.Header{"ETag": []string{`"v1"`}}))
+		})
+
+		JustBeforeEach(func() {
+			ccGateway = NewCloudControllerGateway(config, clock, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
+			ccGateway.ResponseCache = NewResponseCache(cacheDir, config)
+			listOrgs()
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+			os.RemoveAll(cacheDir)
+		})
+
+		It("answers from the cache while the entry is fresh", func() {
+			currentTime = currentTime.Add(59 * time.Second)
+			listOrgs()
+
+			Expect(names).To(Equal([]string{"org-1"}))
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("revalidates a stale entry with its ETag", func() {
+			ccServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyHeader(http.Header{"If-None-Match": []string{`"v1"`}}),
+				ghttp.RespondWith(http.StatusNotModified, ""),
+			))
+
+			currentTime = currentTime.Add(61 * time.Second)
+			listOrgs()
+			Expect(names).To(Equal([]string{"org-1"}))
+
+			By("treating the revalidated entry as fresh again")
+			listOrgs()
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("replaces a stale entry that has changed", func() {
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"resources":[{"entity":{"name":"org-2"}}]}`, http.Header{"ETag": []string{`"v2"`}}))
+
+			currentTime = currentTime.Add(61 * time.Second)
+			listOrgs()
+			Expect(names).To(Equal([]string{"org-2"}))
+		})
+
+		It("forgets the cache when a request changes anything", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusCreated, `{}`),
+				ghttp.RespondWith(http.StatusOK, `{"resources":[{"entity":{"name":"org-2"}}]}`),
+			)
+
+			Expect(ccGateway.CreateResource(config.APIEndpoint(), "/v2/organizations", strings.NewReader(`{"name":"org-2"}`))).To(Succeed())
+			listOrgs()
+			Expect(names).To(Equal([]string{"org-2"}))
+		})
+
+		It("keeps the lists of different users apart", func() {
+			ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"resources":[]}`))
+
+			login(coreconfig.TokenInfo{UserGUID: "user-2"})
+			listOrgs()
+			Expect(names).To(BeEmpty())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		Context("when logged in as a client", func() {
+			BeforeEach(func() {
+				login(coreconfig.TokenInfo{ClientID: "client-1"})
+			})
+
+			It("keeps the lists of different clients apart", func() {
+				ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"resources":[]}`))
+
+				login(coreconfig.TokenInfo{ClientID: "client-2"})
+				listOrgs()
+				Expect(names).To(BeEmpty())
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		It("does not cache lists that hold credentials", func() {
+			ccServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"resources":[]}`),
+				ghttp.RespondWith(http.StatusOK, `{"resources":[]}`),
+				ghttp.RespondWith(http.StatusOK, `{"resources":[]}`),
+			)
+
+			for _, path := range []string{"/v2/service_keys", "/v2/service_keys", "/v2/spaces/space-guid/service_instances?return_user_provided_service_instances=true"} {
+				err := ccGateway.ListPaginatedResources(config.APIEndpoint(), path, listedOrg{}, func(interface{}) bool { return true })
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(4))
+			files, err := ioutil.ReadDir(cacheDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		Context("when the cache is turned off", func() {
+			BeforeEach(func() {
+				config.SetCacheTTL(0)
+				ccServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{"resources":[]}`))
+			})
+
+			It("fetches every list", func() {
+				listOrgs()
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+	})
+
 	Describe("CRUD methods", func() {
 		Describe("Delete", func() {
 			var apiServer *httptest.Server
@@ -371,6 +955,75 @@ var _ = Describe("Gateway", func() {
 			Expect(apiErr).To(HaveOccurred())
 			Expect(apiErr).To(BeAssignableToTypeOf(errors.NewAsyncTimeoutError("http://some.url")))
 		})
+
+		Context("when stdout is a terminal", func() {
+			var originalStdoutIsTerminal bool
+
+			BeforeEach(func() {
+				originalStdoutIsTerminal = terminal.StdoutIsTerminal
+				terminal.StdoutIsTerminal = true
+			})
+
+			AfterEach(func() {
+				terminal.StdoutIsTerminal = originalStdoutIsTerminal
+			})
+
+			It("shows the status of the job and how long it has been running while it waits", func() {
+				ui := &testterm.FakeUI{}
+				ccGateway = NewCloudControllerGateway(config, clock, ui, new(tracefakes.FakePrinter))
+				ccGateway.PollingThrottle = 3 * time.Millisecond
+				ccGateway.SetTokenRefresher(authRepo)
+				ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+				go func() {
+					statusChannel <- "queued"
+					statusChannel <- "running"
+					statusChannel <- "finished"
+				}()
+
+				request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/foo", config.AccessToken(), nil)
+				_, apiErr := ccGateway.PerformPollingRequestForJSONResponse(config.APIEndpoint(), request, new(struct{}), 500*time.Millisecond)
+				Expect(apiErr).NotTo(HaveOccurred())
+				Expect(ui.UncapturedOutput).To(ContainSubstrings(
+					[]string{"Job running", "elapsed"},
+				))
+
+				progress := ui.UncapturedOutput[len(ui.UncapturedOutput)-2]
+				Expect(ui.UncapturedOutput[len(ui.UncapturedOutput)-1]).To(Equal("\r" + strings.Repeat(" ", len(progress)-1) + "\r"))
+			})
+		})
+
+		It("does not show the progress of the job when stdout is not a terminal", func() {
+			originalStdoutIsTerminal := terminal.StdoutIsTerminal
+			terminal.StdoutIsTerminal = false
+			defer func() { terminal.StdoutIsTerminal = originalStdoutIsTerminal }()
+
+			ui := &testterm.FakeUI{}
+			ccGateway = NewCloudControllerGateway(config, clock, ui, new(tracefakes.FakePrinter))
+			ccGateway.PollingThrottle = 3 * time.Millisecond
+			ccGateway.SetTokenRefresher(authRepo)
+			ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+			go func() {
+				statusChannel <- "queued"
+				statusChannel <- "finished"
+			}()
+
+			request, _ := ccGateway.NewRequest("GET", config.APIEndpoint()+"/v2/foo", config.AccessToken(), nil)
+			_, apiErr := ccGateway.PerformPollingRequestForJSONResponse(config.APIEndpoint(), request, new(struct{}), 500*time.Millisecond)
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(ui.UncapturedOutput).To(BeEmpty())
+		})
+
+		It("returns the job without waiting for it when deleting without waiting", func() {
+			go func() {
+				statusChannel <- "queued"
+			}()
+
+			jobURL, apiErr := ccGateway.DeleteResourceWithoutWaiting(config.APIEndpoint(), "/v2/foo")
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(jobURL).To(Equal("/v2/jobs/the-job-guid"))
+		})
 	})
 
 	Describe("when uploading a file", func() {
@@ -515,6 +1168,40 @@ var _ = Describe("Gateway", func() {
 			Expect(apiErr).To(HaveOccurred())
 			Expect(apiErr.(errors.HTTPError).ErrorCode()).To(Equal("333"))
 		})
+
+		It("refreshes the token once for requests that fail at the same time", func() {
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "bearer new-access-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					fmt.Fprint(w, `{ "code": 1000, "description": "Auth token is invalid" }`)
+				}
+			}))
+			defer apiServer.Close()
+
+			config := testconfig.NewRepository()
+			config.SetAPIEndpoint(apiServer.URL)
+			config.SetAccessToken("bearer initial-access-token")
+			refresher := &slowTokenRefresher{config: config}
+			gateway := NewCloudControllerGateway(config, time.Now, &testterm.FakeUI{}, new(tracefakes.FakePrinter))
+			gateway.SetTokenRefresher(refresher)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					request, err := gateway.NewRequest("GET", apiServer.URL+"/v2/foo", config.AccessToken(), nil)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = gateway.PerformRequest(request)
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+
+			Expect(refresher.calls).To(Equal(1))
+		})
 	})
 
 	Describe("SSL certificate validation errors", func() {
@@ -584,6 +1271,86 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("CA bundle and client certificate", func() {
+		var (
+			apiServer  *httptest.Server
+			tmpDir     string
+			caCertFile string
+			request    *Request
+		)
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "gateway-tls")
+			Expect(err).NotTo(HaveOccurred())
+
+			serverCert := testnet.MakeValidTLSCert()
+			serverDir := filepath.Join(tmpDir, "server")
+			Expect(os.Mkdir(serverDir, 0700)).To(Succeed())
+			caCertFile, _ = testnet.WriteTLSCertFiles(serverCert, serverDir)
+
+			clientCert := testnet.MakeValidTLSCert()
+			clientDir := filepath.Join(tmpDir, "client")
+			Expect(os.Mkdir(clientDir, 0700)).To(Succeed())
+			clientCertFile, clientKeyFile := testnet.WriteTLSCertFiles(clientCert, clientDir)
+			clientCAs := x509.NewCertPool()
+			clientCAs.AppendCertsFromPEM(mustReadFile(clientCertFile))
+
+			apiServer = httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				fmt.Fprintln(writer, `{}`)
+			}))
+			apiServer.TLS = &tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				ClientCAs:    clientCAs,
+			}
+			apiServer.StartTLS()
+
+			config.SetCACertFile(caCertFile)
+			config.SetClientCertFile(clientCertFile)
+			config.SetClientKeyFile(clientKeyFile)
+
+			request, _ = ccGateway.NewRequest("GET", apiServer.URL+"/v2/foo", "the-access-token", nil)
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+			os.RemoveAll(tmpDir)
+		})
+
+		It("trusts the CA bundle and presents the client certificate", func() {
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).NotTo(HaveOccurred())
+		})
+
+		It("fails when no client certificate is configured", func() {
+			config.SetClientCertFile("")
+			config.SetClientKeyFile("")
+
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+		})
+
+		It("fails when the server's CA is not in the bundle", func() {
+			otherDir := filepath.Join(tmpDir, "other")
+			Expect(os.Mkdir(otherDir, 0700)).To(Succeed())
+			otherCAFile, _ := testnet.WriteTLSCertFiles(testnet.MakeValidTLSCert(), otherDir)
+			config.SetCACertFile(otherCAFile)
+
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(apiErr.Error()).To(ContainSubstring("unknown authority"))
+		})
+
+		It("returns an error when the CA bundle cannot be read", func() {
+			config.SetCACertFile(filepath.Join(tmpDir, "missing.pem"))
+
+			_, apiErr := ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(apiErr.Error()).To(ContainSubstring("Error reading CA certificate bundle"))
+		})
+	})
+
 	Describe("collecting warnings", func() {
 		var (
 			apiServer  *httptest.Server
@@ -673,6 +1440,20 @@ func refreshTokenAPIEndPoint(unauthorizedBody string, secondReqResp testnet.Test
 	}
 }
 
+// slowTokenRefresher takes a while to store a new token in the config, so
+// that requests failing at the same time wait for it
+type slowTokenRefresher struct {
+	config coreconfig.ReadWriter
+	calls  int
+}
+
+func (refresher *slowTokenRefresher) RefreshAuthToken() (string, error) {
+	refresher.calls++
+	time.Sleep(50 * time.Millisecond)
+	refresher.config.SetAccessToken("bearer new-access-token")
+	return refresher.config.AccessToken(), nil
+}
+
 func createAuthenticationRepository(apiServer *httptest.Server, authServer *httptest.Server) (coreconfig.ReadWriter, authentication.Repository) {
 	config := testconfig.NewRepository()
 	config.SetAuthenticationEndpoint(authServer.URL)
@@ -689,3 +1470,18 @@ func createAuthenticationRepository(apiServer *httptest.Server, authServer *http
 
 	return config, authenticator
 }
+
+func tracedOutput(logger *tracefakes.FakePrinter) string {
+	traced := []string{}
+	for i := 0; i < logger.PrintfCallCount(); i++ {
+		format, args := logger.PrintfArgsForCall(i)
+		traced = append(traced, fmt.Sprintf(format, args...))
+	}
+	return strings.Join(traced, "")
+}
+
+func mustReadFile(path string) []byte {
+	contents, err := ioutil.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	return contents
+}
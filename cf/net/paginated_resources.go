@@ -2,7 +2,10 @@ package net
 
 import (
 	"encoding/json"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 )
 
 func NewPaginatedResources(exampleResource interface{}) PaginatedResources {
@@ -13,6 +16,7 @@ func NewPaginatedResources(exampleResource interface{}) PaginatedResources {
 
 type PaginatedResources struct {
 	NextURL        string          `json:"next_url"`
+	TotalPages     int             `json:"total_pages"`
 	ResourcesBytes json.RawMessage `json:"resources"`
 	resourceType   reflect.Type
 }
@@ -28,3 +32,41 @@ func (pr PaginatedResources) Resources() ([]interface{}, error) {
 	}
 	return contents, err
 }
+
+// RemainingPageURLs works out the URLs of the pages after this one from its
+// next_url and the total number of pages. It returns false when the list does
+// not number its pages, in which case next_url has to be followed.
+func (pr PaginatedResources) RemainingPageURLs() ([]string, bool) {
+	if pr.NextURL == "" || pr.TotalPages < 2 {
+		return nil, false
+	}
+
+	next, err := url.Parse(pr.NextURL)
+	if err != nil {
+		return nil, false
+	}
+
+	params := strings.Split(next.RawQuery, "&")
+	pageParam := -1
+	for i, param := range params {
+		if strings.HasPrefix(param, "page=") {
+			pageParam = i
+		}
+	}
+	if pageParam < 0 {
+		return nil, false
+	}
+
+	nextPage, err := strconv.Atoi(strings.TrimPrefix(params[pageParam], "page="))
+	if err != nil || nextPage < 2 {
+		return nil, false
+	}
+
+	urls := []string{}
+	for page := nextPage; page <= pr.TotalPages; page++ {
+		params[pageParam] = "page=" + strconv.Itoa(page)
+		next.RawQuery = strings.Join(params, "&")
+		urls = append(urls, next.String())
+	}
+	return urls, true
+}
@@ -0,0 +1,477 @@
+package net
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const proxyDialTimeout = 5 * time.Second
+
+type ProxyFunc func(*http.Request) (*url.URL, error)
+
+// ProxyFromConfig returns the proxy to use for a request. The proxy set with
+// `cf config --proxy` takes precedence over the https_proxy and http_proxy
+// environment variables; no_proxy is honoured in both cases.
+func ProxyFromConfig(config coreconfig.Reader) ProxyFunc {
+	return func(request *http.Request) (*url.URL, error) {
+		var proxyURL *url.URL
+		var err error
+
+		if override := config.Proxy(); override != "" {
+			if !useProxy(request.URL) {
+				return nil, nil
+			}
+			proxyURL, err = ParseProxyURL(override)
+		} else {
+			proxyURL, err = http.ProxyFromEnvironment(request)
+		}
+
+		if err != nil || proxyURL == nil {
+			return proxyURL, err
+		}
+
+		if proxyURL.Scheme == "socks5h" {
+			// http.Transport always lets a SOCKS proxy resolve host names
+			copied := *proxyURL
+			copied.Scheme = "socks5"
+			proxyURL = &copied
+		}
+		return proxyURL, nil
+	}
+}
+
+// ParseProxyURL parses an http, https, socks5 or socks5h proxy URL, assuming
+// http when no scheme is given
+func ParseProxyURL(proxy string) (*url.URL, error) {
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		return nil, errors.New(T("Invalid proxy URL '{{.Proxy}}'", map[string]interface{}{"Proxy": proxy}))
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return proxyURL, nil
+	default:
+		return nil, errors.New(T("Unsupported proxy scheme '{{.Scheme}}'. Use http, https, socks5 or socks5h.", map[string]interface{}{"Scheme": proxyURL.Scheme}))
+	}
+}
+
+// NewProxyDialer returns a function that opens TCP connections, going
+// through the proxy configured for the address when there is one. It is used
+// for connections that are not made by an http.Transport, such as SSH.
+func NewProxyDialer(config coreconfig.Reader) func(network, addr string) (net.Conn, error) {
+	proxy := ProxyFromConfig(config)
+
+	return func(network, addr string) (net.Conn, error) {
+		proxyURL, err := proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: addr}})
+		if err != nil {
+			return nil, err
+		}
+
+		if proxyURL == nil {
+			return net.DialTimeout(network, addr, proxyDialTimeout)
+		}
+		return DialThroughProxy(proxyURL, network, addr)
+	}
+}
+
+// DialThroughProxy connects to addr through an HTTP CONNECT or SOCKS5 proxy
+func DialThroughProxy(proxyURL *url.URL, network, addr string) (net.Conn, error) {
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		switch proxyURL.Scheme {
+		case "https":
+			proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "443")
+		case "socks5", "socks5h":
+			proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "1080")
+		default:
+			proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+		}
+	}
+
+	conn, err := net.DialTimeout(network, proxyAddr, proxyDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+	}
+
+	tunnel := conn
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		err = socks5Connect(conn, proxyURL.User, addr)
+	default:
+		tunnel, err = httpConnect(conn, proxyURL.User, addr)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s %s: %s", T("Error connecting through proxy"), proxyURL.Host, err.Error())
+	}
+
+	return tunnel, nil
+}
+
+func httpConnect(conn net.Conn, user *url.Userinfo, addr string) (net.Conn, error) {
+	request := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	err := request.Write(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, request)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(response.Status)
+	}
+
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// socks5Connect performs the RFC 1928 handshake, authenticating with a
+// username and password (RFC 1929) when the proxy URL has them
+func socks5Connect(conn net.Conn, user *url.Userinfo, addr string) error {
+	host, portString, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return err
+	}
+
+	method := byte(0x00)
+	if user != nil {
+		method = 0x02
+	}
+
+	_, err = conn.Write([]byte{0x05, 0x01, method})
+	if err != nil {
+		return err
+	}
+
+	reply := make([]byte, 2)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		return err
+	}
+	if reply[0] != 0x05 || reply[1] != method {
+		return errors.New(T("SOCKS proxy does not support the authentication method"))
+	}
+
+	if method == 0x02 {
+		password, _ := user.Password()
+		auth := []byte{0x01, byte(len(user.Username()))}
+		auth = append(auth, user.Username()...)
+		auth = append(auth, byte(len(password)))
+		auth = append(auth, password...)
+
+		_, err = conn.Write(auth)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.ReadFull(conn, reply)
+		if err != nil {
+			return err
+		}
+		if reply[1] != 0x00 {
+			return errors.New(T("SOCKS proxy authentication failed"))
+		}
+	}
+
+	request := []byte{0x05, 0x01, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		request = append(request, 0x03, byte(len(host)))
+		request = append(request, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		request = append(request, 0x01)
+		request = append(request, ip4...)
+	} else {
+		request = append(request, 0x04)
+		request = append(request, ip.To16()...)
+	}
+	request = append(request, 0, 0)
+	binary.BigEndian.PutUint16(request[len(request)-2:], uint16(port))
+
+	_, err = conn.Write(request)
+	if err != nil {
+		return err
+	}
+
+	header := make([]byte, 4)
+	_, err = io.ReadFull(conn, header)
+	if err != nil {
+		return err
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("%s (%d)", T("SOCKS proxy refused the connection"), header[1])
+	}
+
+	var boundAddrLen int
+	switch header[3] {
+	case 0x01:
+		boundAddrLen = net.IPv4len
+	case 0x04:
+		boundAddrLen = net.IPv6len
+	case 0x03:
+		length := make([]byte, 1)
+		_, err = io.ReadFull(conn, length)
+		if err != nil {
+			return err
+		}
+		boundAddrLen = int(length[0])
+	default:
+		return errors.New(T("Invalid response from SOCKS proxy"))
+	}
+
+	_, err = io.ReadFull(conn, make([]byte, boundAddrLen+2))
+	return err
+}
+
+// useProxy reports whether requests to target should go through the proxy
+// set in the config, according to the no_proxy environment variable
+func useProxy(target *url.URL) bool {
+	hostname := strings.ToLower(target.Hostname())
+	port := target.Port()
+	if port == "" {
+		switch target.Scheme {
+		case "http", "ws":
+			port = "80"
+		default:
+			port = "443"
+		}
+	}
+
+	if hostname == "localhost" {
+		return false
+	}
+	ip := net.ParseIP(hostname)
+	if ip != nil && ip.IsLoopback() {
+		return false
+	}
+
+	noProxy := os.Getenv("NO_PROXY")
+	if noProxy == "" {
+		noProxy = os.Getenv("no_proxy")
+	}
+
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return false
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return false
+			}
+			continue
+		}
+
+		if entryHost, entryPort, err := net.SplitHostPort(entry); err == nil {
+			if entryPort != port {
+				continue
+			}
+			entry = entryHost
+		}
+
+		entry = strings.TrimPrefix(entry, "*")
+		if hostname == strings.TrimPrefix(entry, ".") || strings.HasSuffix(hostname, "."+strings.TrimPrefix(entry, ".")) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WebsocketProxy is the proxy function of a doppler or loggregator consumer.
+// The consumers only speak HTTP CONNECT without credentials, so when a proxy
+// is needed they are pointed at a local relay that connects through it with
+// DialThroughProxy. The relay only connects to the endpoint of the consumer,
+// and stops listening on Close.
+type WebsocketProxy struct {
+	proxy  ProxyFunc
+	scheme string
+	target string
+
+	mutex    sync.Mutex
+	listener net.Listener
+}
+
+func NewWebsocketProxy(config coreconfig.Reader, endpoint string) *WebsocketProxy {
+	websocketProxy := &WebsocketProxy{proxy: ProxyFromConfig(config), scheme: "https"}
+	if endpointURL, err := url.Parse(endpoint); err == nil {
+		if endpointURL.Scheme == "ws" || endpointURL.Scheme == "http" {
+			websocketProxy.scheme = "http"
+		}
+		websocketProxy.target = hostWithPort(endpointURL)
+	}
+	return websocketProxy
+}
+
+// Proxy returns the address of the relay for requests to the endpoint, or
+// nil when they do not go through a proxy
+func (p *WebsocketProxy) Proxy(request *http.Request) (*url.URL, error) {
+	// the consumers ask about http:// URLs, but the traffic is TLS unless
+	// the endpoint is a ws:// one
+	target := *request.URL
+	target.Scheme = p.scheme
+
+	proxyURL, err := p.proxy(&http.Request{URL: &target})
+	if err != nil || proxyURL == nil {
+		return nil, err
+	}
+
+	if hostWithPort(&target) != p.target {
+		return nil, errors.New(T("Cannot connect to {{.Host}} through the proxy of {{.Endpoint}}",
+			map[string]interface{}{"Host": target.Host, "Endpoint": p.target}))
+	}
+
+	relayAddr, err := p.startRelay(proxyURL)
+	if err != nil {
+		return nil, err
+	}
+	return &url.URL{Scheme: "http", Host: relayAddr}, nil
+}
+
+// Close stops the relay. A later request starts a new one.
+func (p *WebsocketProxy) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.listener == nil {
+		return nil
+	}
+	err := p.listener.Close()
+	p.listener = nil
+	return err
+}
+
+func (p *WebsocketProxy) startRelay(proxyURL *url.URL) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.listener != nil {
+		return p.listener.Addr().String(), nil
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go relayConnection(conn, proxyURL, p.target)
+		}
+	}()
+
+	p.listener = listener
+	return listener.Addr().String(), nil
+}
+
+func relayConnection(conn net.Conn, proxyURL *url.URL, target string) {
+	defer conn.Close()
+
+	request, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil || request.Method != "CONNECT" {
+		return
+	}
+
+	if strings.ToLower(request.Host) != target {
+		fmt.Fprintf(conn, "HTTP/1.1 403 Forbidden\r\n\r\n")
+		return
+	}
+
+	upstream, err := DialThroughProxy(proxyURL, "tcp", request.Host)
+	if err != nil {
+		fmt.Fprintf(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer upstream.Close()
+
+	_, err = fmt.Fprintf(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+	if err != nil {
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func hostWithPort(target *url.URL) string {
+	if target.Port() != "" {
+		return strings.ToLower(target.Host)
+	}
+
+	port := "443"
+	if target.Scheme == "http" || target.Scheme == "ws" {
+		port = "80"
+	}
+	return net.JoinHostPort(strings.ToLower(target.Hostname()), port)
+}
@@ -27,6 +27,10 @@ func NewRequestDumper(printer trace.Printer) RequestDumper {
 }
 
 func (p RequestDumper) DumpRequest(req *http.Request) {
+	if recorder, ok := p.printer.(trace.ExchangeRecorder); ok {
+		recorder.RecordRequest(req)
+	}
+
 	shouldDisplayBody := !strings.Contains(req.Header.Get("Content-Type"), "multipart/form-data")
 	dumpedRequest, err := httputil.DumpRequest(req, shouldDisplayBody)
 	if err != nil {
@@ -40,6 +44,10 @@ func (p RequestDumper) DumpRequest(req *http.Request) {
 }
 
 func (p RequestDumper) DumpResponse(res *http.Response) {
+	if recorder, ok := p.printer.(trace.ExchangeRecorder); ok {
+		recorder.RecordResponse(res)
+	}
+
 	dumpedResponse, err := httputil.DumpResponse(res, true)
 	if err != nil {
 		p.printer.Printf(T("Error dumping response\n{{.Err}}\n", map[string]interface{}{"Err": err}))
@@ -47,3 +55,11 @@ func (p RequestDumper) DumpResponse(res *http.Response) {
 		p.printer.Printf("\n%s [%s]\n%s\n", terminal.HeaderColor(T("RESPONSE:")), time.Now().Format(time.RFC3339), trace.Sanitize(string(dumpedResponse)))
 	}
 }
+
+// DumpFailure tells the recorder, if there is one, that req got no response
+// because of err
+func (p RequestDumper) DumpFailure(req *http.Request, err error) {
+	if recorder, ok := p.printer.(trace.ExchangeRecorder); ok {
+		recorder.RecordFailure(req, err)
+	}
+}
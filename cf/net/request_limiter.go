@@ -0,0 +1,119 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+)
+
+const (
+	// throttledRequestRate is the rate a gateway without a configured rate
+	// limit falls back to the first time the server responds with 429
+	throttledRequestRate = 10.0
+	minRequestRate       = 0.5
+)
+
+// requestLimiter holds back requests so that no more than the configured
+// number are in flight at once and, using a token bucket, no more than the
+// configured number are started each second. It is shared by all copies of
+// a Gateway.
+type requestLimiter struct {
+	config   coreconfig.Reader
+	initOnce sync.Once
+
+	slots chan struct{}
+
+	mutex      sync.Mutex
+	rate       float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newRequestLimiter(config coreconfig.Reader) *requestLimiter {
+	return &requestLimiter{config: config}
+}
+
+func (l *requestLimiter) init() {
+	l.initOnce.Do(func() {
+		if max := l.config.MaxConcurrentRequests(); max > 0 {
+			l.slots = make(chan struct{}, max)
+		}
+
+		l.rate = float64(l.config.RequestRateLimit())
+		l.tokens = l.burst()
+		l.lastRefill = time.Now()
+	})
+}
+
+// acquire blocks until a request may be started and returns a function that
+// must be called once the request has completed
+func (l *requestLimiter) acquire() func() {
+	l.init()
+
+	if l.slots != nil {
+		l.slots <- struct{}{}
+	}
+
+	time.Sleep(l.reserve())
+
+	return func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+}
+
+// reserve takes a token from the bucket and returns how long to wait before
+// it becomes valid
+func (l *requestLimiter) reserve() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.rate == 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastRefill).Seconds() * l.rate
+	if l.tokens > l.burst() {
+		l.tokens = l.burst()
+	}
+	l.lastRefill = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// slowDown halves the request rate, or starts limiting it if it was not
+// limited, and returns the new rate
+func (l *requestLimiter) slowDown() float64 {
+	l.init()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.rate == 0 {
+		l.rate = throttledRequestRate
+	} else {
+		l.rate /= 2
+	}
+	if l.rate < minRequestRate {
+		l.rate = minRequestRate
+	}
+	if l.tokens > l.burst() {
+		l.tokens = l.burst()
+	}
+
+	return l.rate
+}
+
+func (l *requestLimiter) burst() float64 {
+	if l.rate < 1 {
+		return 1
+	}
+	return l.rate
+}
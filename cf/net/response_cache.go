@@ -0,0 +1,136 @@
+package net
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+)
+
+// ResponseCache keeps the pages read by ListPaginatedResources on disk so
+// that read-heavy commands run in quick succession do not fetch the same
+// lists again. Entries are kept per API endpoint and user, are used as they
+// are for the number of seconds set with `cf config --cache-ttl`, and are
+// revalidated with their ETag after that. Lists that can hold credentials are
+// never written to disk.
+type ResponseCache struct {
+	dir    string
+	config coreconfig.Reader
+}
+
+type cacheEntry struct {
+	URL      string
+	ETag     string
+	Body     []byte
+	StoredAt time.Time
+}
+
+func NewResponseCache(dir string, config coreconfig.Reader) *ResponseCache {
+	return &ResponseCache{
+		dir:    dir,
+		config: config,
+	}
+}
+
+// uncachedResources are the resources whose lists hold credentials or
+// environment variables
+var uncachedResources = map[string]bool{
+	"apps":                            true,
+	"service_auth_tokens":             true,
+	"service_bindings":                true,
+	"service_keys":                    true,
+	"user_provided_service_instances": true,
+}
+
+// cacheable reports whether the list at rawURL can be kept on disk
+func cacheable(rawURL string) bool {
+	listURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	if listURL.Query().Get("return_user_provided_service_instances") == "true" {
+		return false
+	}
+
+	for _, segment := range strings.Split(listURL.Path, "/") {
+		if uncachedResources[segment] {
+			return false
+		}
+	}
+	return true
+}
+
+func (cache *ResponseCache) enabled() bool {
+	return cache != nil && cache.config.CacheTTL() > 0
+}
+
+func (cache *ResponseCache) ttl() time.Duration {
+	return time.Duration(cache.config.CacheTTL()) * time.Second
+}
+
+// userDir is the directory holding the entries of the current user on the
+// current API endpoint, so that targeting another endpoint or logging in as
+// someone else never serves their lists. Clients authenticated with their
+// own credentials have no user GUID and are told apart by their client id.
+func (cache *ResponseCache) userDir() string {
+	return filepath.Join(cache.dir, hash(cache.config.APIEndpoint()+"\n"+cache.config.UserGUID()+"\n"+cache.config.Username()))
+}
+
+func (cache *ResponseCache) entryPath(url string) string {
+	return filepath.Join(cache.userDir(), hash(url))
+}
+
+func (cache *ResponseCache) get(url string) (cacheEntry, bool) {
+	entry := cacheEntry{}
+
+	data, err := ioutil.ReadFile(cache.entryPath(url))
+	if err != nil {
+		return entry, false
+	}
+
+	err = json.Unmarshal(data, &entry)
+	if err != nil || entry.URL != url {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// put stores an entry; a cache that cannot be written is not an error, the
+// next command will simply fetch the list again
+func (cache *ResponseCache) put(entry cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(cache.userDir(), 0700)
+	if err != nil {
+		return
+	}
+
+	_ = ioutil.WriteFile(cache.entryPath(entry.URL), data, 0600)
+}
+
+// Invalidate forgets every entry of the current user, so that lists are
+// fetched again after a command changes anything
+func (cache *ResponseCache) Invalidate() {
+	if cache == nil {
+		return
+	}
+
+	_ = os.RemoveAll(cache.userDir())
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,156 @@
+package net
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/terminal"
+)
+
+const (
+	DefaultRetryBackoff = 1 * time.Second
+	maxRetryDelay       = 30 * time.Second
+	maxThrottledRetries = 5
+)
+
+var (
+	jitterMutex  sync.Mutex
+	jitterSource = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// doRequestWithRetries performs the request, repeating idempotent requests
+// that failed with a network error or a 5xx response up to the number of
+// retries in the config. Requests rejected with 429 were not processed by the
+// server, so they are repeated whatever their method, and the gateway slows
+// down for the rest of the run.
+func (gateway Gateway) doRequestWithRetries(request *Request) (*http.Response, error) {
+	if gateway.transport == nil {
+		err := makeHTTPTransport(&gateway)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	maxRetries := 0
+	if isIdempotent(request.HTTPReq.Method) {
+		maxRetries = int(gateway.config.RequestRetries())
+	}
+
+	for attempt := 1; ; attempt++ {
+		rawResponse, err := gateway.doRequestAndHandlerError(request)
+
+		retries := maxRetries
+		if isThrottled(rawResponse) {
+			retries = maxThrottledRetries
+			rate := gateway.limiter.slowDown()
+			gateway.logger.Printf("\n%s %s\n", terminal.HeaderColor(T("RATE LIMITED:")),
+				T("slowing down to {{.Rate}} requests per second", map[string]interface{}{"Rate": rate}))
+		} else if !isRetryable(rawResponse, err) {
+			return rawResponse, err
+		}
+
+		if attempt > retries {
+			return rawResponse, err
+		}
+
+		delay := gateway.retryDelay(attempt, rawResponse)
+
+		reason := ""
+		if rawResponse != nil {
+			reason = rawResponse.Status
+		} else {
+			reason = err.Error()
+		}
+		gateway.logger.Printf("\n%s %s\n", terminal.HeaderColor(T("RETRYING:")),
+			T("{{.Method}} {{.URL}} in {{.Delay}} (retry {{.Attempt}} of {{.MaxRetries}}): {{.Reason}}",
+				map[string]interface{}{
+					"Method":     request.HTTPReq.Method,
+					"URL":        request.HTTPReq.URL.String(),
+					"Delay":      delay,
+					"Attempt":    attempt,
+					"MaxRetries": retries,
+					"Reason":     reason,
+				}))
+
+		if request.SeekableBody != nil {
+			_, _ = request.SeekableBody.Seek(0, 0)
+			request.HTTPReq.Body = ioutil.NopCloser(request.SeekableBody)
+		}
+
+		time.Sleep(delay)
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+func isRetryable(rawResponse *http.Response, err error) bool {
+	if rawResponse == nil {
+		_, invalidCert := err.(*errors.InvalidSSLCert)
+		return err != nil && !invalidCert
+	}
+	return rawResponse.StatusCode >= 500
+}
+
+func isThrottled(rawResponse *http.Response) bool {
+	return rawResponse != nil && rawResponse.StatusCode == http.StatusTooManyRequests
+}
+
+// retryDelay honours a Retry-After header on the response, and otherwise
+// doubles the backoff for every attempt with up to half of it randomised so
+// that parallel clients do not retry in lockstep
+func (gateway Gateway) retryDelay(attempt int, rawResponse *http.Response) time.Duration {
+	if rawResponse != nil {
+		if delay, ok := gateway.parseRetryAfter(rawResponse.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+
+	backoff := gateway.RetryBackoff
+	for i := 1; i < attempt && backoff < maxRetryDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+
+	half := int64(backoff / 2)
+
+	jitterMutex.Lock()
+	defer jitterMutex.Unlock()
+	return time.Duration(half + jitterSource.Int63n(half+1))
+}
+
+func (gateway Gateway) parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		delay = date.Sub(gateway.Clock())
+	} else {
+		return 0, false
+	}
+
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay, true
+}
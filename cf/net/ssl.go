@@ -3,6 +3,12 @@ package net
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/errors"
+	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
 func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *tls.Config) {
@@ -23,3 +29,63 @@ func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *t
 
 	return
 }
+
+// NewTLSConfigFromConfig returns the TLS config for connections to the
+// targeted foundation, trusting the CA bundle and presenting the client
+// certificate named in the config as well as the given trusted certs
+func NewTLSConfigFromConfig(config coreconfig.Reader, trustedCerts []tls.Certificate) (*tls.Config, error) {
+	tlsConfig := NewTLSConfig(trustedCerts, config.IsSSLDisabled())
+
+	if caCertFile := config.CACertFile(); caCertFile != "" {
+		certPool, err := LoadCACertPool(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tlsCert := range trustedCerts {
+			cert, _ := x509.ParseCertificate(tlsCert.Certificate[0])
+			certPool.AddCert(cert)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if config.ClientCertFile() != "" {
+		clientCert, err := LoadClientCertificate(config.ClientCertFile(), config.ClientKeyFile())
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
+
+// LoadCACertPool returns the system cert pool with the PEM encoded
+// certificates in the file at path added to it
+func LoadCACertPool(path string) (*x509.CertPool, error) {
+	pemCerts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", T("Error reading CA certificate bundle"), err.Error())
+	}
+
+	certPool, err := x509.SystemCertPool()
+	if err != nil || certPool == nil {
+		certPool = x509.NewCertPool()
+	}
+
+	if !certPool.AppendCertsFromPEM(pemCerts) {
+		return nil, errors.New(T("No certificates found in CA certificate bundle {{.Path}}", map[string]interface{}{"Path": path}))
+	}
+
+	return certPool, nil
+}
+
+// LoadClientCertificate reads the PEM encoded client certificate and
+// private key used for mutual TLS
+func LoadClientCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("%s: %s", T("Error loading client certificate"), err.Error())
+	}
+	return cert, nil
+}
@@ -0,0 +1,143 @@
+package replay
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/trace"
+)
+
+// RequestLogger is told about every request the server answers, and whether
+// a recorded response was found for it
+type RequestLogger func(request *http.Request, matched bool)
+
+// Server answers requests with the responses recorded in a HAR file. The
+// hosts of the recording are rewritten to the server's own address, so that
+// the CLI keeps talking to it after following links returned by the API.
+type Server struct {
+	mutex      sync.Mutex
+	entries    []trace.HAREntry
+	served     []bool
+	origins    []string
+	logRequest RequestLogger
+}
+
+func NewServer(har trace.HAR, logRequest RequestLogger) *Server {
+	server := &Server{
+		entries:    har.Log.Entries,
+		served:     make([]bool, len(har.Log.Entries)),
+		logRequest: logRequest,
+	}
+
+	seen := map[string]bool{}
+	for _, entry := range har.Log.Entries {
+		entryURL, err := url.Parse(entry.Request.URL)
+		if err != nil || entryURL.Host == "" {
+			continue
+		}
+
+		origin := entryURL.Scheme + "://" + entryURL.Host
+		if !seen[origin] {
+			seen[origin] = true
+			server.origins = append(server.origins, origin)
+		}
+	}
+
+	return server
+}
+
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	entry, found := s.match(request)
+	if s.logRequest != nil {
+		s.logRequest(request, found)
+	}
+
+	if !found {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusNotFound)
+		description := T("No recorded response for {{.Method}} {{.URI}}", map[string]interface{}{
+			"Method": request.Method,
+			"URI":    request.URL.RequestURI(),
+		})
+		body, _ := json.Marshal(map[string]interface{}{
+			"code":        10000,
+			"description": description,
+			"error_code":  "CF-NotFound",
+		})
+		_, _ = writer.Write(body)
+		return
+	}
+
+	selfOrigin := "http://" + request.Host
+	for _, header := range entry.Response.Headers {
+		switch http.CanonicalHeaderKey(header.Name) {
+		case "Content-Length", "Content-Encoding", "Transfer-Encoding", "Connection":
+			continue
+		}
+		writer.Header().Add(header.Name, s.rewriteOrigins(header.Value, selfOrigin))
+	}
+
+	writer.WriteHeader(entry.Response.Status)
+	_, _ = writer.Write([]byte(replaceHiddenTokens(s.rewriteOrigins(entry.Response.Content.Text, selfOrigin))))
+}
+
+// match returns the first recorded exchange for the request that has not been
+// served yet. Once they have all been served, the last one is repeated, which
+// lets a recording of a finished job answer any number of polls.
+func (s *Server) match(request *http.Request) (trace.HAREntry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	last := -1
+	for i, entry := range s.entries {
+		if entry.Request.Method != request.Method {
+			continue
+		}
+
+		entryURL, err := url.Parse(entry.Request.URL)
+		if err != nil || entryURL.RequestURI() != request.URL.RequestURI() {
+			continue
+		}
+
+		if !s.served[i] {
+			s.served[i] = true
+			return entry, true
+		}
+		last = i
+	}
+
+	if last == -1 {
+		return trace.HAREntry{}, false
+	}
+	return s.entries[last], true
+}
+
+func (s *Server) rewriteOrigins(text, selfOrigin string) string {
+	for _, origin := range s.origins {
+		text = strings.Replace(text, origin, selfOrigin, -1)
+	}
+	return text
+}
+
+// replaceHiddenTokens swaps the tokens hidden when the trace was recorded for
+// an unsigned token, so that the CLI can still read a user name from it
+func replaceHiddenTokens(text string) string {
+	placeholder := trace.PrivateDataPlaceholder()
+	text = strings.Replace(text, fmt.Sprintf(`"access_token":"%s"`, placeholder), fmt.Sprintf(`"access_token":"%s"`, replayAccessToken()), -1)
+	text = strings.Replace(text, fmt.Sprintf(`"refresh_token":"%s"`, placeholder), `"refresh_token":"replay-refresh-token"`, -1)
+	return text
+}
+
+func replayAccessToken() string {
+	encode := func(data string) string {
+		return strings.TrimRight(base64.StdEncoding.EncodeToString([]byte(data)), "=")
+	}
+
+	return encode(`{"alg":"none"}`) + "." + encode(`{"user_name":"replay","user_id":"replay","email":"replay"}`) + "."
+}
@@ -0,0 +1,111 @@
+package replay_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	. "github.com/cloudfoundry/cli/cf/replay"
+	"github.com/cloudfoundry/cli/cf/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		har       trace.HAR
+		server    *httptest.Server
+		unmatched []string
+	)
+
+	entry := func(method, url string, status int, body string) trace.HAREntry {
+		return trace.HAREntry{
+			Request: trace.HARRequest{Method: method, URL: url},
+			Response: trace.HARResponse{
+				Status: status,
+				Headers: []trace.HARNameValue{
+					{Name: "Content-Type", Value: "application/json"},
+					{Name: "Content-Length", Value: "1234"},
+				},
+				Content: trace.HARContent{Text: body},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		unmatched = []string{}
+		har = trace.HAR{Log: trace.HARLog{Entries: []trace.HAREntry{
+			entry("GET", "https://api.example.com/v2/info", http.StatusOK, `{"authorization_endpoint":"https://login.example.com"}`),
+			entry("POST", "https://login.example.com/oauth/token", http.StatusOK, `{"access_token":"[PRIVATE DATA HIDDEN]","refresh_token":"[PRIVATE DATA HIDDEN]"}`),
+			entry("GET", "https://api.example.com/v2/jobs/guid", http.StatusOK, `{"entity":{"status":"running"}}`),
+			entry("GET", "https://api.example.com/v2/jobs/guid", http.StatusOK, `{"entity":{"status":"finished"}}`),
+			entry("DELETE", "https://api.example.com/v2/apps/guid?async=true", http.StatusNotFound, `{"error_code":"CF-AppNotFound"}`),
+		}}}
+	})
+
+	JustBeforeEach(func() {
+		server = httptest.NewServer(NewServer(har, func(request *http.Request, matched bool) {
+			if !matched {
+				unmatched = append(unmatched, request.Method+" "+request.URL.RequestURI())
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	do := func(method, path string) (*http.Response, string) {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return response, string(body)
+	}
+
+	It("answers with the recorded response for the method and path", func() {
+		response, body := do("DELETE", "/v2/apps/guid?async=true")
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(body).To(Equal(`{"error_code":"CF-AppNotFound"}`))
+	})
+
+	It("points links to the recorded hosts back at itself", func() {
+		_, body := do("GET", "/v2/info")
+		Expect(body).To(Equal(`{"authorization_endpoint":"` + server.URL + `"}`))
+	})
+
+	It("replays repeated requests in order and then repeats the last response", func() {
+		_, body := do("GET", "/v2/jobs/guid")
+		Expect(body).To(ContainSubstring("running"))
+
+		_, body = do("GET", "/v2/jobs/guid")
+		Expect(body).To(ContainSubstring("finished"))
+
+		_, body = do("GET", "/v2/jobs/guid")
+		Expect(body).To(ContainSubstring("finished"))
+	})
+
+	It("replaces hidden tokens with a token the CLI can read", func() {
+		_, body := do("POST", "/oauth/token")
+		Expect(body).NotTo(ContainSubstring("[PRIVATE DATA HIDDEN]"))
+
+		start := strings.Index(body, `"access_token":"`) + len(`"access_token":"`)
+		token := body[start : start+strings.Index(body[start:], `"`)]
+		Expect(coreconfig.NewTokenInfo("bearer " + token).Username).To(Equal("replay"))
+	})
+
+	It("answers requests that were not recorded with a 404", func() {
+		response, body := do("GET", "/v2/apps")
+		Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		Expect(body).To(ContainSubstring("No recorded response for GET /v2/apps"))
+		Expect(unmatched).To(Equal([]string{"GET /v2/apps"}))
+	})
+})
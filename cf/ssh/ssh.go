@@ -35,6 +35,7 @@ type SecureShell interface {
 	Connect(opts *options.SSHOptions) error
 	InteractiveSession() error
 	LocalPortForward() error
+	ForwardStdio(targetAddr string) error
 	Wait() error
 	Close() error
 }
@@ -201,11 +202,48 @@ func copyAndClose(wg *sync.WaitGroup, dest io.WriteCloser, src io.Reader) {
 	}
 }
 
+func copyAndCloseWrite(dest net.Conn, src io.Reader) {
+	_, _ = io.Copy(dest, src)
+	if cw, ok := dest.(interface {
+		CloseWrite() error
+	}); ok {
+		_ = cw.CloseWrite()
+		return
+	}
+	_ = dest.Close()
+}
+
 func copyAndDone(wg *sync.WaitGroup, dest io.Writer, src io.Reader) {
 	_, _ = io.Copy(dest, src)
 	wg.Done()
 }
 
+// ForwardStdio opens a direct-tcpip channel to targetAddr and bridges it to
+// the standard streams, so the CLI can act as an OpenSSH ProxyCommand.
+func (c *secureShell) ForwardStdio(targetAddr string) error {
+	target, err := c.secureClient.Dial("tcp", targetAddr)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	stdin, stdout, _ := c.terminalHelper.StdStreams()
+
+	keepaliveStopCh := make(chan struct{})
+	defer close(keepaliveStopCh)
+
+	go keepalive(c.secureClient.Conn(), time.NewTicker(c.keepAliveInterval), keepaliveStopCh)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go copyAndCloseWrite(target, stdin)
+	go copyAndDone(wg, stdout, target)
+	wg.Wait()
+
+	return nil
+}
+
 func (c *secureShell) InteractiveSession() error {
 	var err error
 
@@ -446,19 +484,33 @@ func (c *secureShell) getWindowDimensions(terminalFd uintptr) (width int, height
 	return int(winSize.Width), int(winSize.Height)
 }
 
-type secureDialer struct{}
+type secureDialer struct {
+	dial func(network, address string) (net.Conn, error)
+}
 
 func (d *secureDialer) Dial(network string, address string, config *ssh.ClientConfig) (SecureClient, error) {
-	client, err := ssh.Dial(network, address, config)
+	conn, err := d.dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConn, chans, reqs, err := ssh.NewClientConn(conn, address, config)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
-	return &secureClient{client: client}, nil
+	return &secureClient{client: ssh.NewClient(clientConn, chans, reqs)}, nil
 }
 
 func DefaultSecureDialer() SecureDialer {
-	return &secureDialer{}
+	return NewSecureDialer(net.Dial)
+}
+
+// NewSecureDialer returns a SecureDialer that opens its connections with
+// dial, e.g. to go through a proxy
+func NewSecureDialer(dial func(network, address string) (net.Conn, error)) SecureDialer {
+	return &secureDialer{dial: dial}
 }
 
 type secureClient struct{ client *ssh.Client }
@@ -7,11 +7,14 @@
 package sshCmd_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
+	"strings"
 	"syscall"
 	"time"
 
@@ -1123,6 +1126,81 @@ var _ = Describe("SSH", func() {
 		})
 	})
 
+	Describe("ForwardStdio", func() {
+		var (
+			opts        *options.SSHOptions
+			forwardErr  error
+			echoAddress string
+			echoServer  net.Listener
+			stdout      *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoServer, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoServer.Addr().String()
+
+			go func() {
+				conn, err := echoServer.Accept()
+				if err != nil {
+					return
+				}
+				io.Copy(conn, conn)
+				conn.Close()
+			}()
+
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+
+			fakeSecureClient.DialStub = net.Dial
+
+			stdout = &bytes.Buffer{}
+			fakeTerminalHelper.StdStreamsReturns(ioutil.NopCloser(strings.NewReader("SSH-2.0-OpenSSH\r\n")), stdout, ioutil.Discard)
+			terminalHelper = fakeTerminalHelper
+		})
+
+		AfterEach(func() {
+			echoServer.Close()
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			forwardErr = secureShell.ForwardStdio(echoAddress)
+		})
+
+		It("dials the target address through the secure client", func() {
+			Expect(forwardErr).NotTo(HaveOccurred())
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(1))
+
+			network, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal(echoAddress))
+		})
+
+		It("copies stdin to the target and the target to stdout", func() {
+			Expect(forwardErr).NotTo(HaveOccurred())
+			Expect(stdout.String()).To(Equal("SSH-2.0-OpenSSH\r\n"))
+		})
+
+		Context("when dialing the target fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.DialStub = nil
+				fakeSecureClient.DialReturns(nil, errors.New("channel rejected"))
+			})
+
+			It("returns the error", func() {
+				Expect(forwardErr).To(MatchError("channel rejected"))
+			})
+		})
+	})
+
 	Describe("Wait", func() {
 		var opts *options.SSHOptions
 		var waitErr error
@@ -29,6 +29,14 @@ type FakeSecureShell struct {
 	localPortForwardReturns     struct {
 		result1 error
 	}
+	ForwardStdioStub        func(targetAddr string) error
+	forwardStdioMutex       sync.RWMutex
+	forwardStdioArgsForCall []struct {
+		targetAddr string
+	}
+	forwardStdioReturns struct {
+		result1 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -123,6 +131,38 @@ func (fake *FakeSecureShell) LocalPortForwardReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) ForwardStdio(targetAddr string) error {
+	fake.forwardStdioMutex.Lock()
+	fake.forwardStdioArgsForCall = append(fake.forwardStdioArgsForCall, struct {
+		targetAddr string
+	}{targetAddr})
+	fake.forwardStdioMutex.Unlock()
+	if fake.ForwardStdioStub != nil {
+		return fake.ForwardStdioStub(targetAddr)
+	} else {
+		return fake.forwardStdioReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) ForwardStdioCallCount() int {
+	fake.forwardStdioMutex.RLock()
+	defer fake.forwardStdioMutex.RUnlock()
+	return len(fake.forwardStdioArgsForCall)
+}
+
+func (fake *FakeSecureShell) ForwardStdioArgsForCall(i int) string {
+	fake.forwardStdioMutex.RLock()
+	defer fake.forwardStdioMutex.RUnlock()
+	return fake.forwardStdioArgsForCall[i].targetAddr
+}
+
+func (fake *FakeSecureShell) ForwardStdioReturns(result1 error) {
+	fake.ForwardStdioStub = nil
+	fake.forwardStdioReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureShell) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
@@ -20,7 +20,8 @@ const (
 
 var (
 	colorize               func(message string, textColor color.Attribute, bold int) string
-	TerminalSupportsColors = isTerminal()
+	StdoutIsTerminal       = isTerminal()
+	TerminalSupportsColors = StdoutIsTerminal
 	UserAskedForColors     = ""
 )
 
@@ -149,7 +149,7 @@ func (t *Table) calculateMaxSize(transformer rowTransformer, rowIndex int, row [
 			// https://www.pivotaltracker.com/n/projects/892938/stories/117404629
 
 			value := trim(Decolorize(transformer.Transform(columnIndex, trim(lines[i]))))
-			width := visibleSize(value)
+			width := VisibleSize(value)
 			if t.columnWidth[columnIndex] < width {
 				t.columnWidth[columnIndex] = width
 			}
@@ -264,7 +264,7 @@ func (t *Table) printCellValue(result io.Writer, transformer rowTransformer, col
 		// This happened for
 		// https://www.pivotaltracker.com/n/projects/892938/stories/117404629
 
-		padlen := t.columnWidth[col] - visibleSize(trim(Decolorize(value)))
+		padlen := t.columnWidth[col] - VisibleSize(trim(Decolorize(value)))
 		padding := strings.Repeat(" ", padlen)
 		fmt.Fprint(result, padding)
 		fmt.Fprint(result, t.colSpacing)
@@ -310,10 +310,10 @@ func trim(s string) string {
 	return strings.TrimRight(s, " \t")
 }
 
-// visibleSize returns the number of columns the string will cover
+// VisibleSize returns the number of columns the string will cover
 // when displayed in the terminal. This is the number of runes,
 // i.e. characters, not the number of bytes it consists of.
-func visibleSize(s string) int {
+func VisibleSize(s string) int {
 	// This code re-implements the basic functionality of
 	// RuneCountInString to account for special cases. Namely
 	// UTF-8 characters taking up 3 bytes (**) appear as double-width.
@@ -83,7 +83,13 @@ func (ui *terminalUI) PrintPaginator(rows []string, err error) {
 	}
 }
 
+// PrintCapturingNoOutput writes to stdout without going through the printer,
+// unless the printer is a TeePrinter whose terminal output is disabled
 func (ui *terminalUI) PrintCapturingNoOutput(message string, args ...interface{}) {
+	if printer, ok := ui.printer.(*TeePrinter); ok && printer.disableTerminalOutput {
+		return
+	}
+
 	if len(args) == 0 {
 		fmt.Fprintf(ui.stdout, "%s", message)
 	} else {
@@ -194,13 +200,21 @@ func (ui *terminalUI) ShowConfiguration(config coreconfig.Reader) {
 		)
 	}
 
+	if config.CurrentContext() != "" {
+		table.Add(T("Context:"), EntityNameColor(config.CurrentContext()))
+	}
+
 	if !config.IsLoggedIn() {
 		table.Print()
 		ui.Say(NotLoggedInText())
 		return
 	}
 
-	table.Add(T("User:"), EntityNameColor(config.UserEmail()))
+	user := config.UserEmail()
+	if user == "" {
+		user = config.Username()
+	}
+	table.Add(T("User:"), EntityNameColor(user))
 
 	if !config.HasOrganization() && !config.HasSpace() {
 		table.Print()
@@ -47,6 +47,20 @@ var _ = Describe("UI", func() {
 				Expect(bucket.Contents()).To(HaveLen(0))
 			})
 		})
+
+		It("prints nothing when the terminal output of the TeePrinter is disabled", func() {
+			printer := NewTeePrinter(os.Stdout)
+			printer.DisableTerminalOutput(true)
+
+			io_helpers.SimulateStdin("", func(reader io.Reader) {
+				output := io_helpers.CaptureOutput(func() {
+					ui := NewUI(reader, os.Stdout, printer, fakeLogger)
+					ui.PrintCapturingNoOutput("Hello")
+				})
+
+				Expect(strings.Join(output, "")).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("Printing message to stdout with Say", func() {
@@ -278,6 +292,16 @@ var _ = Describe("UI", func() {
 				Expect(output).To(ContainSubstrings([]string{"User:", "my-user-email"}))
 			})
 
+			Context("when a context is in use", func() {
+				BeforeEach(func() {
+					config.SaveContext("prod")
+				})
+
+				It("tells the user which context is in use", func() {
+					Expect(output).To(ContainSubstrings([]string{"Context:", "prod"}))
+				})
+			})
+
 			Context("when an org is targeted", func() {
 				BeforeEach(func() {
 					config.SetOrganizationFields(models.OrganizationFields{
@@ -341,6 +365,20 @@ var _ = Describe("UI", func() {
 		})
 	})
 
+	Context("when logged in with client credentials", func() {
+		It("tells the user which client is logged in", func() {
+			config := testconfig.NewRepositoryWithAccessToken(coreconfig.TokenInfo{ClientID: "my-client"})
+			config.SetAPIEndpoint("https://test.example.org")
+
+			output := io_helpers.CaptureOutput(func() {
+				ui := NewUI(os.Stdin, os.Stdout, NewTeePrinter(os.Stdout), fakeLogger)
+				ui.ShowConfiguration(config)
+			})
+
+			Expect(output).To(ContainSubstrings([]string{"User:", "my-client"}))
+		})
+	})
+
 	Describe("failing", func() {
 		It("panics with a specific string", func() {
 			io_helpers.CaptureOutput(func() {
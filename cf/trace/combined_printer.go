@@ -1,5 +1,7 @@
 package trace
 
+import "net/http"
+
 type combinedPrinter []Printer
 
 func CombinePrinters(printers []Printer) Printer {
@@ -33,3 +35,27 @@ func (p combinedPrinter) WritesToConsole() bool {
 
 	return false
 }
+
+func (p combinedPrinter) RecordRequest(req *http.Request) {
+	for _, printer := range p {
+		if recorder, ok := printer.(ExchangeRecorder); ok {
+			recorder.RecordRequest(req)
+		}
+	}
+}
+
+func (p combinedPrinter) RecordResponse(res *http.Response) {
+	for _, printer := range p {
+		if recorder, ok := printer.(ExchangeRecorder); ok {
+			recorder.RecordResponse(res)
+		}
+	}
+}
+
+func (p combinedPrinter) RecordFailure(req *http.Request, err error) {
+	for _, printer := range p {
+		if recorder, ok := printer.(ExchangeRecorder); ok {
+			recorder.RecordFailure(req, err)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf"
+	"github.com/cloudfoundry/cli/cf/configuration/safefile"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+const FormatHAR = "har"
+
+// HAR is an HTTP Archive, as described in
+// https://w3c.github.io/web-performance/specs/HAR/Overview.html
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+}
+
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// ExchangeRecorder is implemented by printers that record whole HTTP
+// exchanges instead of printing text. Every recorded request is followed by
+// either its response or the error that kept it from getting one.
+type ExchangeRecorder interface {
+	RecordRequest(*http.Request)
+	RecordResponse(*http.Response)
+	RecordFailure(*http.Request, error)
+}
+
+// HARRecorder is a Printer that ignores text and records the exchanges
+// passed to it in a HAR file. Each exchange is written over the end of the
+// archive together with a new end, so that the file is complete however the
+// process exits without being rewritten as it grows. The file is locked
+// while it is written so that several cf processes can share it.
+type HARRecorder struct {
+	path    string
+	mutex   sync.Mutex
+	pending map[*http.Request]pendingRequest
+}
+
+// harTrailer closes the entries array, the log and the archive
+const harTrailer = "\n]}}\n"
+
+type pendingRequest struct {
+	started time.Time
+	request HARRequest
+}
+
+// NewHARRecorder returns a recorder for path, keeping the entries already in
+// the file if there is one
+func NewHARRecorder(path string) (*HARRecorder, error) {
+	recorder := &HARRecorder{
+		path:    path,
+		pending: map[*http.Request]pendingRequest{},
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := safefile.Lock(path)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var existing HAR
+	if err == nil {
+		err = json.Unmarshal(contents, &existing)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasSuffix(contents, []byte(harTrailer)) {
+			return recorder, nil
+		}
+	}
+
+	header, err := json.Marshal(HAR{Log: HARLog{
+		Version: "1.2",
+		Creator: HARCreator{Name: "cf", Version: cf.Version},
+		Entries: []HAREntry{},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	archive := bytes.NewBuffer(bytes.TrimSuffix(header, []byte("]}}")))
+	for i, entry := range existing.Log.Entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			archive.WriteString(",")
+		}
+		archive.WriteString("\n")
+		archive.Write(data)
+	}
+	archive.WriteString(harTrailer)
+
+	return recorder, safefile.WriteFile(path, archive.Bytes(), 0600)
+}
+
+// ReadHAR reads the HAR file at path
+func ReadHAR(path string) (HAR, error) {
+	var har HAR
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return har, err
+	}
+
+	err = json.Unmarshal(data, &har)
+	return har, err
+}
+
+func (r *HARRecorder) Print(v ...interface{})                 {}
+func (r *HARRecorder) Printf(format string, v ...interface{}) {}
+func (r *HARRecorder) Println(v ...interface{})               {}
+func (r *HARRecorder) WritesToConsole() bool                  { return false }
+
+func (r *HARRecorder) RecordRequest(req *http.Request) {
+	harRequest := HARRequest{
+		Method:      req.Method,
+		URL:         Sanitize(req.URL.String()),
+		HTTPVersion: req.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(req.Header),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    int(req.ContentLength),
+	}
+	if harRequest.HTTPVersion == "" {
+		harRequest.HTTPVersion = "HTTP/1.1"
+	}
+	if req.Host != "" && req.Host != req.URL.Host {
+		harRequest.Headers = append(harRequest.Headers, HARNameValue{Name: "Host", Value: req.Host})
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			harRequest.QueryString = append(harRequest.QueryString, HARNameValue{Name: name, Value: value})
+		}
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if strings.Contains(contentType, "multipart/form-data") {
+		harRequest.PostData = &HARPostData{MimeType: contentType, Text: T("[MULTIPART/FORM-DATA CONTENT HIDDEN]")}
+	} else if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil && len(body) > 0 {
+			harRequest.PostData = &HARPostData{MimeType: contentType, Text: Sanitize(string(body))}
+			harRequest.BodySize = len(body)
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.pending[req] = pendingRequest{started: time.Now(), request: harRequest}
+}
+
+func (r *HARRecorder) RecordResponse(res *http.Response) {
+	var body []byte
+	if res.Body != nil {
+		body, _ = ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// the responses that redirected to res.Request were never passed on
+	// their own, so they are recorded first without their bodies
+	entries := []HAREntry{}
+	for req := res.Request; req != nil; req = res.Request {
+		pending, ok := r.pending[req]
+		if ok {
+			delete(r.pending, req)
+			entries = append([]HAREntry{harEntry(pending, harResponse(res, body))}, entries...)
+		}
+		if req.Response == nil {
+			break
+		}
+		res, body = req.Response, nil
+	}
+
+	for _, entry := range entries {
+		_ = r.append(entry)
+	}
+}
+
+// RecordFailure records req, which got no response because of err, and
+// forgets the requests it was redirected to
+func (r *HARRecorder) RecordFailure(req *http.Request, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for pendingReq := range r.pending {
+		if redirectedFrom(pendingReq, req) {
+			delete(r.pending, pendingReq)
+		}
+	}
+
+	pending, ok := r.pending[req]
+	if !ok {
+		return
+	}
+	delete(r.pending, req)
+
+	_ = r.append(harEntry(pending, HARResponse{
+		Status:      0,
+		StatusText:  Sanitize(err.Error()),
+		HTTPVersion: pending.request.HTTPVersion,
+		Cookies:     []HARNameValue{},
+		Headers:     []HARNameValue{},
+		HeadersSize: -1,
+		BodySize:    -1,
+	}))
+}
+
+func harEntry(pending pendingRequest, response HARResponse) HAREntry {
+	elapsed := float64(time.Since(pending.started)) / float64(time.Millisecond)
+	return HAREntry{
+		StartedDateTime: pending.started.Format(time.RFC3339Nano),
+		Time:            elapsed,
+		Request:         pending.request,
+		Response:        response,
+		Timings:         HARTimings{Send: 0, Wait: elapsed, Receive: 0},
+	}
+}
+
+func harResponse(res *http.Response, body []byte) HARResponse {
+	statusText := strings.TrimSpace(strings.TrimPrefix(res.Status, strconv.Itoa(res.StatusCode)))
+	if statusText == "" {
+		statusText = http.StatusText(res.StatusCode)
+	}
+
+	return HARResponse{
+		Status:      res.StatusCode,
+		StatusText:  statusText,
+		HTTPVersion: res.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(res.Header),
+		Content: HARContent{
+			Size:     len(body),
+			MimeType: res.Header.Get("Content-Type"),
+			Text:     Sanitize(string(body)),
+		},
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+}
+
+// redirectedFrom reports whether req was created by following redirects
+// from original
+func redirectedFrom(req, original *http.Request) bool {
+	for req.Response != nil && req.Response.Request != nil {
+		req = req.Response.Request
+		if req == original {
+			return true
+		}
+	}
+	return false
+}
+
+// append writes entry over the trailer at the end of the archive, followed
+// by the trailer. The end is found in the file while it is locked, since
+// other processes may have appended to it.
+func (r *HARRecorder) append(entry HAREntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	unlock, err := safefile.Lock(r.path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	file, err := os.OpenFile(r.path, os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	end := info.Size() - int64(len(harTrailer))
+	tail := make([]byte, len(harTrailer)+1)
+	if end < 1 {
+		return fmt.Errorf("%s is not a HAR file written by cf", r.path)
+	}
+	_, err = file.ReadAt(tail, end-1)
+	if err != nil {
+		return err
+	}
+	if string(tail[1:]) != harTrailer {
+		return fmt.Errorf("%s is not a HAR file written by cf", r.path)
+	}
+
+	separator := ",\n"
+	if tail[0] == '[' {
+		separator = "\n"
+	}
+
+	data = append([]byte(separator), data...)
+	_, err = file.WriteAt(append(data, harTrailer...), end)
+	return err
+}
+
+func harHeaders(header http.Header) []HARNameValue {
+	names := []string{}
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []HARNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			sanitized := strings.SplitN(Sanitize(name+": "+value), ": ", 2)
+			headers = append(headers, HARNameValue{Name: name, Value: sanitized[1]})
+		}
+	}
+	return headers
+}
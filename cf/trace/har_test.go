@@ -0,0 +1,238 @@
+package trace_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/cloudfoundry/cli/cf/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HARRecorder", func() {
+	var (
+		tmpDir   string
+		harPath  string
+		recorder *HARRecorder
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "har-recorder")
+		Expect(err).NotTo(HaveOccurred())
+		harPath = filepath.Join(tmpDir, "trace.har")
+
+		recorder, err = NewHARRecorder(harPath)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	exchange := func(request *http.Request, status int, body string) *http.Response {
+		recorder.RecordRequest(request)
+		response := &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Proto:      "HTTP/1.1",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    request,
+		}
+		recorder.RecordResponse(response)
+		return response
+	}
+
+	It("writes an empty archive when created", func() {
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Version).To(Equal("1.2"))
+		Expect(har.Log.Creator.Name).To(Equal("cf"))
+		Expect(har.Log.Entries).To(BeEmpty())
+	})
+
+	It("records each exchange with its timings", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/apps?q=name:my-app", nil)
+		request.Header.Set("Accept", "application/json")
+		response := exchange(request, http.StatusOK, `{"total_results":1}`)
+
+		By("leaving the response body readable")
+		body, _ := ioutil.ReadAll(response.Body)
+		Expect(string(body)).To(Equal(`{"total_results":1}`))
+
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Entries).To(HaveLen(1))
+
+		entry := har.Log.Entries[0]
+		Expect(entry.StartedDateTime).NotTo(BeEmpty())
+		Expect(entry.Time).To(BeNumerically(">=", 0))
+		Expect(entry.Request.Method).To(Equal("GET"))
+		Expect(entry.Request.URL).To(Equal("https://api.example.com/v2/apps?q=name:my-app"))
+		Expect(entry.Request.Headers).To(ContainElement(HARNameValue{Name: "Accept", Value: "application/json"}))
+		Expect(entry.Request.QueryString).To(ConsistOf(HARNameValue{Name: "q", Value: "name:my-app"}))
+		Expect(entry.Response.Status).To(Equal(http.StatusOK))
+		Expect(entry.Response.StatusText).To(Equal("OK"))
+		Expect(entry.Response.Content.MimeType).To(Equal("application/json"))
+		Expect(entry.Response.Content.Text).To(Equal(`{"total_results":1}`))
+	})
+
+	It("hides private data like the text trace does", func() {
+		request, _ := http.NewRequest("POST", "https://uaa.example.com/oauth/token", strings.NewReader("grant_type=password&password=secret&username=admin"))
+		request.Header.Set("Authorization", "Basic Y2Y6")
+		exchange(request, http.StatusOK, `{"access_token":"my-token","refresh_token":"my-refresh-token"}`)
+
+		By("leaving the request body readable")
+		body, _ := ioutil.ReadAll(request.Body)
+		Expect(string(body)).To(ContainSubstring("password=secret"))
+
+		contents, err := ioutil.ReadFile(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).NotTo(ContainSubstring("secret"))
+		Expect(string(contents)).NotTo(ContainSubstring("my-token"))
+		Expect(string(contents)).NotTo(ContainSubstring("Y2Y6"))
+
+		har, _ := ReadHAR(harPath)
+		entry := har.Log.Entries[0]
+		Expect(entry.Request.Headers).To(ContainElement(HARNameValue{Name: "Authorization", Value: "[PRIVATE DATA HIDDEN]"}))
+		Expect(entry.Request.PostData.Text).To(Equal("grant_type=password&password=[PRIVATE DATA HIDDEN]&username=admin"))
+	})
+
+	It("does not record multipart bodies", func() {
+		request, _ := http.NewRequest("PUT", "https://api.example.com/v2/apps/guid/bits", strings.NewReader("zip contents"))
+		request.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+		exchange(request, http.StatusCreated, `{}`)
+
+		har, _ := ReadHAR(harPath)
+		Expect(har.Log.Entries[0].Request.PostData.Text).To(Equal("[MULTIPART/FORM-DATA CONTENT HIDDEN]"))
+	})
+
+	It("ignores responses to requests it did not see", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		recorder.RecordResponse(&http.Response{StatusCode: http.StatusOK, Request: request, Header: http.Header{}})
+
+		har, _ := ReadHAR(harPath)
+		Expect(har.Log.Entries).To(BeEmpty())
+	})
+
+	It("appends to an existing archive", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		exchange(request, http.StatusOK, `{}`)
+
+		var err error
+		recorder, err = NewHARRecorder(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		request, _ = http.NewRequest("GET", "https://api.example.com/v2/apps", nil)
+		exchange(request, http.StatusOK, `{}`)
+
+		har, _ := ReadHAR(harPath)
+		Expect(har.Log.Entries).To(HaveLen(2))
+	})
+
+	It("writes each exchange after the ones already in the file", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		exchange(request, http.StatusOK, `{}`)
+		before, err := ioutil.ReadFile(harPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 3; i++ {
+			request, _ = http.NewRequest("GET", "https://api.example.com/v2/apps", nil)
+			exchange(request, http.StatusOK, `{}`)
+		}
+
+		after, err := ioutil.ReadFile(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(after)).To(HavePrefix(strings.TrimSuffix(string(before), "\n]}}\n")))
+
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Entries).To(HaveLen(4))
+		Expect(har.Log.Entries[3].Request.URL).To(Equal("https://api.example.com/v2/apps"))
+	})
+
+	It("keeps the exchanges of other recorders writing to the same file", func() {
+		other, err := NewHARRecorder(harPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i := 0; i < 2; i++ {
+			request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+			exchange(request, http.StatusOK, `{}`)
+
+			request, _ = http.NewRequest("GET", "https://api.example.com/v2/apps", nil)
+			other.RecordRequest(request)
+			other.RecordResponse(&http.Response{StatusCode: http.StatusOK, Request: request, Header: http.Header{}})
+		}
+
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Entries).To(HaveLen(4))
+		Expect(har.Log.Entries[1].Request.URL).To(Equal("https://api.example.com/v2/apps"))
+		Expect(har.Log.Entries[2].Request.URL).To(Equal("https://api.example.com/v2/info"))
+	})
+
+	It("records requests that got no response", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		recorder.RecordRequest(request)
+		recorder.RecordFailure(request, errors.New("dial tcp: connection refused"))
+
+		By("forgetting the request")
+		recorder.RecordResponse(&http.Response{StatusCode: http.StatusOK, Request: request, Header: http.Header{}})
+
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Entries).To(HaveLen(1))
+		Expect(har.Log.Entries[0].Response.Status).To(Equal(0))
+		Expect(har.Log.Entries[0].Response.StatusText).To(Equal("dial tcp: connection refused"))
+	})
+
+	It("records the redirects that led to a response", func() {
+		request, _ := http.NewRequest("GET", "https://api.example.com/v2/info", nil)
+		recorder.RecordRequest(request)
+		redirect, _ := http.NewRequest("GET", "https://login.example.com/v2/info", nil)
+		redirect.Response = &http.Response{
+			StatusCode: http.StatusFound,
+			Header:     http.Header{"Location": []string{"https://login.example.com/v2/info"}},
+			Request:    request,
+		}
+		recorder.RecordRequest(redirect)
+		recorder.RecordResponse(&http.Response{StatusCode: http.StatusOK, Request: redirect, Header: http.Header{}})
+
+		har, err := ReadHAR(harPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(har.Log.Entries).To(HaveLen(2))
+		Expect(har.Log.Entries[0].Request.URL).To(Equal("https://api.example.com/v2/info"))
+		Expect(har.Log.Entries[0].Response.Status).To(Equal(http.StatusFound))
+		Expect(har.Log.Entries[0].Response.RedirectURL).To(Equal("https://login.example.com/v2/info"))
+		Expect(har.Log.Entries[1].Request.URL).To(Equal("https://login.example.com/v2/info"))
+		Expect(har.Log.Entries[1].Response.Status).To(Equal(http.StatusOK))
+	})
+
+	It("refuses to overwrite a file that is not an archive", func() {
+		Expect(ioutil.WriteFile(harPath, []byte("REQUEST: GET /v2/info"), 0600)).To(Succeed())
+
+		_, err := NewHARRecorder(harPath)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("through a combined printer", func() {
+		It("records exchanges passed to the printer", func() {
+			printer := CombinePrinters([]Printer{NewWriterPrinter(ioutil.Discard, false), recorder})
+			exchangeRecorder, ok := printer.(ExchangeRecorder)
+			Expect(ok).To(BeTrue())
+
+			request, _ := http.NewRequest("DELETE", "https://api.example.com/v2/apps/guid", nil)
+			exchangeRecorder.RecordRequest(request)
+			exchangeRecorder.RecordResponse(&http.Response{StatusCode: http.StatusNoContent, Request: request, Header: http.Header{}})
+
+			har, _ := ReadHAR(harPath)
+			Expect(har.Log.Entries).To(HaveLen(1))
+			Expect(har.Log.Entries[0].Response.Status).To(Equal(http.StatusNoContent))
+		})
+	})
+})
@@ -2,7 +2,10 @@ package trace
 
 import (
 	"io"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	. "github.com/cloudfoundry/cli/cf/i18n"
 
@@ -10,21 +13,37 @@ import (
 )
 
 func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Printer {
+	return NewFormattedLogger(writer, verbose, "", cfTrace, configTrace)
+}
+
+// NewFormattedLogger is like NewLogger for any number of trace settings. Trace
+// files are written as HAR when format is FormatHAR or their name ends in
+// .har, and as text otherwise.
+func NewFormattedLogger(writer io.Writer, verbose bool, format string, traces ...string) Printer {
 	LoggingToStdout = verbose
 
 	var printers []Printer
 
 	stdoutLogger := NewWriterPrinter(writer, true)
 
-	for _, path := range []string{cfTrace, configTrace} {
+	for _, path := range traces {
 		b, err := strconv.ParseBool(path)
 		LoggingToStdout = LoggingToStdout || b
 
 		if path != "" && err != nil {
-			file, err := fileutils.Open(path)
+			var printer Printer
+			if format == FormatHAR || strings.EqualFold(filepath.Ext(path), ".har") {
+				printer, err = NewHARRecorder(path)
+			} else {
+				var file *os.File
+				file, err = fileutils.Open(path)
+				if err == nil {
+					printer = NewWriterPrinter(file, false)
+				}
+			}
 
 			if err == nil {
-				printers = append(printers, NewWriterPrinter(file, false))
+				printers = append(printers, printer)
 			} else {
 				stdoutLogger.Printf(T("CF_TRACE ERROR CREATING LOG FILE {{.Path}}:\n{{.Err}}",
 					map[string]interface{}{"Path": path, "Err": err}))
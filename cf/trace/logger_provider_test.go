@@ -2,6 +2,7 @@ package trace_test
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"runtime"
 
 	. "github.com/cloudfoundry/cli/cf/trace"
@@ -181,4 +182,46 @@ var _ = Describe("NewLogger", func() {
 			Expect(buffer).To(gbytes.Say("Hello World"))
 		}
 	})
+
+	Describe("NewFormattedLogger", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "trace_test")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("writes trace files ending in .har as HTTP Archives", func() {
+			path := filepath.Join(tmpDir, "trace.har")
+			logger := NewFormattedLogger(buffer, false, "", "", "", path)
+
+			logger.Print("Hello World")
+
+			_, err := ReadHAR(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ioutil.ReadFile(path)).NotTo(ContainSubstring("Hello World"))
+		})
+
+		It("writes every trace file as an HTTP Archive when the format is har", func() {
+			path := filepath.Join(tmpDir, "trace.log")
+			NewFormattedLogger(buffer, false, FormatHAR, path, "")
+
+			har, err := ReadHAR(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(har.Log.Entries).To(BeEmpty())
+		})
+
+		It("still prints text to STDOUT when the format is har", func() {
+			logger := NewFormattedLogger(buffer, false, FormatHAR, "true")
+
+			logger.Print("Hello World")
+
+			Expect(buffer).To(gbytes.Say("Hello World"))
+		})
+	})
 })
@@ -0,0 +1,269 @@
+package trace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	. "github.com/cloudfoundry/cli/cf/i18n"
+)
+
+var guidPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// Profile is a Printer that ignores text and collects how long the HTTP
+// exchanges passed to it take, along with the other calls and local
+// operations reported with TimeCall and TimeOperation, for `cf --timings`
+type Profile struct {
+	mutex      sync.Mutex
+	started    time.Time
+	pending    map[*http.Request]time.Time
+	calls      map[string]*callTimings
+	operations map[string]*callTimings
+}
+
+type callTimings struct {
+	durations []time.Duration
+	bytes     int64
+}
+
+var (
+	activeProfileMutex sync.Mutex
+	activeProfile      *Profile
+)
+
+func NewProfile() *Profile {
+	return &Profile{
+		started:    time.Now(),
+		pending:    map[*http.Request]time.Time{},
+		calls:      map[string]*callTimings{},
+		operations: map[string]*callTimings{},
+	}
+}
+
+// SetProfile makes TimeCall and TimeOperation report to profile; nil turns
+// them off
+func SetProfile(profile *Profile) {
+	activeProfileMutex.Lock()
+	defer activeProfileMutex.Unlock()
+	activeProfile = profile
+}
+
+func currentProfile() *Profile {
+	activeProfileMutex.Lock()
+	defer activeProfileMutex.Unlock()
+	return activeProfile
+}
+
+// TimingsEnabled reports whether a profile is collecting timings
+func TimingsEnabled() bool {
+	return currentProfile() != nil
+}
+
+// TimeCall starts timing a call to endpoint that is not made through a
+// Gateway. The returned function stops the timer and records the number of
+// bytes transferred.
+func TimeCall(endpoint string) func(bytes int64) {
+	profile := currentProfile()
+	if profile == nil {
+		return func(int64) {}
+	}
+
+	started := time.Now()
+	return func(bytes int64) {
+		profile.RecordCall(endpoint, time.Since(started), bytes)
+	}
+}
+
+// TimeOperation starts timing a local operation such as zipping app files,
+// returning the function that stops the timer
+func TimeOperation(name string) func() {
+	profile := currentProfile()
+	if profile == nil {
+		return func() {}
+	}
+
+	started := time.Now()
+	return func() {
+		profile.RecordOperation(name, time.Since(started))
+	}
+}
+
+func (p *Profile) Print(v ...interface{})                 {}
+func (p *Profile) Printf(format string, v ...interface{}) {}
+func (p *Profile) Println(v ...interface{})               {}
+func (p *Profile) WritesToConsole() bool                  { return false }
+
+func (p *Profile) RecordRequest(req *http.Request) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.pending[req] = time.Now()
+}
+
+func (p *Profile) RecordResponse(res *http.Response) {
+	size := res.ContentLength
+	if size < 0 && res.Body != nil {
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		res.Body = ioutil.NopCloser(bytes.NewReader(body))
+		size = int64(len(body))
+	}
+
+	p.mutex.Lock()
+	started, ok := p.pending[res.Request]
+	delete(p.pending, res.Request)
+	for req := res.Request; req != nil && req.Response != nil; req = req.Response.Request {
+		delete(p.pending, req.Response.Request)
+	}
+	p.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if res.Request.ContentLength > 0 {
+		size += res.Request.ContentLength
+	}
+	p.RecordCall(EndpointName(res.Request), time.Since(started), size)
+}
+
+// RecordFailure forgets req, which got no response, and the requests it was
+// redirected to
+func (p *Profile) RecordFailure(req *http.Request, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.pending, req)
+	for pendingReq := range p.pending {
+		if redirectedFrom(pendingReq, req) {
+			delete(p.pending, pendingReq)
+		}
+	}
+}
+
+func (p *Profile) RecordCall(endpoint string, elapsed time.Duration, bytes int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	record(p.calls, endpoint, elapsed, bytes)
+}
+
+func (p *Profile) RecordOperation(name string, elapsed time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	record(p.operations, name, elapsed, 0)
+}
+
+func record(timings map[string]*callTimings, name string, elapsed time.Duration, bytes int64) {
+	t, ok := timings[name]
+	if !ok {
+		t = &callTimings{}
+		timings[name] = t
+	}
+	t.durations = append(t.durations, elapsed)
+	t.bytes += bytes
+}
+
+// EndpointName identifies the endpoint a request is for, with GUIDs in the
+// path replaced so that requests for different resources are grouped
+func EndpointName(req *http.Request) string {
+	return req.Method + " " + req.URL.Host + guidPattern.ReplaceAllString(req.URL.Path, ":guid")
+}
+
+// WriteSummary writes a table of the calls and operations recorded so far,
+// slowest first
+func (p *Profile) WriteSummary(writer io.Writer) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	table := tabwriter.NewWriter(writer, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(table, T("TIMINGS:"))
+	fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", T("endpoint"), T("calls"), T("total"), T("p50"), T("p90"), T("p99"), T("bytes"))
+	for _, name := range sortedBySlowest(p.calls) {
+		t := p.calls[name]
+		fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\t%s\t%d\n", name, len(t.durations), formatDuration(total(t.durations)),
+			formatDuration(percentile(t.durations, 50)), formatDuration(percentile(t.durations, 90)), formatDuration(percentile(t.durations, 99)), t.bytes)
+	}
+
+	if len(p.operations) > 0 {
+		fmt.Fprintln(table)
+		fmt.Fprintf(table, "%s\t%s\t%s\n", T("local operation"), T("calls"), T("total"))
+		for _, name := range sortedBySlowest(p.operations) {
+			t := p.operations[name]
+			fmt.Fprintf(table, "%s\t%d\t%s\n", name, len(t.durations), formatDuration(total(t.durations)))
+		}
+	}
+
+	fmt.Fprintln(table)
+	fmt.Fprintf(table, "%s\t%s\n", T("total elapsed"), formatDuration(time.Since(p.started)))
+
+	_ = table.Flush()
+}
+
+func sortedBySlowest(timings map[string]*callTimings) []string {
+	names := []string{}
+	for name := range timings {
+		names = append(names, name)
+	}
+
+	sort.Sort(bySlowest{names: names, timings: timings})
+	return names
+}
+
+type bySlowest struct {
+	names   []string
+	timings map[string]*callTimings
+}
+
+func (s bySlowest) Len() int      { return len(s.names) }
+func (s bySlowest) Swap(i, j int) { s.names[i], s.names[j] = s.names[j], s.names[i] }
+func (s bySlowest) Less(i, j int) bool {
+	ti, tj := total(s.timings[s.names[i]].durations), total(s.timings[s.names[j]].durations)
+	if ti == tj {
+		return s.names[i] < s.names[j]
+	}
+	return ti > tj
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+
+func total(ds []time.Duration) time.Duration {
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum
+}
+
+// percentile returns the nearest-rank percentile of durations
+func percentile(ds []time.Duration, p int) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+
+	sorted := append(durations{}, ds...)
+	sort.Sort(sorted)
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return (d / time.Millisecond * time.Millisecond).String()
+	}
+	return (d / (10 * time.Millisecond) * (10 * time.Millisecond)).String()
+}
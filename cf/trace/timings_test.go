@@ -0,0 +1,95 @@
+package trace_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/cloudfoundry/cli/cf/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+	var profile *Profile
+
+	BeforeEach(func() {
+		profile = NewProfile()
+	})
+
+	AfterEach(func() {
+		SetProfile(nil)
+	})
+
+	summary := func() string {
+		buffer := new(bytes.Buffer)
+		profile.WriteSummary(buffer)
+		return buffer.String()
+	}
+
+	Describe("EndpointName", func() {
+		It("groups requests for different resources", func() {
+			request, _ := http.NewRequest("GET", "https://api.example.com/v2/apps/6c4b2a8e-0a1c-4b0e-9d3c-6f1e2a3b4c5d/summary?inline-relations-depth=1", nil)
+			Expect(EndpointName(request)).To(Equal("GET api.example.com/v2/apps/:guid/summary"))
+		})
+	})
+
+	It("records the latency and size of each exchange", func() {
+		request, _ := http.NewRequest("PUT", "https://api.example.com/v2/apps/6c4b2a8e-0a1c-4b0e-9d3c-6f1e2a3b4c5d", strings.NewReader(`{"name":"new-name"}`))
+		profile.RecordRequest(request)
+		response := &http.Response{
+			StatusCode:    http.StatusCreated,
+			ContentLength: -1,
+			Body:          ioutil.NopCloser(strings.NewReader(`{"metadata":{}}`)),
+			Request:       request,
+		}
+		profile.RecordResponse(response)
+
+		By("leaving the response body readable")
+		body, _ := ioutil.ReadAll(response.Body)
+		Expect(string(body)).To(Equal(`{"metadata":{}}`))
+
+		Expect(summary()).To(MatchRegexp(`PUT api\.example\.com/v2/apps/:guid\s+1\s+\S+\s+\S+\s+\S+\s+\S+\s+34\n`))
+	})
+
+	It("reports the total and percentile latencies of each endpoint, slowest first", func() {
+		for i := 1; i <= 10; i++ {
+			profile.RecordCall("GET api.example.com/v2/apps", time.Duration(i)*10*time.Millisecond, 100)
+		}
+		profile.RecordCall("GET uaa.example.com/oauth/token", 2*time.Second, 50)
+
+		output := summary()
+		Expect(output).To(MatchRegexp(`GET api\.example\.com/v2/apps\s+10\s+550ms\s+50ms\s+90ms\s+100ms\s+1000\n`))
+		Expect(output).To(MatchRegexp(`GET uaa\.example\.com/oauth/token\s+1\s+2s\s+2s\s+2s\s+2s\s+50\n`))
+		Expect(strings.Index(output, "oauth/token")).To(BeNumerically("<", strings.Index(output, "v2/apps")))
+		Expect(output).To(ContainSubstring("total elapsed"))
+	})
+
+	It("reports local operations", func() {
+		SetProfile(profile)
+		stop := TimeOperation("zip app files")
+		stop()
+
+		Expect(summary()).To(MatchRegexp(`local operation\s+calls\s+total\nzip app files\s+1\s+`))
+	})
+
+	It("records calls made outside of a gateway", func() {
+		SetProfile(profile)
+		stop := TimeCall("GET doppler.example.com/apps/:guid/recentlogs")
+		stop(2048)
+
+		Expect(TimingsEnabled()).To(BeTrue())
+		Expect(summary()).To(MatchRegexp(`GET doppler\.example\.com/apps/:guid/recentlogs\s+1\s+.*\s+2048\n`))
+	})
+
+	It("does nothing when no profile is set", func() {
+		Expect(TimingsEnabled()).To(BeFalse())
+		TimeOperation("zip app files")()
+		TimeCall("GET doppler.example.com/apps/:guid/recentlogs")(2048)
+
+		Expect(summary()).NotTo(ContainSubstring("zip app files"))
+	})
+})
@@ -3,10 +3,13 @@ package downloader
 import (
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 type Downloader interface {
@@ -19,18 +22,43 @@ type downloader struct {
 	saveDir    string
 	filename   string
 	downloaded bool
+	transport  http.RoundTripper
 }
 
-func NewDownloader(saveDir string) Downloader {
+func NewDownloader(saveDir string, proxy func(*http.Request) (*neturl.URL, error)) Downloader {
+	return NewDownloaderWithTransport(saveDir, NewTransport(proxy))
+}
+
+// NewTransport returns a transport with the settings of http.DefaultTransport
+// that uses proxy. The default transport is not copied, since it may have
+// been replaced by one that is not an *http.Transport, and copying one that
+// is in use would share its idle connections.
+func NewTransport(proxy func(*http.Request) (*neturl.URL, error)) *http.Transport {
+	return &http.Transport{
+		Proxy: proxy,
+		Dial: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).Dial,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// NewDownloaderWithTransport returns a Downloader that makes its requests
+// with transport, e.g. to authenticate them
+func NewDownloaderWithTransport(saveDir string, transport http.RoundTripper) Downloader {
 	return &downloader{
 		saveDir:    saveDir,
 		downloaded: false,
+		transport:  transport,
 	}
 }
 
 //this func returns byte written, filename and error
 func (d *downloader) DownloadFile(url string) (int64, string, error) {
 	c := http.Client{
+		Transport: d.transport,
 		CheckRedirect: func(r *http.Request, via []*http.Request) error {
 			r.URL.Opaque = r.URL.Path
 
@@ -57,6 +85,9 @@ func (d *downloader) DownloadFile(url string) (int64, string, error) {
 			d.filename = getFilenameFromURL(url)
 		}
 
+		// the name comes from the server, so it must not leave saveDir
+		d.filename = filepath.Base(d.filename)
+
 		f, err := os.Create(filepath.Join(d.saveDir, d.filename))
 		if err != nil {
 			return 0, "", err
@@ -3,8 +3,10 @@ package downloader_test
 import (
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
+	"time"
 
 	"github.com/cloudfoundry/cli/downloader"
 
@@ -24,7 +26,7 @@ var _ = Describe("Downloader", func() {
 		var err error
 		tempDir, err = ioutil.TempDir("", "file-download-test")
 		Expect(err).NotTo(HaveOccurred())
-		d = downloader.NewDownloader(tempDir)
+		d = downloader.NewDownloader(tempDir, http.ProxyFromEnvironment)
 	})
 
 	AfterEach(func() {
@@ -112,6 +114,28 @@ var _ = Describe("Downloader", func() {
 			})
 		})
 
+		Context("when the filename in the header contains a path", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/abc.zip"),
+						ghttp.RespondWith(http.StatusOK, "abc123", http.Header{
+							"Content-Disposition": []string{"attachment; filename=../header.zip"},
+						}),
+					),
+				)
+			})
+
+			It("saves the file in the provided dir", func() {
+				_, name, err := d.DownloadFile(server.URL() + "/abc.zip")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(name).To(Equal("header.zip"))
+
+				_, err = os.Stat(path.Join(tempDir, "header.zip"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when the server returns a redirect to a file", func() {
 			BeforeEach(func() {
 				server.AppendHandlers(
@@ -200,4 +224,18 @@ var _ = Describe("Downloader", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+	Describe("NewTransport", func() {
+		It("uses the proxy with the timeouts of the default transport", func() {
+			proxyURL, _ := url.Parse("http://proxy.example.com:8080")
+			transport := downloader.NewTransport(http.ProxyURL(proxyURL))
+
+			request, _ := http.NewRequest("GET", "https://plugins.example.com/plugin", nil)
+			usedURL, err := transport.Proxy(request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usedURL).To(Equal(proxyURL))
+
+			Expect(transport.Dial).NotTo(BeNil())
+			Expect(transport.TLSHandshakeTimeout).To(Equal(10 * time.Second))
+		})
+	})
 })
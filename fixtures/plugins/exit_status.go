@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+type ExitStatus struct{}
+
+func (c *ExitStatus) Run(cliConnection plugin.CliConnection, args []string) {
+	status, _ := strconv.Atoi(args[1])
+	fmt.Fprintf(os.Stderr, "exiting with %d\n", status)
+	os.Exit(status)
+}
+
+func (c *ExitStatus) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "ExitStatus",
+		Commands: []plugin.Command{
+			{
+				Name:     "exit-status",
+				HelpText: "exits with the given status",
+			},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(ExitStatus))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+type Hooks struct{}
+
+func (c *Hooks) Run(cliConnection plugin.CliConnection, args []string) {}
+
+func (c *Hooks) RunHook(cliConnection plugin.CliConnection, context plugin.HookContext) error {
+	switch context.Hook {
+	case "pre-plugins":
+		if message := os.Getenv("HOOKS_VETO"); message != "" {
+			return errors.New(message)
+		}
+		fmt.Printf("pre-plugins hook called with args [%s]\n", strings.Join(context.Args, " "))
+	case "post-plugins":
+		fmt.Printf("post-plugins hook called, failed: %t\n", context.Failed)
+	}
+	return nil
+}
+
+func (c *Hooks) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name:    "Hooks",
+		Version: plugin.VersionType{Major: 1},
+		Commands: []plugin.Command{
+			{Name: "hooks", HelpText: "help text for hooks"},
+		},
+		Hooks: []plugin.Hook{
+			{Name: "pre-plugins"},
+			{Name: "post-plugins"},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(Hooks))
+}
@@ -0,0 +1,26 @@
+package main
+
+import "github.com/cloudfoundry/cli/plugin"
+
+type InvalidFlag struct{}
+
+func (c *InvalidFlag) Run(cliConnection plugin.CliConnection, args []string) {}
+
+func (c *InvalidFlag) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "InvalidFlag",
+		Commands: []plugin.Command{
+			{
+				Name:     "invalid-flag",
+				HelpText: "help text for invalid-flag",
+				Flags: []plugin.Flag{
+					{Name: "when", Type: "date"},
+				},
+			},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(InvalidFlag))
+}
@@ -0,0 +1,23 @@
+package main
+
+import "github.com/cloudfoundry/cli/plugin"
+
+type InvalidHook struct{}
+
+func (c *InvalidHook) Run(cliConnection plugin.CliConnection, args []string) {}
+
+func (c *InvalidHook) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "InvalidHook",
+		Commands: []plugin.Command{
+			{Name: "invalid-hook", HelpText: "help text for invalid-hook"},
+		},
+		Hooks: []plugin.Hook{
+			{Name: "pre-v"},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(InvalidHook))
+}
@@ -33,7 +33,11 @@ func (c *MySay) GetMetadata() plugin.PluginMetadata {
 		Commands: []plugin.Command{
 			{
 				Name:     "my-say",
+				Aliases:  []string{"say-it"},
 				HelpText: "Plugin to say things from the cli",
+				Flags: []plugin.Flag{
+					{Name: "loud", Type: plugin.FlagTypeBool, Usage: "Say it loud"},
+				},
 			},
 		},
 	}
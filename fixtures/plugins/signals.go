@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+type Signals struct{}
+
+func (c *Signals) Run(cliConnection plugin.CliConnection, args []string) {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	ignore := len(args) > 1 && args[1] == "--ignore"
+
+	fmt.Println("waiting for a signal")
+	for {
+		select {
+		case sig := <-signals:
+			if !ignore {
+				fmt.Printf("cleaning up after %s\n", sig)
+				os.Exit(7)
+			}
+		case <-time.After(10 * time.Second):
+			os.Exit(0)
+		}
+	}
+}
+
+func (c *Signals) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "Signals",
+		Commands: []plugin.Command{
+			{
+				Name:     "wait-for-signal",
+				HelpText: "waits for SIGINT or SIGTERM",
+			},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(Signals))
+}
@@ -25,3 +25,7 @@ func (f *BackwardsCompatibilityFlag) GetValue() interface{} {
 func (f *BackwardsCompatibilityFlag) Visible() bool {
 	return false
 }
+
+func (f *BackwardsCompatibilityFlag) IsSensitive() bool {
+	return false
+}
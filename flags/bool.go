@@ -34,3 +34,7 @@ func (f *BoolFlag) GetValue() interface{} {
 func (f *BoolFlag) Visible() bool {
 	return !f.Hidden
 }
+
+func (f *BoolFlag) IsSensitive() bool {
+	return false
+}
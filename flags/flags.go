@@ -14,6 +14,10 @@ type FlagSet interface {
 	GetValue() interface{}
 	Set(string)
 	Visible() bool
+
+	// IsSensitive tells whether the values of the flag can hold passwords,
+	// tokens or credentials, which are hidden from plugin hooks
+	IsSensitive() bool
 }
 
 type FlagContext interface {
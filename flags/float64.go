@@ -34,3 +34,7 @@ func (f *Float64Flag) GetValue() interface{} {
 func (f *Float64Flag) Visible() bool {
 	return !f.Hidden
 }
+
+func (f *Float64Flag) IsSensitive() bool {
+	return false
+}
@@ -38,3 +38,7 @@ func (f *IntFlag) Visible() bool {
 func (f *IntFlag) SetVisibility(v bool) {
 	f.Hidden = !v
 }
+
+func (f *IntFlag) IsSensitive() bool {
+	return false
+}
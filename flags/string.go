@@ -6,6 +6,7 @@ type StringFlag struct {
 	Usage     string
 	ShortName string
 	Hidden    bool
+	Sensitive bool
 }
 
 func (f *StringFlag) Set(v string) {
@@ -31,3 +32,7 @@ func (f *StringFlag) GetValue() interface{} {
 func (f *StringFlag) Visible() bool {
 	return !f.Hidden
 }
+
+func (f *StringFlag) IsSensitive() bool {
+	return f.Sensitive
+}
@@ -7,6 +7,7 @@ type StringSliceFlag struct {
 	Usage     string
 	ShortName string
 	Hidden    bool
+	Sensitive bool
 }
 
 func (f *StringSliceFlag) Set(v string) {
@@ -32,3 +33,7 @@ func (f *StringSliceFlag) GetValue() interface{} {
 func (f *StringSliceFlag) Visible() bool {
 	return !f.Hidden
 }
+
+func (f *StringSliceFlag) IsSensitive() bool {
+	return f.Sensitive
+}
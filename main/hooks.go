@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/cloudfoundry/cli/plugin/rpc"
+)
+
+// commandHooks runs the pre and post hooks plugins registered for a core
+// command
+type commandHooks struct {
+	deps       commandregistry.Dependency
+	command    string
+	args       []string
+	pluginList map[string]pluginconfig.PluginMetadata
+	rpcService *rpc.CliRpcService
+}
+
+func newCommandHooks(deps commandregistry.Dependency, command string, args []string) *commandHooks {
+	hooks := &commandHooks{
+		deps:    deps,
+		command: command,
+		args:    args,
+	}
+
+	pluginConfig := pluginconfig.NewPluginConfig(func(err error) {
+		deps.UI.Warn(fmt.Sprintf("Error read/writing plugin config: %s, ", err.Error()))
+	})
+	hooks.pluginList = pluginConfig.Plugins()
+
+	return hooks
+}
+
+// runPre runs the pre hooks of the command and fails when one of them could
+// not be run or vetoed the command
+func (h *commandHooks) runPre() {
+	vetoes, err := h.run(plugin.HookContext{Hook: "pre-" + h.command})
+	if err != nil {
+		h.deps.UI.Failed(T("Plugin hook {{.Hook}} failed: {{.Error}}",
+			map[string]interface{}{
+				"Hook":  "pre-" + h.command,
+				"Error": err.Error(),
+			}))
+	}
+
+	for _, veto := range vetoes {
+		h.deps.UI.Failed(T("{{.Command}} was stopped by plugin {{.PluginName}}: {{.Message}}",
+			map[string]interface{}{
+				"Command":    h.command,
+				"PluginName": veto.PluginName,
+				"Message":    veto.Message,
+			}))
+	}
+}
+
+// execute calls run and then the post hooks of the command with its result,
+// including when run fails by panicking
+func (h *commandHooks) execute(run func() error) error {
+	defer func() {
+		if r := recover(); r != nil {
+			h.runPost(plugin.HookContext{Failed: true})
+			panic(r)
+		}
+	}()
+
+	err := run()
+	if err != nil {
+		h.runPost(plugin.HookContext{Failed: true, Error: err.Error()})
+	} else {
+		h.runPost(plugin.HookContext{})
+	}
+
+	return err
+}
+
+// runPost runs the post hooks of the command. The command has already run, so
+// a hook that could not be run or vetoed only produces a warning.
+func (h *commandHooks) runPost(context plugin.HookContext) {
+	context.Hook = "post-" + h.command
+
+	_, err := h.run(context)
+	if err != nil {
+		h.deps.UI.Warn(T("Plugin hook {{.Hook}} failed: {{.Error}}",
+			map[string]interface{}{
+				"Hook":  context.Hook,
+				"Error": err.Error(),
+			}))
+	}
+}
+
+func (h *commandHooks) run(context plugin.HookContext) ([]rpc.HookVeto, error) {
+	if len(rpc.PluginsWithHook(context.Hook, h.pluginList)) == 0 {
+		return nil, nil
+	}
+
+	if h.rpcService == nil {
+		rpcService, err := rpc.NewRpcService(h.deps.TeePrinter, h.deps.TeePrinter, h.deps.Config, h.deps.RepoLocator, rpc.NewCommandRunner(), h.deps.Logger, Writer)
+		if err != nil {
+			return nil, err
+		}
+		h.rpcService = rpcService
+	}
+
+	context.Command = h.command
+	context.Args = rpc.RedactArgs(h.args, commandregistry.Commands.FindCommand(h.command).MetaData())
+
+	return rpc.RunHooks(h.rpcService, context, h.pluginList)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/cloudfoundry/cli/cf/configuration/confighelpers"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/help"
 	. "github.com/cloudfoundry/cli/cf/i18n"
 	"github.com/cloudfoundry/cli/cf/net"
 	"github.com/cloudfoundry/cli/cf/panicprinter"
@@ -23,6 +24,8 @@ import (
 
 var cmdRegistry = commandregistry.Commands
 
+var profile *trace.Profile
+
 func main() {
 	traceEnv := os.Getenv("CF_TRACE")
 	traceLogger := trace.NewLogger(Writer, false, traceEnv, "")
@@ -43,6 +46,28 @@ func main() {
 
 	newArgs, isVerbose := handleVerbose(os.Args)
 	os.Args = newArgs
+
+	//handles `cf --context NAME COMMAND`
+	//the context is passed through the environment so plugins inherit it too
+	newArgs, contextName := handleContext(os.Args)
+	os.Args = newArgs
+	if contextName != "" {
+		os.Setenv("CF_CONTEXT", contextName)
+	}
+
+	//handles `cf --trace-file PATH COMMAND`
+	newArgs, traceFile := handleTraceFile(os.Args)
+	os.Args = newArgs
+	traceFormat := os.Getenv("CF_TRACE_FORMAT")
+
+	//handles `cf --timings COMMAND`
+	newArgs, showTimings := handleTimings(os.Args)
+	os.Args = newArgs
+	if showTimings {
+		profile = trace.NewProfile()
+		trace.SetProfile(profile)
+	}
+
 	traceLogger = trace.NewLogger(Writer, isVerbose, traceEnv, "")
 
 	errFunc := func(err error) {
@@ -62,12 +87,22 @@ func main() {
 
 	traceConfigVal := config.Trace()
 
-	traceLogger = trace.NewLogger(Writer, isVerbose, traceEnv, traceConfigVal)
+	traceLogger = trace.NewFormattedLogger(Writer, isVerbose, traceFormat, traceEnv, traceConfigVal, traceFile)
+	if profile != nil {
+		traceLogger = trace.CombinePrinters([]trace.Printer{traceLogger, profile})
+	}
 
 	deps := commandregistry.NewDependency(Writer, traceLogger)
 	defer handlePanics(deps.TeePrinter, deps.Logger)
 	defer deps.Config.Close()
 
+	if contextName := os.Getenv("CF_CONTEXT"); contextName != "" {
+		err = deps.Config.OverrideContext(contextName)
+		if err != nil {
+			deps.UI.Failed(err.Error())
+		}
+	}
+
 	//handle `cf --build`
 	if len(os.Args) == 2 && (os.Args[1] == "--build" || os.Args[1] == "-b") {
 		deps.UI.Say(T("{{.CFName}} was built with Go version: {{.GoVersion}}",
@@ -115,13 +150,19 @@ func main() {
 			}
 		}
 
-		err = cmd.Execute(flagContext)
+		hooks := newCommandHooks(deps, meta.Name, cmdArgs)
+		hooks.runPre()
+
+		err = hooks.execute(func() error {
+			return cmd.Execute(flagContext)
+		})
 		if err != nil {
 			ui := terminal.NewUI(os.Stdin, Writer, terminal.NewTeePrinter(Writer), traceLogger)
 			ui.Failed(err.Error())
 		}
 
 		warningsCollector.PrintWarnings()
+		printTimings()
 
 		os.Exit(0)
 	}
@@ -138,12 +179,24 @@ func main() {
 	})
 	pluginList := pluginConfig.Plugins()
 
-	ran := rpc.RunMethodIfExists(rpcService, os.Args[1:], pluginList)
+	if pluginCmd, found := pluginconfig.FindCommand(pluginList, os.Args[1]); found {
+		err = pluginconfig.ValidateArgs(pluginCmd, os.Args[2:])
+		if err != nil {
+			deps.UI.Failed(T("Incorrect Usage") + "\n\n" + err.Error() + "\n\n" + help.PluginCommandUsage(pluginCmd))
+		}
+	}
+
+	ran, exitStatus := rpc.RunMethodIfExists(rpcService, os.Args[1:], pluginList)
 	if !ran {
 		deps.UI.Say("'" + os.Args[1] + T("' is not a registered command. See 'cf help'"))
 		os.Exit(1)
 	}
 
+	warningsCollector.PrintWarnings()
+	if exitStatus != 0 {
+		os.Exit(exitStatus)
+	}
+
 }
 
 func handlePanics(printer terminal.Printer, logger trace.Printer) {
@@ -154,6 +207,7 @@ func handlePanics(printer terminal.Printer, logger trace.Printer) {
 
 	err := recover()
 	panicprinter.DisplayCrashDialog(err, commandArgs, stackTrace)
+	printTimings()
 
 	if err != nil {
 		os.Exit(1)
@@ -212,3 +266,50 @@ func handleVerbose(args []string) ([]string, bool) {
 
 	return args, verbose
 }
+
+func handleTimings(args []string) ([]string, bool) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if arg == "--timings" {
+			return append(append([]string{}, args[:i]...), args[i+1:]...), true
+		}
+	}
+
+	return args, false
+}
+
+func printTimings() {
+	if profile != nil {
+		fmt.Fprintln(os.Stderr)
+		profile.WriteSummary(os.Stderr)
+	}
+}
+
+func handleContext(args []string) ([]string, string) {
+	return extractGlobalOption(args, "--context")
+}
+
+func handleTraceFile(args []string) ([]string, string) {
+	return extractGlobalOption(args, "--trace-file")
+}
+
+func extractGlobalOption(args []string, name string) ([]string, string) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if arg == name && i+1 < len(args) {
+			return append(append([]string{}, args[:i]...), args[i+2:]...), args[i+1]
+		}
+
+		if strings.HasPrefix(arg, name+"=") {
+			return append(append([]string{}, args[:i]...), args[i+1:]...), strings.TrimPrefix(arg, name+"=")
+		}
+	}
+
+	return args, ""
+}
@@ -22,6 +22,9 @@ func TestMain(t *testing.T) {
 	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "call_core_cmd")
 	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "input")
 	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "panics")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "hooks")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "exit_status")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "fixtures", "plugins"), "signals")
 
 	//compile plugin examples to ensure they're up to date
 	pluginbuilder.BuildTestBinary(filepath.Join("..", "plugin_examples"), "basic_plugin")
@@ -2,9 +2,11 @@ package main_test
 
 import (
 	"bufio"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -114,6 +116,14 @@ var _ = Describe("main", func() {
 		})
 	})
 
+	Describe("Overrides the login context with --context", func() {
+		It("fails when the context has not been saved", func() {
+			output := Cf("--context", "not-a-saved-context", "target").Wait(5 * time.Second)
+			Eventually(output.Out.Contents).Should(ContainSubstring("Context 'not-a-saved-context' not found"))
+			Eventually(output).Should(Exit(1))
+		})
+	})
+
 	Describe("Shows debug information with -b or --build", func() {
 		It("prints the golang version if '--build' flag is provided", func() {
 			output := Cf("--build").Wait(1 * time.Second)
@@ -227,6 +237,19 @@ var _ = Describe("main", func() {
 			Eventually(output.Out).Should(Say("FOO"))
 		})
 
+		It("Can call a plugin command via one of its additional aliases", func() {
+			output := Cf("say-it", "foo").Wait(3 * time.Second)
+			Eventually(output.Out).Should(Say("foo"))
+		})
+
+		It("Rejects flags a plugin command did not declare", func() {
+			output := Cf("my-say", "foo", "--quiet").Wait(3 * time.Second)
+			Eventually(output.Out).Should(Say("Incorrect Usage"))
+			Eventually(output.Out).Should(Say("Invalid flag: --quiet"))
+			Eventually(output.Out).Should(Say("--loud\\s+Say it loud"))
+			Eventually(output).Should(Exit(1))
+		})
+
 		It("Calls a plugin that calls core commands", func() {
 			output := Cf("awesomeness").Wait(3 * time.Second)
 			Eventually(output.Out).Should(Say("my-say")) //look for another plugin
@@ -256,6 +279,75 @@ var _ = Describe("main", func() {
 			session := Cf("exit1").Wait(5 * time.Second)
 			Eventually(session).Should(Exit(1))
 		})
+
+		It("forwards SIGTERM to a plugin and lets it clean up", func() {
+			if runtime.GOOS == "windows" {
+				Skip("Signals cannot be sent on windows")
+			}
+
+			session := Cf("wait-for-signal")
+			Eventually(session.Out, 5*time.Second).Should(Say("waiting for a signal"))
+
+			session.Terminate()
+			Eventually(session.Out).Should(Say("cleaning up after terminated"))
+			Eventually(session).Should(Exit(7))
+		})
+
+		It("forwards SIGINT to a plugin that is not reading from a terminal", func() {
+			if runtime.GOOS == "windows" {
+				Skip("Signals cannot be sent on windows")
+			}
+
+			session := Cf("wait-for-signal")
+			Eventually(session.Out, 5*time.Second).Should(Say("waiting for a signal"))
+
+			session.Interrupt()
+			Eventually(session.Out).Should(Say("cleaning up after interrupt"))
+			Eventually(session).Should(Exit(7))
+		})
+
+		It("kills a plugin that is still running at a second signal", func() {
+			if runtime.GOOS == "windows" {
+				Skip("Signals cannot be sent on windows")
+			}
+
+			session := Cf("wait-for-signal", "--ignore")
+			Eventually(session.Out, 5*time.Second).Should(Say("waiting for a signal"))
+
+			session.Terminate()
+			Consistently(session, 500*time.Millisecond).ShouldNot(Exit())
+			session.Terminate()
+			Eventually(session).Should(Exit(128 + 9))
+		})
+
+		It("exits with the exit status of the plugin and shows its stderr", func() {
+			session := Cf("exit-status", "3").Wait(5 * time.Second)
+			Eventually(session.Err).Should(Say("exiting with 3"))
+			Eventually(session).Should(Exit(3))
+		})
+	})
+
+	Describe("Plugin hooks", func() {
+		AfterEach(func() {
+			os.Unsetenv("HOOKS_VETO")
+		})
+
+		It("runs the pre and post hooks around a core command", func() {
+			session := Cf("plugins").Wait(5 * time.Second)
+			Eventually(session.Out).Should(Say("pre-plugins hook called with args"))
+			Eventually(session.Out).Should(Say("Listing Installed Plugins"))
+			Eventually(session.Out).Should(Say("post-plugins hook called, failed: false"))
+			Eventually(session).Should(Exit(0))
+		})
+
+		It("stops the command when a pre hook vetoes it", func() {
+			os.Setenv("HOOKS_VETO", "plugins are hidden")
+
+			session := Cf("plugins").Wait(5 * time.Second)
+			Eventually(session.Out).Should(Say("plugins was stopped by plugin Hooks: plugins are hidden"))
+			Consistently(session.Out).ShouldNot(Say("post-plugins hook called"))
+			Eventually(session).Should(Exit(1))
+		})
 	})
 
 })
@@ -283,11 +375,29 @@ func CfWithIo(command string, args string) *Session {
 	return session
 }
 
+// CfWith_CF_HOME runs cf against a copy of the config in cfHome, so that
+// the config cf writes never lands in fixture directories.
 func CfWith_CF_HOME(cfHome string, args ...string) *Session {
+	home, err := ioutil.TempDir("", "cf-home")
+	Expect(err).NotTo(HaveOccurred())
+
+	config, err := ioutil.ReadFile(filepath.Join(cfHome, ".cf", "config.json"))
+	if err == nil {
+		Expect(os.Mkdir(filepath.Join(home, ".cf"), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(home, ".cf", "config.json"), config, 0600)).To(Succeed())
+	} else {
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	}
+
 	cmd := exec.Command(buildPath, args...)
-	cmd.Env = append(cmd.Env, "CF_HOME="+cfHome)
+	cmd.Env = append(cmd.Env, "CF_HOME="+home)
 	session, err := Start(cmd, GinkgoWriter, GinkgoWriter)
 	Expect(err).NotTo(HaveOccurred())
 
+	go func() {
+		<-session.Exited
+		os.RemoveAll(home)
+	}()
+
 	return session
 }
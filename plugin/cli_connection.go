@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/rpc"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -13,19 +14,31 @@ import (
 
 type cliConnection struct {
 	cliServerPort string
+	token         string
 }
 
 func NewCliConnection(cliServerPort string) *cliConnection {
 	return &cliConnection{
 		cliServerPort: cliServerPort,
+		token:         os.Getenv(RpcTokenEnvVar),
 	}
 }
 
 func (c *cliConnection) withClientDo(f func(client *rpc.Client) error) error {
-	client, err := rpc.Dial("tcp", "127.0.0.1:"+c.cliServerPort)
+	conn, err := net.Dial("tcp", "127.0.0.1:"+c.cliServerPort)
 	if err != nil {
 		return err
 	}
+
+	if c.token != "" {
+		_, err = conn.Write([]byte(c.token))
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	client := rpc.NewClient(conn)
 	defer client.Close()
 
 	return f(client)
@@ -65,6 +78,34 @@ func (c *cliConnection) isMinCliVersion(version string) bool {
 	return result
 }
 
+func (c *cliConnection) getHookContext() HookContext {
+	var result HookContext
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetHookContext", "", &result)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return result
+}
+
+func (c *cliConnection) vetoCommand(message string) {
+	var success bool
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.VetoCommand", message, &success)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
 func (c *cliConnection) CliCommandWithoutTerminalOutput(args ...string) ([]string, error) {
 	return c.callCliCommand(true, args...)
 }
@@ -381,3 +422,207 @@ func (c *cliConnection) GetService(serviceInstance string) (plugin_models.GetSer
 
 	return result, err
 }
+
+func (c *cliConnection) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	var result []plugin_models.GetRoutes_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetRoutes", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	var result []plugin_models.GetDomains_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetDomains", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetAppInstances(appName string) ([]plugin_models.GetAppInstances_Model, error) {
+	var result []plugin_models.GetAppInstances_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetAppInstances", appName, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetAppEvents(appName string) ([]plugin_models.GetAppEvents_Model, error) {
+	var result []plugin_models.GetAppEvents_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetAppEvents", appName, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetServiceKeys(serviceInstance string) ([]plugin_models.GetServiceKeys_Model, error) {
+	var result []plugin_models.GetServiceKeys_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetServiceKeys", serviceInstance, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	var result []plugin_models.GetSecurityGroups_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetSecurityGroups", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetQuotas() ([]plugin_models.GetQuotas_Model, error) {
+	var result []plugin_models.GetQuotas_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetQuotas", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error) {
+	var result []plugin_models.GetSpaceQuotas_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetSpaceQuotas", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	var result []plugin_models.GetBuildpacks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) CurlCC(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	return c.curl("CliRpcCmd.CurlCC", method, path, headers, body)
+}
+
+func (c *cliConnection) CurlUAA(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	return c.curl("CliRpcCmd.CurlUAA", method, path, headers, body)
+}
+
+func (c *cliConnection) CurlRoutingAPI(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	return c.curl("CliRpcCmd.CurlRoutingAPI", method, path, headers, body)
+}
+
+func (c *cliConnection) curl(serviceMethod string, method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	var result plugin_models.CurlResponse_Model
+
+	headerLines := []string{}
+	for key, value := range headers {
+		headerLines = append(headerLines, key+": "+value)
+	}
+
+	cmdArgs := []string{method, path, strings.Join(headerLines, "\n"), body}
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call(serviceMethod, cmdArgs, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetRecentLogs(appName string) ([]plugin_models.LogMessage_Model, error) {
+	var result []plugin_models.LogMessage_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetRecentLogs", appName, &result)
+	})
+
+	return result, err
+}
+
+// RequestCleanupTime asks the CLI to wait up to cleanupTime for the plugin
+// to exit after forwarding it SIGINT or SIGTERM, before killing it
+func (c *cliConnection) RequestCleanupTime(cleanupTime time.Duration) error {
+	var success bool
+
+	return c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.RequestCleanupTime", cleanupTime, &success)
+	})
+}
+
+// TailLogs delivers the logs of the app until the stream ends or stop is
+// closed. Both returned channels are closed when the stream ends; errs
+// receives at most one error first.
+func (c *cliConnection) TailLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage_Model, <-chan error, error) {
+	var started bool
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.StartLogStream", appName, &started)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := make(chan plugin_models.LogMessage_Model)
+	errs := make(chan error, 1)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-stop:
+			var stopped bool
+			_ = c.withClientDo(func(client *rpc.Client) error {
+				return client.Call("CliRpcCmd.StopLogStream", "", &stopped)
+			})
+		case <-done:
+		}
+	}()
+
+	go func() {
+		defer close(errs)
+		defer close(messages)
+		defer close(done)
+
+		for {
+			var batch plugin_models.TailLogs_Model
+
+			err := c.withClientDo(func(client *rpc.Client) error {
+				return client.Call("CliRpcCmd.NextLogMessages", "", &batch)
+			})
+			if err != nil {
+				select {
+				case <-stop:
+				default:
+					errs <- err
+				}
+				return
+			}
+
+			for _, msg := range batch.Messages {
+				select {
+				case messages <- msg:
+				case <-stop:
+					return
+				}
+			}
+
+			if batch.Closed {
+				return
+			}
+		}
+	}()
+
+	return messages, errs, nil
+}
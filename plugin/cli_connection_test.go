@@ -0,0 +1,165 @@
+package plugin_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/cloudfoundry/cli/plugin"
+	"github.com/cloudfoundry/cli/plugin/models"
+	"github.com/cloudfoundry/cli/testhelpers/rpcserver"
+	"github.com/cloudfoundry/cli/testhelpers/rpcserver/rpcserverfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CliConnection", func() {
+	var (
+		rpcHandlers *rpcserverfakes.FakeHandlers
+		ts          *rpcserver.TestServer
+		connection  plugin.CliConnection
+		stop        chan struct{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		rpcHandlers = new(rpcserverfakes.FakeHandlers)
+		ts, err = rpcserver.NewTestRPCServer(rpcHandlers)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = ts.Start()
+		Expect(err).NotTo(HaveOccurred())
+
+		connection = plugin.NewCliConnection(ts.Port())
+		stop = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		ts.Stop()
+	})
+
+	Describe(".RequestCleanupTime", func() {
+		It("sends the requested cleanup time to the CLI", func() {
+			err := connection.RequestCleanupTime(30 * time.Second)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(rpcHandlers.RequestCleanupTimeCallCount()).To(Equal(1))
+			cleanupTime, _ := rpcHandlers.RequestCleanupTimeArgsForCall(0)
+			Expect(cleanupTime).To(Equal(30 * time.Second))
+		})
+
+		It("returns the error of the CLI", func() {
+			rpcHandlers.RequestCleanupTimeReturns(errors.New("Plugins can request at most 5m0s of cleanup time"))
+
+			err := connection.RequestCleanupTime(time.Hour)
+			Expect(err).To(MatchError("Plugins can request at most 5m0s of cleanup time"))
+		})
+	})
+
+	Describe(".TailLogs", func() {
+		It("delivers the messages of every batch until the stream is closed", func() {
+			batches := []plugin_models.TailLogs_Model{
+				{Messages: []plugin_models.LogMessage_Model{{Message: "Staging..."}, {Message: "Downloading buildpack"}}},
+				{},
+				{Messages: []plugin_models.LogMessage_Model{{Message: "Staging complete"}}, Closed: true},
+			}
+			rpcHandlers.NextLogMessagesStub = func(_ string, retVal *plugin_models.TailLogs_Model) error {
+				*retVal = batches[0]
+				batches = batches[1:]
+				return nil
+			}
+
+			messages, errs, err := connection.TailLogs("my-app", stop)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rpcHandlers.StartLogStreamCallCount()).To(Equal(1))
+			appName, _ := rpcHandlers.StartLogStreamArgsForCall(0)
+			Expect(appName).To(Equal("my-app"))
+
+			received := []string{}
+			for msg := range messages {
+				received = append(received, msg.Message)
+			}
+			Expect(received).To(Equal([]string{"Staging...", "Downloading buildpack", "Staging complete"}))
+			Eventually(errs).Should(BeClosed())
+		})
+
+		It("returns the error when the stream cannot be started", func() {
+			rpcHandlers.StartLogStreamReturns(errors.New("App my-app not found"))
+
+			_, _, err := connection.TailLogs("my-app", stop)
+			Expect(err).To(MatchError("App my-app not found"))
+			Expect(rpcHandlers.NextLogMessagesCallCount()).To(Equal(0))
+		})
+
+		It("sends the error that ended the stream", func() {
+			rpcHandlers.NextLogMessagesReturns(errors.New("connection lost"))
+
+			messages, errs, err := connection.TailLogs("my-app", stop)
+			Expect(err).NotTo(HaveOccurred())
+
+			var streamErr error
+			Eventually(errs).Should(Receive(&streamErr))
+			Expect(streamErr).To(MatchError("connection lost"))
+			Eventually(messages).Should(BeClosed())
+		})
+
+		It("stops the stream when stop is closed", func() {
+			stopped := make(chan struct{})
+			rpcHandlers.StopLogStreamStub = func(_ string, _ *bool) error {
+				close(stopped)
+				return nil
+			}
+			rpcHandlers.NextLogMessagesStub = func(_ string, retVal *plugin_models.TailLogs_Model) error {
+				select {
+				case <-stopped:
+					retVal.Closed = true
+				default:
+				}
+				return nil
+			}
+
+			messages, errs, err := connection.TailLogs("my-app", stop)
+			Expect(err).NotTo(HaveOccurred())
+
+			close(stop)
+
+			Eventually(rpcHandlers.StopLogStreamCallCount).Should(Equal(1))
+			Eventually(messages).Should(BeClosed())
+			Eventually(errs).Should(BeClosed())
+		})
+	})
+
+	Describe("authentication", func() {
+		var listener net.Listener
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			listener.Close()
+			os.Unsetenv(plugin.RpcTokenEnvVar)
+		})
+
+		It("sends the token of the CLI before every call", func() {
+			os.Setenv(plugin.RpcTokenEnvVar, "the-token")
+			_, port, _ := net.SplitHostPort(listener.Addr().String())
+			connection := plugin.NewCliConnection(port)
+
+			go connection.RequestCleanupTime(time.Second)
+
+			conn, err := listener.Accept()
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			received := make([]byte, len("the-token"))
+			_, err = io.ReadFull(conn, received)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(received)).To(Equal("the-token"))
+		})
+	})
+})
@@ -0,0 +1,7 @@
+package plugin_models
+
+type CurlResponse_Model struct {
+	StatusCode int
+	Headers    map[string][]string
+	Body       string
+}
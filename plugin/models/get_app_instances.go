@@ -0,0 +1,15 @@
+package plugin_models
+
+import "time"
+
+type GetAppInstances_Model struct {
+	Index     int
+	State     string
+	Details   string
+	Since     time.Time
+	CpuUsage  float64 // percentage
+	DiskQuota int64   // in bytes
+	DiskUsage int64
+	MemQuota  int64
+	MemUsage  int64
+}
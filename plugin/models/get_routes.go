@@ -0,0 +1,32 @@
+package plugin_models
+
+type GetRoutes_Model struct {
+	Guid            string
+	Host            string
+	Path            string
+	Port            int
+	Domain          GetRoutes_DomainFields
+	Space           GetRoutes_SpaceFields
+	Apps            []GetRoutes_AppFields
+	ServiceInstance GetRoutes_ServiceInstanceFields
+}
+
+type GetRoutes_DomainFields struct {
+	Guid string
+	Name string
+}
+
+type GetRoutes_SpaceFields struct {
+	Guid string
+	Name string
+}
+
+type GetRoutes_AppFields struct {
+	Guid string
+	Name string
+}
+
+type GetRoutes_ServiceInstanceFields struct {
+	Guid string
+	Name string
+}
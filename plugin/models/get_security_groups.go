@@ -0,0 +1,14 @@
+package plugin_models
+
+type GetSecurityGroups_Model struct {
+	Guid   string
+	Name   string
+	Rules  []map[string]interface{}
+	Spaces []GetSecurityGroups_Space
+}
+
+type GetSecurityGroups_Space struct {
+	Guid             string
+	Name             string
+	OrganizationName string
+}
@@ -0,0 +1,14 @@
+package plugin_models
+
+type GetSpaceQuotas_Model struct {
+	Guid                    string
+	Name                    string
+	OrgGuid                 string
+	MemoryLimit             int64 // in Megabytes
+	InstanceMemoryLimit     int64 // in Megabytes
+	RoutesLimit             int
+	ServicesLimit           int
+	NonBasicServicesAllowed bool
+	AppInstanceLimit        int
+	ReservedRoutePorts      string
+}
@@ -0,0 +1,11 @@
+package plugin_models
+
+import "encoding/gob"
+
+// Service key credentials and security group rules are free-form JSON, so the
+// nested values they may hold have to be known to gob on both ends of the RPC
+// connection.
+func init() {
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
@@ -0,0 +1,16 @@
+package plugin_models
+
+import "time"
+
+type LogMessage_Model struct {
+	Message        string
+	MessageType    string // OUT or ERR
+	Timestamp      time.Time
+	SourceType     string
+	SourceInstance string
+}
+
+type TailLogs_Model struct {
+	Messages []LogMessage_Model
+	Closed   bool
+}
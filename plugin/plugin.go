@@ -1,6 +1,10 @@
 package plugin
 
-import "github.com/cloudfoundry/cli/plugin/models"
+import (
+	"time"
+
+	"github.com/cloudfoundry/cli/plugin/models"
+)
 
 /**
 	Command interface needs to be implemented for a runnable plugin of `cf`
@@ -42,8 +46,27 @@ type CliConnection interface {
 	GetService(string) (plugin_models.GetService_Model, error)
 	GetOrg(string) (plugin_models.GetOrg_Model, error)
 	GetSpace(string) (plugin_models.GetSpace_Model, error)
+	GetRoutes() ([]plugin_models.GetRoutes_Model, error)
+	GetDomains() ([]plugin_models.GetDomains_Model, error)
+	GetAppInstances(string) ([]plugin_models.GetAppInstances_Model, error)
+	GetAppEvents(string) ([]plugin_models.GetAppEvents_Model, error)
+	GetServiceKeys(string) ([]plugin_models.GetServiceKeys_Model, error)
+	GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error)
+	GetQuotas() ([]plugin_models.GetQuotas_Model, error)
+	GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error)
+	GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error)
+	CurlCC(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	CurlUAA(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	CurlRoutingAPI(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	GetRecentLogs(string) ([]plugin_models.LogMessage_Model, error)
+	TailLogs(string, <-chan struct{}) (<-chan plugin_models.LogMessage_Model, <-chan error, error)
+	RequestCleanupTime(time.Duration) error
 }
 
+// RpcTokenEnvVar is the environment variable the CLI passes the token of its
+// RPC server in. Every connection to the server sends the token first.
+const RpcTokenEnvVar = "CF_PLUGIN_RPC_TOKEN"
+
 type VersionType struct {
 	Major int
 	Minor int
@@ -55,6 +78,7 @@ type PluginMetadata struct {
 	Version       VersionType
 	MinCliVersion VersionType
 	Commands      []Command
+	Hooks         []Hook
 }
 
 type Usage struct {
@@ -65,6 +89,79 @@ type Usage struct {
 type Command struct {
 	Name         string
 	Alias        string
+	Aliases      []string //Additional aliases, next to Alias
 	HelpText     string
-	UsageDetails Usage //Detail usage to be displayed in `cf help <cmd>`
+	UsageDetails Usage  //Detail usage to be displayed in `cf help <cmd>`
+	Flags        []Flag //Typed flags, checked by the CLI before the plugin is run
+}
+
+// AllAliases returns Alias followed by Aliases
+func (c Command) AllAliases() []string {
+	aliases := []string{}
+	if c.Alias != "" {
+		aliases = append(aliases, c.Alias)
+	}
+	for _, alias := range c.Aliases {
+		if alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// Matches reports whether name is the name or one of the aliases of the command
+func (c Command) Matches(name string) bool {
+	if c.Name == name {
+		return true
+	}
+	for _, alias := range c.AllAliases() {
+		if alias == name {
+			return true
+		}
+	}
+	return false
+}
+
+type FlagType string
+
+const (
+	FlagTypeBool        FlagType = "bool"
+	FlagTypeString      FlagType = "string"
+	FlagTypeInt         FlagType = "int"
+	FlagTypeStringSlice FlagType = "string-slice"
+)
+
+/**
+	A flag of a plugin command. Once a command declares Flags the CLI rejects
+	any other flag, and flags that are Required but missing, before the plugin
+	is run. The args are still passed to the plugin as they were typed.
+**/
+type Flag struct {
+	Name      string
+	ShortName string
+	Type      FlagType
+	Default   string
+	Required  bool
+	Usage     string
+}
+
+type Hook struct {
+	Name string //"pre-" or "post-" followed by the name of a core command, e.g. "pre-push"
+}
+
+type HookContext struct {
+	Hook    string
+	Command string
+	Args    []string
+	Failed  bool   //post hooks only, whether the command failed
+	Error   string //post hooks only, the error the command returned
+}
+
+/**
+	Plugins that register Hooks in their metadata implement HookRunner.
+	An error returned from a pre hook stops the command, and its message
+	is shown to the user.
+**/
+type HookRunner interface {
+	RunHook(cliConnection CliConnection, context HookContext) error
 }
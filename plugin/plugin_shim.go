@@ -11,6 +11,7 @@ import (
 	* os.Args[1] port CF_CLI rpc server is running on
 	* os.Args[2] **OPTIONAL**
 		* SendMetadata - used to fetch the plugin metadata
+		* RunHook - used to run a hook registered in the plugin metadata
 **/
 func Start(cmd Plugin) {
 	cliConnection := NewCliConnection(os.Args[1])
@@ -18,6 +19,8 @@ func Start(cmd Plugin) {
 	cliConnection.pingCLI()
 	if isMetadataRequest(os.Args) {
 		cliConnection.sendPluginMetadataToCliServer(cmd.GetMetadata())
+	} else if isHookRequest(os.Args) {
+		runHook(cmd, cliConnection)
 	} else {
 		if version := MinCliVersionStr(cmd.GetMetadata().MinCliVersion); version != "" {
 			ok := cliConnection.isMinCliVersion(version)
@@ -35,6 +38,22 @@ func isMetadataRequest(args []string) bool {
 	return len(args) == 3 && args[2] == "SendMetadata"
 }
 
+func isHookRequest(args []string) bool {
+	return len(args) == 3 && args[2] == "RunHook"
+}
+
+func runHook(cmd Plugin, cliConnection *cliConnection) {
+	hookRunner, ok := cmd.(HookRunner)
+	if !ok {
+		return
+	}
+
+	err := hookRunner.RunHook(cliConnection, cliConnection.getHookContext())
+	if err != nil {
+		cliConnection.vetoCommand(err.Error())
+	}
+}
+
 func MinCliVersionStr(version VersionType) string {
 	if version.Major == 0 && version.Minor == 0 && version.Build == 0 {
 		return ""
@@ -3,6 +3,7 @@ package pluginfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -216,6 +217,139 @@ type FakeCliConnection struct {
 		result1 plugin_models.GetSpace_Model
 		result2 error
 	}
+	GetRoutesStub        func() ([]plugin_models.GetRoutes_Model, error)
+	getRoutesMutex       sync.RWMutex
+	getRoutesArgsForCall []struct{}
+	getRoutesReturns     struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}
+	GetDomainsStub        func() ([]plugin_models.GetDomains_Model, error)
+	getDomainsMutex       sync.RWMutex
+	getDomainsArgsForCall []struct{}
+	getDomainsReturns     struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}
+	GetAppInstancesStub        func(string) ([]plugin_models.GetAppInstances_Model, error)
+	getAppInstancesMutex       sync.RWMutex
+	getAppInstancesArgsForCall []struct {
+		arg1 string
+	}
+	getAppInstancesReturns struct {
+		result1 []plugin_models.GetAppInstances_Model
+		result2 error
+	}
+	GetAppEventsStub        func(string) ([]plugin_models.GetAppEvents_Model, error)
+	getAppEventsMutex       sync.RWMutex
+	getAppEventsArgsForCall []struct {
+		arg1 string
+	}
+	getAppEventsReturns struct {
+		result1 []plugin_models.GetAppEvents_Model
+		result2 error
+	}
+	GetServiceKeysStub        func(string) ([]plugin_models.GetServiceKeys_Model, error)
+	getServiceKeysMutex       sync.RWMutex
+	getServiceKeysArgsForCall []struct {
+		arg1 string
+	}
+	getServiceKeysReturns struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}
+	GetSecurityGroupsStub        func() ([]plugin_models.GetSecurityGroups_Model, error)
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct{}
+	getSecurityGroupsReturns     struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}
+	GetQuotasStub        func() ([]plugin_models.GetQuotas_Model, error)
+	getQuotasMutex       sync.RWMutex
+	getQuotasArgsForCall []struct{}
+	getQuotasReturns     struct {
+		result1 []plugin_models.GetQuotas_Model
+		result2 error
+	}
+	GetSpaceQuotasStub        func() ([]plugin_models.GetSpaceQuotas_Model, error)
+	getSpaceQuotasMutex       sync.RWMutex
+	getSpaceQuotasArgsForCall []struct{}
+	getSpaceQuotasReturns     struct {
+		result1 []plugin_models.GetSpaceQuotas_Model
+		result2 error
+	}
+	GetBuildpacksStub        func() ([]plugin_models.GetBuildpacks_Model, error)
+	getBuildpacksMutex       sync.RWMutex
+	getBuildpacksArgsForCall []struct{}
+	getBuildpacksReturns     struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}
+	CurlCCStub        func(method, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	curlCCMutex       sync.RWMutex
+	curlCCArgsForCall []struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}
+	curlCCReturns struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}
+	CurlUAAStub        func(method, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	curlUAAMutex       sync.RWMutex
+	curlUAAArgsForCall []struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}
+	curlUAAReturns struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}
+	CurlRoutingAPIStub        func(method, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error)
+	curlRoutingAPIMutex       sync.RWMutex
+	curlRoutingAPIArgsForCall []struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}
+	curlRoutingAPIReturns struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}
+	GetRecentLogsStub        func(string) ([]plugin_models.LogMessage_Model, error)
+	getRecentLogsMutex       sync.RWMutex
+	getRecentLogsArgsForCall []struct {
+		arg1 string
+	}
+	getRecentLogsReturns struct {
+		result1 []plugin_models.LogMessage_Model
+		result2 error
+	}
+	TailLogsStub        func(string, <-chan struct{}) (<-chan plugin_models.LogMessage_Model, <-chan error, error)
+	tailLogsMutex       sync.RWMutex
+	tailLogsArgsForCall []struct {
+		arg1 string
+		arg2 <-chan struct{}
+	}
+	tailLogsReturns struct {
+		result1 <-chan plugin_models.LogMessage_Model
+		result2 <-chan error
+		result3 error
+	}
+	RequestCleanupTimeStub        func(time.Duration) error
+	requestCleanupTimeMutex       sync.RWMutex
+	requestCleanupTimeArgsForCall []struct {
+		arg1 time.Duration
+	}
+	requestCleanupTimeReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeCliConnection) CliCommandWithoutTerminalOutput(args ...string) ([]string, error) {
@@ -959,4 +1093,461 @@ func (fake *FakeCliConnection) GetSpaceReturns(result1 plugin_models.GetSpace_Mo
 	}{result1, result2}
 }
 
+func (fake *FakeCliConnection) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	fake.getRoutesMutex.Lock()
+	fake.getRoutesArgsForCall = append(fake.getRoutesArgsForCall, struct{}{})
+	fake.getRoutesMutex.Unlock()
+	if fake.GetRoutesStub != nil {
+		return fake.GetRoutesStub()
+	} else {
+		return fake.getRoutesReturns.result1, fake.getRoutesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetRoutesCallCount() int {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return len(fake.getRoutesArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetRoutesReturns(result1 []plugin_models.GetRoutes_Model, result2 error) {
+	fake.GetRoutesStub = nil
+	fake.getRoutesReturns = struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	fake.getDomainsMutex.Lock()
+	fake.getDomainsArgsForCall = append(fake.getDomainsArgsForCall, struct{}{})
+	fake.getDomainsMutex.Unlock()
+	if fake.GetDomainsStub != nil {
+		return fake.GetDomainsStub()
+	} else {
+		return fake.getDomainsReturns.result1, fake.getDomainsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetDomainsCallCount() int {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return len(fake.getDomainsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetDomainsReturns(result1 []plugin_models.GetDomains_Model, result2 error) {
+	fake.GetDomainsStub = nil
+	fake.getDomainsReturns = struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetAppInstances(arg1 string) ([]plugin_models.GetAppInstances_Model, error) {
+	fake.getAppInstancesMutex.Lock()
+	fake.getAppInstancesArgsForCall = append(fake.getAppInstancesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getAppInstancesMutex.Unlock()
+	if fake.GetAppInstancesStub != nil {
+		return fake.GetAppInstancesStub(arg1)
+	} else {
+		return fake.getAppInstancesReturns.result1, fake.getAppInstancesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetAppInstancesCallCount() int {
+	fake.getAppInstancesMutex.RLock()
+	defer fake.getAppInstancesMutex.RUnlock()
+	return len(fake.getAppInstancesArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetAppInstancesArgsForCall(i int) string {
+	fake.getAppInstancesMutex.RLock()
+	defer fake.getAppInstancesMutex.RUnlock()
+	return fake.getAppInstancesArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetAppInstancesReturns(result1 []plugin_models.GetAppInstances_Model, result2 error) {
+	fake.GetAppInstancesStub = nil
+	fake.getAppInstancesReturns = struct {
+		result1 []plugin_models.GetAppInstances_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetAppEvents(arg1 string) ([]plugin_models.GetAppEvents_Model, error) {
+	fake.getAppEventsMutex.Lock()
+	fake.getAppEventsArgsForCall = append(fake.getAppEventsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getAppEventsMutex.Unlock()
+	if fake.GetAppEventsStub != nil {
+		return fake.GetAppEventsStub(arg1)
+	} else {
+		return fake.getAppEventsReturns.result1, fake.getAppEventsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetAppEventsCallCount() int {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return len(fake.getAppEventsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetAppEventsArgsForCall(i int) string {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return fake.getAppEventsArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetAppEventsReturns(result1 []plugin_models.GetAppEvents_Model, result2 error) {
+	fake.GetAppEventsStub = nil
+	fake.getAppEventsReturns = struct {
+		result1 []plugin_models.GetAppEvents_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetServiceKeys(arg1 string) ([]plugin_models.GetServiceKeys_Model, error) {
+	fake.getServiceKeysMutex.Lock()
+	fake.getServiceKeysArgsForCall = append(fake.getServiceKeysArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getServiceKeysMutex.Unlock()
+	if fake.GetServiceKeysStub != nil {
+		return fake.GetServiceKeysStub(arg1)
+	} else {
+		return fake.getServiceKeysReturns.result1, fake.getServiceKeysReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetServiceKeysCallCount() int {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return len(fake.getServiceKeysArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetServiceKeysArgsForCall(i int) string {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return fake.getServiceKeysArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetServiceKeysReturns(result1 []plugin_models.GetServiceKeys_Model, result2 error) {
+	fake.GetServiceKeysStub = nil
+	fake.getServiceKeysReturns = struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	fake.getSecurityGroupsMutex.Lock()
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct{}{})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub()
+	} else {
+		return fake.getSecurityGroupsReturns.result1, fake.getSecurityGroupsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetSecurityGroupsReturns(result1 []plugin_models.GetSecurityGroups_Model, result2 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetQuotas() ([]plugin_models.GetQuotas_Model, error) {
+	fake.getQuotasMutex.Lock()
+	fake.getQuotasArgsForCall = append(fake.getQuotasArgsForCall, struct{}{})
+	fake.getQuotasMutex.Unlock()
+	if fake.GetQuotasStub != nil {
+		return fake.GetQuotasStub()
+	} else {
+		return fake.getQuotasReturns.result1, fake.getQuotasReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetQuotasCallCount() int {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return len(fake.getQuotasArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetQuotasReturns(result1 []plugin_models.GetQuotas_Model, result2 error) {
+	fake.GetQuotasStub = nil
+	fake.getQuotasReturns = struct {
+		result1 []plugin_models.GetQuotas_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotas() ([]plugin_models.GetSpaceQuotas_Model, error) {
+	fake.getSpaceQuotasMutex.Lock()
+	fake.getSpaceQuotasArgsForCall = append(fake.getSpaceQuotasArgsForCall, struct{}{})
+	fake.getSpaceQuotasMutex.Unlock()
+	if fake.GetSpaceQuotasStub != nil {
+		return fake.GetSpaceQuotasStub()
+	} else {
+		return fake.getSpaceQuotasReturns.result1, fake.getSpaceQuotasReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotasCallCount() int {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return len(fake.getSpaceQuotasArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetSpaceQuotasReturns(result1 []plugin_models.GetSpaceQuotas_Model, result2 error) {
+	fake.GetSpaceQuotasStub = nil
+	fake.getSpaceQuotasReturns = struct {
+		result1 []plugin_models.GetSpaceQuotas_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	fake.getBuildpacksMutex.Lock()
+	fake.getBuildpacksArgsForCall = append(fake.getBuildpacksArgsForCall, struct{}{})
+	fake.getBuildpacksMutex.Unlock()
+	if fake.GetBuildpacksStub != nil {
+		return fake.GetBuildpacksStub()
+	} else {
+		return fake.getBuildpacksReturns.result1, fake.getBuildpacksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetBuildpacksCallCount() int {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return len(fake.getBuildpacksArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetBuildpacksReturns(result1 []plugin_models.GetBuildpacks_Model, result2 error) {
+	fake.GetBuildpacksStub = nil
+	fake.getBuildpacksReturns = struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) CurlCC(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	fake.curlCCMutex.Lock()
+	fake.curlCCArgsForCall = append(fake.curlCCArgsForCall, struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}{method, path, headers, body})
+	fake.curlCCMutex.Unlock()
+	if fake.CurlCCStub != nil {
+		return fake.CurlCCStub(method, path, headers, body)
+	} else {
+		return fake.curlCCReturns.result1, fake.curlCCReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) CurlCCCallCount() int {
+	fake.curlCCMutex.RLock()
+	defer fake.curlCCMutex.RUnlock()
+	return len(fake.curlCCArgsForCall)
+}
+
+func (fake *FakeCliConnection) CurlCCArgsForCall(i int) (string, string, map[string]string, string) {
+	fake.curlCCMutex.RLock()
+	defer fake.curlCCMutex.RUnlock()
+	return fake.curlCCArgsForCall[i].method, fake.curlCCArgsForCall[i].path, fake.curlCCArgsForCall[i].headers, fake.curlCCArgsForCall[i].body
+}
+
+func (fake *FakeCliConnection) CurlCCReturns(result1 plugin_models.CurlResponse_Model, result2 error) {
+	fake.CurlCCStub = nil
+	fake.curlCCReturns = struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) CurlUAA(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	fake.curlUAAMutex.Lock()
+	fake.curlUAAArgsForCall = append(fake.curlUAAArgsForCall, struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}{method, path, headers, body})
+	fake.curlUAAMutex.Unlock()
+	if fake.CurlUAAStub != nil {
+		return fake.CurlUAAStub(method, path, headers, body)
+	} else {
+		return fake.curlUAAReturns.result1, fake.curlUAAReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) CurlUAACallCount() int {
+	fake.curlUAAMutex.RLock()
+	defer fake.curlUAAMutex.RUnlock()
+	return len(fake.curlUAAArgsForCall)
+}
+
+func (fake *FakeCliConnection) CurlUAAArgsForCall(i int) (string, string, map[string]string, string) {
+	fake.curlUAAMutex.RLock()
+	defer fake.curlUAAMutex.RUnlock()
+	return fake.curlUAAArgsForCall[i].method, fake.curlUAAArgsForCall[i].path, fake.curlUAAArgsForCall[i].headers, fake.curlUAAArgsForCall[i].body
+}
+
+func (fake *FakeCliConnection) CurlUAAReturns(result1 plugin_models.CurlResponse_Model, result2 error) {
+	fake.CurlUAAStub = nil
+	fake.curlUAAReturns = struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) CurlRoutingAPI(method string, path string, headers map[string]string, body string) (plugin_models.CurlResponse_Model, error) {
+	fake.curlRoutingAPIMutex.Lock()
+	fake.curlRoutingAPIArgsForCall = append(fake.curlRoutingAPIArgsForCall, struct {
+		method  string
+		path    string
+		headers map[string]string
+		body    string
+	}{method, path, headers, body})
+	fake.curlRoutingAPIMutex.Unlock()
+	if fake.CurlRoutingAPIStub != nil {
+		return fake.CurlRoutingAPIStub(method, path, headers, body)
+	} else {
+		return fake.curlRoutingAPIReturns.result1, fake.curlRoutingAPIReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) CurlRoutingAPICallCount() int {
+	fake.curlRoutingAPIMutex.RLock()
+	defer fake.curlRoutingAPIMutex.RUnlock()
+	return len(fake.curlRoutingAPIArgsForCall)
+}
+
+func (fake *FakeCliConnection) CurlRoutingAPIArgsForCall(i int) (string, string, map[string]string, string) {
+	fake.curlRoutingAPIMutex.RLock()
+	defer fake.curlRoutingAPIMutex.RUnlock()
+	return fake.curlRoutingAPIArgsForCall[i].method, fake.curlRoutingAPIArgsForCall[i].path, fake.curlRoutingAPIArgsForCall[i].headers, fake.curlRoutingAPIArgsForCall[i].body
+}
+
+func (fake *FakeCliConnection) CurlRoutingAPIReturns(result1 plugin_models.CurlResponse_Model, result2 error) {
+	fake.CurlRoutingAPIStub = nil
+	fake.curlRoutingAPIReturns = struct {
+		result1 plugin_models.CurlResponse_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) GetRecentLogs(arg1 string) ([]plugin_models.LogMessage_Model, error) {
+	fake.getRecentLogsMutex.Lock()
+	fake.getRecentLogsArgsForCall = append(fake.getRecentLogsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.getRecentLogsMutex.Unlock()
+	if fake.GetRecentLogsStub != nil {
+		return fake.GetRecentLogsStub(arg1)
+	} else {
+		return fake.getRecentLogsReturns.result1, fake.getRecentLogsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) GetRecentLogsCallCount() int {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return len(fake.getRecentLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) GetRecentLogsArgsForCall(i int) string {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return fake.getRecentLogsArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) GetRecentLogsReturns(result1 []plugin_models.LogMessage_Model, result2 error) {
+	fake.GetRecentLogsStub = nil
+	fake.getRecentLogsReturns = struct {
+		result1 []plugin_models.LogMessage_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) TailLogs(arg1 string, arg2 <-chan struct{}) (<-chan plugin_models.LogMessage_Model, <-chan error, error) {
+	fake.tailLogsMutex.Lock()
+	fake.tailLogsArgsForCall = append(fake.tailLogsArgsForCall, struct {
+		arg1 string
+		arg2 <-chan struct{}
+	}{arg1, arg2})
+	fake.tailLogsMutex.Unlock()
+	if fake.TailLogsStub != nil {
+		return fake.TailLogsStub(arg1, arg2)
+	} else {
+		return fake.tailLogsReturns.result1, fake.tailLogsReturns.result2, fake.tailLogsReturns.result3
+	}
+}
+
+func (fake *FakeCliConnection) TailLogsCallCount() int {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return len(fake.tailLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) TailLogsArgsForCall(i int) (string, <-chan struct{}) {
+	fake.tailLogsMutex.RLock()
+	defer fake.tailLogsMutex.RUnlock()
+	return fake.tailLogsArgsForCall[i].arg1, fake.tailLogsArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnection) TailLogsReturns(result1 <-chan plugin_models.LogMessage_Model, result2 <-chan error, result3 error) {
+	fake.TailLogsStub = nil
+	fake.tailLogsReturns = struct {
+		result1 <-chan plugin_models.LogMessage_Model
+		result2 <-chan error
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCliConnection) RequestCleanupTime(arg1 time.Duration) error {
+	fake.requestCleanupTimeMutex.Lock()
+	fake.requestCleanupTimeArgsForCall = append(fake.requestCleanupTimeArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.requestCleanupTimeMutex.Unlock()
+	if fake.RequestCleanupTimeStub != nil {
+		return fake.RequestCleanupTimeStub(arg1)
+	} else {
+		return fake.requestCleanupTimeReturns.result1
+	}
+}
+
+func (fake *FakeCliConnection) RequestCleanupTimeCallCount() int {
+	fake.requestCleanupTimeMutex.RLock()
+	defer fake.requestCleanupTimeMutex.RUnlock()
+	return len(fake.requestCleanupTimeArgsForCall)
+}
+
+func (fake *FakeCliConnection) RequestCleanupTimeArgsForCall(i int) time.Duration {
+	fake.requestCleanupTimeMutex.RLock()
+	defer fake.requestCleanupTimeMutex.RUnlock()
+	return fake.requestCleanupTimeArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) RequestCleanupTimeReturns(result1 error) {
+	fake.RequestCleanupTimeStub = nil
+	fake.requestCleanupTimeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ plugin.CliConnection = new(FakeCliConnection)
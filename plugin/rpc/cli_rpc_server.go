@@ -1,13 +1,24 @@
 package rpc
 
 import (
+	"bufio"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api"
 	"github.com/cloudfoundry/cli/cf/commandregistry"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/models"
+	"github.com/cloudfoundry/cli/cf/requirements"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -16,6 +27,8 @@ import (
 	"net"
 	"net/rpc"
 	"strconv"
+	"sync"
+	"time"
 
 	"bytes"
 	"io"
@@ -23,11 +36,18 @@ import (
 	"github.com/cloudfoundry/cli/cf/trace"
 )
 
+// DefaultRpcAuthenticationTimeout is how long a connection to an
+// authenticated service has to send the token, unless the
+// AuthenticationTimeout of the service is changed before it starts
+const DefaultRpcAuthenticationTimeout = 5 * time.Second
+
 type CliRpcService struct {
-	listener net.Listener
-	stopCh   chan struct{}
-	Pinged   bool
-	RpcCmd   *CliRpcCmd
+	listener              net.Listener
+	stopCh                chan struct{}
+	Pinged                bool
+	RpcCmd                *CliRpcCmd
+	server                *rpc.Server
+	AuthenticationTimeout time.Duration
 }
 
 type CliRpcCmd struct {
@@ -40,6 +60,13 @@ type CliRpcCmd struct {
 	outputBucket         *bytes.Buffer
 	logger               trace.Printer
 	stdout               io.Writer
+	logStreamMutex       sync.Mutex
+	logStream            *logStream
+	hookMutex            sync.Mutex
+	hookContext          plugin.HookContext
+	hookVeto             string
+	cleanupTimeMutex     sync.Mutex
+	cleanupTime          time.Duration
 }
 
 //go:generate counterfeiter . TerminalOutputSwitch
@@ -75,9 +102,11 @@ func NewRpcService(
 			outputBucket:         &bytes.Buffer{},
 			stdout:               w,
 		},
+		server:                rpc.NewServer(),
+		AuthenticationTimeout: DefaultRpcAuthenticationTimeout,
 	}
 
-	err := rpc.Register(rpcService.RpcCmd)
+	err := rpcService.server.Register(rpcService.RpcCmd)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +123,41 @@ func (cli *CliRpcService) Port() string {
 	return strconv.Itoa(cli.listener.Addr().(*net.TCPAddr).Port)
 }
 
+// Start listens on a random local port and serves every connection, for
+// plugins that do not send the token of StartAuthenticated
 func (cli *CliRpcService) Start() error {
+	return cli.start("")
+}
+
+// StartAuthenticated starts the service like Start, but only serves
+// connections that first send the returned token. Plugins read the token
+// from the plugin.RpcTokenEnvVar environment variable.
+func (cli *CliRpcService) StartAuthenticated() (string, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(tokenBytes)
+	return token, cli.start(token)
+}
+
+// StartForPlugin starts the service for a run of an installed plugin and
+// returns the environment to run the plugin with
+func (cli *CliRpcService) StartForPlugin(metadata pluginconfig.PluginMetadata) ([]string, error) {
+	if !metadata.AuthenticatedRpc {
+		return os.Environ(), cli.Start()
+	}
+
+	token, err := cli.StartAuthenticated()
+	if err != nil {
+		return nil, err
+	}
+	return append(os.Environ(), plugin.RpcTokenEnvVar+"="+token), nil
+}
+
+func (cli *CliRpcService) start(token string) error {
 	var err error
 
 	cli.stopCh = make(chan struct{})
@@ -104,25 +167,44 @@ func (cli *CliRpcService) Start() error {
 		return err
 	}
 
-	go func() {
+	go func(listener net.Listener, stopCh chan struct{}, timeout time.Duration) {
 		for {
-			conn, err := cli.listener.Accept()
+			conn, err := listener.Accept()
 			if err != nil {
 				select {
-				case <-cli.stopCh:
+				case <-stopCh:
 					return
 				default:
 					fmt.Println(err)
 				}
 			} else {
-				go rpc.ServeConn(conn)
+				go cli.serveConn(conn, token, timeout)
 			}
 		}
-	}()
+	}(cli.listener, cli.stopCh, cli.AuthenticationTimeout)
 
 	return nil
 }
 
+// serveConn serves the RPC calls of a connection, after checking that it
+// sent the token within timeout first when there is one
+func (cli *CliRpcService) serveConn(conn net.Conn, token string, timeout time.Duration) {
+	if token != "" {
+		received := make([]byte, len(token))
+
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		_, err := io.ReadFull(conn, received)
+		conn.SetReadDeadline(time.Time{})
+
+		if err != nil || subtle.ConstantTimeCompare(received, []byte(token)) != 1 {
+			conn.Close()
+			return
+		}
+	}
+
+	cli.server.ServeConn(conn)
+}
+
 func (cmd *CliRpcCmd) IsMinCliVersion(version string, retVal *bool) error {
 	if cf.Version == "BUILT_FROM_SOURCE" {
 		*retVal = true
@@ -475,3 +557,330 @@ func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.G
 
 	return cmd.newCmdRunner.Command([]string{"service", serviceInstance}, deps, true)
 }
+
+func (cmd *CliRpcCmd) GetRoutes(_ string, retVal *[]plugin_models.GetRoutes_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	routes := []plugin_models.GetRoutes_Model{}
+	err = cmd.repoLocator.GetRouteRepository().ListRoutes(func(route models.Route) bool {
+		r := plugin_models.GetRoutes_Model{
+			Guid: route.GUID,
+			Host: route.Host,
+			Path: route.Path,
+			Port: route.Port,
+			Domain: plugin_models.GetRoutes_DomainFields{
+				Guid: route.Domain.GUID,
+				Name: route.Domain.Name,
+			},
+			Space: plugin_models.GetRoutes_SpaceFields{
+				Guid: route.Space.GUID,
+				Name: route.Space.Name,
+			},
+			ServiceInstance: plugin_models.GetRoutes_ServiceInstanceFields{
+				Guid: route.ServiceInstance.GUID,
+				Name: route.ServiceInstance.Name,
+			},
+		}
+		for _, app := range route.Apps {
+			r.Apps = append(r.Apps, plugin_models.GetRoutes_AppFields{
+				Guid: app.GUID,
+				Name: app.Name,
+			})
+		}
+		routes = append(routes, r)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = routes
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetDomains(_ string, retVal *[]plugin_models.GetDomains_Model) error {
+	err := requirements.NewTargetedOrgRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	domains := []plugin_models.GetDomains_Model{}
+	err = cmd.repoLocator.GetDomainRepository().ListDomainsForOrg(cmd.cliConfig.OrganizationFields().GUID, func(domain models.DomainFields) bool {
+		domains = append(domains, plugin_models.GetDomains_Model{
+			Guid:                   domain.GUID,
+			Name:                   domain.Name,
+			OwningOrganizationGuid: domain.OwningOrganizationGUID,
+			RouterGroupType:        domain.RouterGroupType,
+			Shared:                 domain.Shared,
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = domains
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetAppInstances(appName string, retVal *[]plugin_models.GetAppInstances_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	appInstances, err := cmd.repoLocator.GetAppInstancesRepository().GetInstances(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	instances := []plugin_models.GetAppInstances_Model{}
+	for index, instance := range appInstances {
+		instances = append(instances, plugin_models.GetAppInstances_Model{
+			Index:     index,
+			State:     string(instance.State),
+			Details:   instance.Details,
+			Since:     instance.Since,
+			CpuUsage:  instance.CPUUsage,
+			DiskQuota: instance.DiskQuota,
+			DiskUsage: instance.DiskUsage,
+			MemQuota:  instance.MemQuota,
+			MemUsage:  instance.MemUsage,
+		})
+	}
+
+	*retVal = instances
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	appEvents, err := cmd.repoLocator.GetAppEventsRepository().RecentEvents(app.GUID, 50)
+	if err != nil {
+		return err
+	}
+
+	events := []plugin_models.GetAppEvents_Model{}
+	for _, event := range appEvents {
+		events = append(events, plugin_models.GetAppEvents_Model{
+			Guid:        event.GUID,
+			Name:        event.Name,
+			Timestamp:   event.Timestamp,
+			Description: event.Description,
+			Actor:       event.Actor,
+			ActorName:   event.ActorName,
+		})
+	}
+
+	*retVal = events
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	instance, err := cmd.repoLocator.GetServiceRepository().FindInstanceByName(serviceInstance)
+	if err != nil {
+		return err
+	}
+
+	serviceKeys, err := cmd.repoLocator.GetServiceKeyRepository().ListServiceKeys(instance.GUID)
+	if err != nil {
+		return err
+	}
+
+	keys := []plugin_models.GetServiceKeys_Model{}
+	for _, serviceKey := range serviceKeys {
+		keys = append(keys, plugin_models.GetServiceKeys_Model{
+			Guid:                serviceKey.Fields.GUID,
+			Name:                serviceKey.Fields.Name,
+			ServiceInstanceGuid: serviceKey.Fields.ServiceInstanceGUID,
+			Credentials:         serviceKey.Credentials,
+		})
+	}
+
+	*retVal = keys
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetSecurityGroups(_ string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	err := requirements.NewLoginRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	securityGroups, err := cmd.repoLocator.GetSecurityGroupRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	groups := []plugin_models.GetSecurityGroups_Model{}
+	for _, securityGroup := range securityGroups {
+		group := plugin_models.GetSecurityGroups_Model{
+			Guid:  securityGroup.GUID,
+			Name:  securityGroup.Name,
+			Rules: securityGroup.Rules,
+		}
+		for _, space := range securityGroup.Spaces {
+			group.Spaces = append(group.Spaces, plugin_models.GetSecurityGroups_Space{
+				Guid:             space.GUID,
+				Name:             space.Name,
+				OrganizationName: space.Organization.Name,
+			})
+		}
+		groups = append(groups, group)
+	}
+
+	*retVal = groups
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetQuotas(_ string, retVal *[]plugin_models.GetQuotas_Model) error {
+	err := requirements.NewLoginRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	orgQuotas, err := cmd.repoLocator.GetQuotaRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	quotas := []plugin_models.GetQuotas_Model{}
+	for _, quota := range orgQuotas {
+		quotas = append(quotas, plugin_models.GetQuotas_Model{
+			Guid:                    quota.GUID,
+			Name:                    quota.Name,
+			MemoryLimit:             quota.MemoryLimit,
+			InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+			RoutesLimit:             quota.RoutesLimit,
+			ServicesLimit:           quota.ServicesLimit,
+			NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+			AppInstanceLimit:        quota.AppInstanceLimit,
+			ReservedRoutePorts:      quota.ReservedRoutePorts.String(),
+		})
+	}
+
+	*retVal = quotas
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetSpaceQuotas(_ string, retVal *[]plugin_models.GetSpaceQuotas_Model) error {
+	err := requirements.NewTargetedOrgRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	spaceQuotas, err := cmd.repoLocator.GetSpaceQuotaRepository().FindByOrg(cmd.cliConfig.OrganizationFields().GUID)
+	if err != nil {
+		return err
+	}
+
+	quotas := []plugin_models.GetSpaceQuotas_Model{}
+	for _, quota := range spaceQuotas {
+		quotas = append(quotas, plugin_models.GetSpaceQuotas_Model{
+			Guid:                    quota.GUID,
+			Name:                    quota.Name,
+			OrgGuid:                 quota.OrgGUID,
+			MemoryLimit:             quota.MemoryLimit,
+			InstanceMemoryLimit:     quota.InstanceMemoryLimit,
+			RoutesLimit:             quota.RoutesLimit,
+			ServicesLimit:           quota.ServicesLimit,
+			NonBasicServicesAllowed: quota.NonBasicServicesAllowed,
+			AppInstanceLimit:        quota.AppInstanceLimit,
+			ReservedRoutePorts:      quota.ReservedRoutePortsLimit.String(),
+		})
+	}
+
+	*retVal = quotas
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetBuildpacks(_ string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	err := requirements.NewLoginRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	buildpacks := []plugin_models.GetBuildpacks_Model{}
+	err = cmd.repoLocator.GetBuildpackRepository().ListBuildpacks(func(buildpack models.Buildpack) bool {
+		b := plugin_models.GetBuildpacks_Model{
+			Guid:     buildpack.GUID,
+			Name:     buildpack.Name,
+			Filename: buildpack.Filename,
+		}
+		if buildpack.Position != nil {
+			b.Position = *buildpack.Position
+		}
+		if buildpack.Enabled != nil {
+			b.Enabled = *buildpack.Enabled
+		}
+		if buildpack.Locked != nil {
+			b.Locked = *buildpack.Locked
+		}
+		buildpacks = append(buildpacks, b)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = buildpacks
+	return nil
+}
+
+func (cmd *CliRpcCmd) CurlCC(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	return curl(cmd.repoLocator.GetCurlRepository(), args, retVal)
+}
+
+func (cmd *CliRpcCmd) CurlUAA(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	return curl(cmd.repoLocator.GetUAACurlRepository(), args, retVal)
+}
+
+func (cmd *CliRpcCmd) CurlRoutingAPI(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	return curl(cmd.repoLocator.GetRoutingAPICurlRepository(), args, retVal)
+}
+
+// curl expects the method, path, headers and body of the request in args and
+// performs it through the repository so that it goes through the same gateway
+// as the CLI's own requests
+func curl(repo api.CurlRepository, args []string, retVal *plugin_models.CurlResponse_Model) error {
+	if len(args) != 4 {
+		return errors.New(T("expected method, path, headers and body, got {{.Count}} arguments", map[string]interface{}{"Count": len(args)}))
+	}
+
+	resHeaders, resBody, err := repo.Request(args[0], args[1], args[2], args[3])
+	if err != nil {
+		return err
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(strings.NewReader(resHeaders)), nil)
+	if err != nil {
+		return err
+	}
+
+	retVal.StatusCode = res.StatusCode
+	retVal.Headers = map[string][]string(res.Header)
+	retVal.Body = resBody
+	return nil
+}
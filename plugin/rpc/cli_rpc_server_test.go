@@ -5,12 +5,19 @@ import (
 	"net"
 	"net/rpc"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cloudfoundry/cli/cf"
 	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/api/apifakes"
+	"github.com/cloudfoundry/cli/cf/api/appinstances/appinstancesfakes"
+	"github.com/cloudfoundry/cli/cf/api/applications/applicationsfakes"
 	"github.com/cloudfoundry/cli/cf/api/authentication/authenticationfakes"
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	"github.com/cloudfoundry/cli/cf/api/logs/logsfakes"
 	"github.com/cloudfoundry/cli/cf/configuration/coreconfig"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
 	"github.com/cloudfoundry/cli/cf/models"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/plugin"
@@ -20,6 +27,8 @@ import (
 	. "github.com/cloudfoundry/cli/plugin/rpc/fakecommand"
 	"github.com/cloudfoundry/cli/plugin/rpc/rpcfakes"
 	testconfig "github.com/cloudfoundry/cli/testhelpers/configuration"
+	testlogs "github.com/cloudfoundry/cli/testhelpers/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -40,19 +49,34 @@ var _ = Describe("Server", func() {
 		}
 	})
 
-	BeforeEach(func() {
-		rpc.DefaultServer = rpc.NewServer()
-	})
-
 	Describe(".NewRpcService", func() {
 		BeforeEach(func() {
 			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
-		It("returns an err of another Rpc process is already registered", func() {
-			_, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
-			Expect(err).To(HaveOccurred())
+		It("can run next to another service", func() {
+			otherService, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(rpcService.Start()).To(Succeed())
+			defer rpcService.Stop()
+			Expect(otherService.Start()).To(Succeed())
+			defer otherService.Stop()
+
+			for _, service := range []*CliRpcService{rpcService, otherService} {
+				pingCli(service.Port())
+				client, err := rpc.Dial("tcp", "127.0.0.1:"+service.Port())
+				Expect(err).ToNot(HaveOccurred())
+
+				var success bool
+				err = client.Call("CliRpcCmd.SetPluginMetadata", &plugin.PluginMetadata{Name: "plugin-on-" + service.Port()}, &success)
+				client.Close()
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(rpcService.RpcCmd.PluginMetadata.Name).To(Equal("plugin-on-" + rpcService.Port()))
+			Expect(otherService.RpcCmd.PluginMetadata.Name).To(Equal("plugin-on-" + otherService.Port()))
 		})
 	})
 
@@ -102,6 +126,101 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe(".StartAuthenticated", func() {
+		var (
+			token       string
+			dialSending func(prefix string) *rpc.Client
+		)
+
+		BeforeEach(func() {
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			token, err = rpcService.StartAuthenticated()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			dialSending = func(prefix string) *rpc.Client {
+				conn, err := net.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = conn.Write([]byte(prefix))
+				Expect(err).ToNot(HaveOccurred())
+
+				return rpc.NewClient(conn)
+			}
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		It("returns a different random token every time", func() {
+			Expect(token).To(HaveLen(64))
+
+			rpcService.Stop()
+			otherToken, err := rpcService.StartAuthenticated()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherToken).ToNot(Equal(token))
+		})
+
+		It("serves connections that send the token first", func() {
+			client = dialSending(token)
+
+			var result bool
+			err = client.Call("CliRpcCmd.IsMinCliVersion", "0.0.1", &result)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("closes connections that send a different token", func() {
+			client = dialSending(strings.Repeat("0", len(token)))
+
+			var result bool
+			err = client.Call("CliRpcCmd.IsMinCliVersion", "0.0.1", &result)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("closes connections that do not send a token", func() {
+			rpcService.Stop()
+			rpcService.AuthenticationTimeout = 100 * time.Millisecond
+			_, err = rpcService.StartAuthenticated()
+			Expect(err).ToNot(HaveOccurred())
+
+			client = dialSending("")
+
+			var result bool
+			err = client.Call("CliRpcCmd.IsMinCliVersion", "0.0.1", &result)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe(".StartForPlugin", func() {
+		BeforeEach(func() {
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+		})
+
+		It("passes a token to plugins that authenticate", func() {
+			env, err := rpcService.StartForPlugin(pluginconfig.PluginMetadata{AuthenticatedRpc: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env[len(env)-1]).To(MatchRegexp("^" + plugin.RpcTokenEnvVar + "=[0-9a-f]{64}$"))
+		})
+
+		It("does not pass a token to other plugins", func() {
+			env, err := rpcService.StartForPlugin(pluginconfig.PluginMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(env).To(Equal(os.Environ()))
+		})
+	})
+
 	Describe(".IsMinCliVersion()", func() {
 		BeforeEach(func() {
 			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
@@ -744,6 +863,447 @@ var _ = Describe("Server", func() {
 				})
 			})
 
+			Context("structured data methods", func() {
+				var (
+					routeRepo      *apifakes.FakeRouteRepository
+					appRepo        *applicationsfakes.FakeRepository
+					instancesRepo  *appinstancesfakes.FakeRepository
+					serviceRepo    *apifakes.FakeServiceRepository
+					serviceKeyRepo *apifakes.FakeServiceKeyRepository
+					buildpackRepo  *apifakes.FakeBuildpackRepository
+				)
+
+				BeforeEach(func() {
+					routeRepo = new(apifakes.FakeRouteRepository)
+					appRepo = new(applicationsfakes.FakeRepository)
+					instancesRepo = new(appinstancesfakes.FakeRepository)
+					serviceRepo = new(apifakes.FakeServiceRepository)
+					serviceKeyRepo = new(apifakes.FakeServiceKeyRepository)
+					buildpackRepo = new(apifakes.FakeBuildpackRepository)
+
+					locator := api.RepositoryLocator{}
+					locator = locator.SetRouteRepository(routeRepo)
+					locator = locator.SetApplicationRepository(appRepo)
+					locator = locator.SetAppInstancesRepository(instancesRepo)
+					locator = locator.SetServiceRepository(serviceRepo)
+					locator = locator.SetServiceKeyRepository(serviceKeyRepo)
+					locator = locator.SetBuildpackRepository(buildpackRepo)
+					config.SetAPIEndpoint("https://api.example.com")
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				Describe(".GetRoutes", func() {
+					It("returns the routes of the targeted space", func() {
+						routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+							cb(models.Route{
+								GUID:   "route-guid",
+								Host:   "my-host",
+								Path:   "/path",
+								Domain: models.DomainFields{GUID: "domain-guid", Name: "example.com"},
+								Apps:   []models.ApplicationFields{{GUID: "app-guid", Name: "my-app"}},
+							})
+							return nil
+						}
+
+						var result []plugin_models.GetRoutes_Model
+						err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(result).To(HaveLen(1))
+						Expect(result[0].Guid).To(Equal("route-guid"))
+						Expect(result[0].Host).To(Equal("my-host"))
+						Expect(result[0].Path).To(Equal("/path"))
+						Expect(result[0].Domain.Name).To(Equal("example.com"))
+						Expect(result[0].Apps).To(Equal([]plugin_models.GetRoutes_AppFields{{Guid: "app-guid", Name: "my-app"}}))
+					})
+
+					It("returns an error when no space is targeted", func() {
+						config.SetSpaceFields(models.SpaceFields{})
+
+						var result []plugin_models.GetRoutes_Model
+						err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("No space targeted"))
+						Expect(routeRepo.ListRoutesCallCount()).To(Equal(0))
+					})
+
+					It("returns the error from listing the routes", func() {
+						routeRepo.ListRoutesReturns(errors.New("list error"))
+
+						var result []plugin_models.GetRoutes_Model
+						err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+						Expect(err).To(MatchError("list error"))
+					})
+				})
+
+				Describe(".GetAppInstances", func() {
+					BeforeEach(func() {
+						appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{GUID: "app-guid"}}, nil)
+						instancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+							{State: models.InstanceRunning, CPUUsage: 1.5, MemUsage: 1024},
+							{State: models.InstanceCrashed, Details: "out of memory"},
+						}, nil)
+					})
+
+					It("returns the instances of the named app with their index and stats", func() {
+						var result []plugin_models.GetAppInstances_Model
+						err = client.Call("CliRpcCmd.GetAppInstances", "my-app", &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(appRepo.ReadArgsForCall(0)).To(Equal("my-app"))
+						Expect(instancesRepo.GetInstancesArgsForCall(0)).To(Equal("app-guid"))
+
+						Expect(result).To(HaveLen(2))
+						Expect(result[0].Index).To(Equal(0))
+						Expect(result[0].State).To(Equal("running"))
+						Expect(result[0].CpuUsage).To(Equal(1.5))
+						Expect(result[0].MemUsage).To(Equal(int64(1024)))
+						Expect(result[1].Index).To(Equal(1))
+						Expect(result[1].State).To(Equal("crashed"))
+						Expect(result[1].Details).To(Equal("out of memory"))
+					})
+
+					It("returns the error when the app cannot be found", func() {
+						appRepo.ReadReturns(models.Application{}, errors.New("app not found"))
+
+						var result []plugin_models.GetAppInstances_Model
+						err = client.Call("CliRpcCmd.GetAppInstances", "my-app", &result)
+						Expect(err).To(MatchError("app not found"))
+						Expect(instancesRepo.GetInstancesCallCount()).To(Equal(0))
+					})
+				})
+
+				Describe(".GetServiceKeys", func() {
+					It("returns the keys of the named service instance with their credentials", func() {
+						serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{ServiceInstanceFields: models.ServiceInstanceFields{GUID: "instance-guid"}}, nil)
+						serviceKeyRepo.ListServiceKeysReturns([]models.ServiceKey{
+							{
+								Fields: models.ServiceKeyFields{GUID: "key-guid", Name: "my-key", ServiceInstanceGUID: "instance-guid"},
+								Credentials: map[string]interface{}{
+									"username": "admin",
+									"hosts":    []interface{}{"10.0.0.1", "10.0.0.2"},
+									"tls":      map[string]interface{}{"enabled": true},
+								},
+							},
+						}, nil)
+
+						var result []plugin_models.GetServiceKeys_Model
+						err = client.Call("CliRpcCmd.GetServiceKeys", "my-service", &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(serviceRepo.FindInstanceByNameArgsForCall(0)).To(Equal("my-service"))
+						Expect(serviceKeyRepo.ListServiceKeysArgsForCall(0)).To(Equal("instance-guid"))
+
+						Expect(result).To(HaveLen(1))
+						Expect(result[0].Guid).To(Equal("key-guid"))
+						Expect(result[0].Name).To(Equal("my-key"))
+						Expect(result[0].Credentials).To(Equal(map[string]interface{}{
+							"username": "admin",
+							"hosts":    []interface{}{"10.0.0.1", "10.0.0.2"},
+							"tls":      map[string]interface{}{"enabled": true},
+						}))
+					})
+				})
+
+				Describe(".GetSecurityGroups", func() {
+					It("returns an error when not logged in", func() {
+						config.SetAccessToken("")
+
+						var result []plugin_models.GetSecurityGroups_Model
+						err = client.Call("CliRpcCmd.GetSecurityGroups", "", &result)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("Not logged in"))
+					})
+				})
+
+				Describe(".GetQuotas", func() {
+					It("returns an error when not logged in", func() {
+						config.SetAccessToken("")
+
+						var result []plugin_models.GetQuotas_Model
+						err = client.Call("CliRpcCmd.GetQuotas", "", &result)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("Not logged in"))
+					})
+				})
+
+				Describe(".GetBuildpacks", func() {
+					It("returns the buildpacks with unset fields left at their zero value", func() {
+						position := 2
+						enabled := true
+						buildpackRepo.ListBuildpacksStub = func(cb func(models.Buildpack) bool) error {
+							cb(models.Buildpack{GUID: "bp-guid", Name: "go_buildpack", Position: &position, Enabled: &enabled, Filename: "go.zip"})
+							cb(models.Buildpack{GUID: "bp-guid-2", Name: "empty_buildpack"})
+							return nil
+						}
+
+						var result []plugin_models.GetBuildpacks_Model
+						err = client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(result).To(Equal([]plugin_models.GetBuildpacks_Model{
+							{Guid: "bp-guid", Name: "go_buildpack", Position: 2, Enabled: true, Filename: "go.zip"},
+							{Guid: "bp-guid-2", Name: "empty_buildpack"},
+						}))
+					})
+
+					It("returns an error when not logged in", func() {
+						config.SetAccessToken("")
+
+						var result []plugin_models.GetBuildpacks_Model
+						err = client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("Not logged in"))
+						Expect(buildpackRepo.ListBuildpacksCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("raw API methods", func() {
+				var (
+					ccCurlRepo         *apifakes.FakeCurlRepository
+					uaaCurlRepo        *apifakes.FakeCurlRepository
+					routingAPICurlRepo *apifakes.FakeCurlRepository
+				)
+
+				BeforeEach(func() {
+					ccCurlRepo = new(apifakes.FakeCurlRepository)
+					uaaCurlRepo = new(apifakes.FakeCurlRepository)
+					routingAPICurlRepo = new(apifakes.FakeCurlRepository)
+
+					locator := api.RepositoryLocator{}
+					locator = locator.SetCurlRepository(ccCurlRepo)
+					locator = locator.SetUAACurlRepository(uaaCurlRepo)
+					locator = locator.SetRoutingAPICurlRepository(routingAPICurlRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				Describe(".CurlCC", func() {
+					It("performs the request through the cloud controller curl repository", func() {
+						ccCurlRepo.RequestReturns("HTTP/1.1 201 Created\r\nContent-Type: application/json\r\nX-Cf-Warnings: careful\r\n\r\n", `{"guid":"some-guid"}`, nil)
+
+						var result plugin_models.CurlResponse_Model
+						err = client.Call("CliRpcCmd.CurlCC", []string{"POST", "/v2/apps", "Accept: application/json", `{"name":"my-app"}`}, &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(ccCurlRepo.RequestCallCount()).To(Equal(1))
+						method, path, headers, body := ccCurlRepo.RequestArgsForCall(0)
+						Expect(method).To(Equal("POST"))
+						Expect(path).To(Equal("/v2/apps"))
+						Expect(headers).To(Equal("Accept: application/json"))
+						Expect(body).To(Equal(`{"name":"my-app"}`))
+
+						Expect(result.StatusCode).To(Equal(201))
+						Expect(result.Headers["Content-Type"]).To(Equal([]string{"application/json"}))
+						Expect(result.Headers["X-Cf-Warnings"]).To(Equal([]string{"careful"}))
+						Expect(result.Body).To(Equal(`{"guid":"some-guid"}`))
+					})
+
+					It("returns the error from performing the request", func() {
+						ccCurlRepo.RequestReturns("", "", errors.New("connection refused"))
+
+						var result plugin_models.CurlResponse_Model
+						err = client.Call("CliRpcCmd.CurlCC", []string{"GET", "/v2/info", "", ""}, &result)
+						Expect(err).To(MatchError("connection refused"))
+					})
+
+					It("returns an error when not given all the arguments", func() {
+						var result plugin_models.CurlResponse_Model
+						err = client.Call("CliRpcCmd.CurlCC", []string{"GET"}, &result)
+						Expect(err).To(HaveOccurred())
+						Expect(ccCurlRepo.RequestCallCount()).To(Equal(0))
+					})
+				})
+
+				Describe(".CurlUAA and .CurlRoutingAPI", func() {
+					It("performs the requests through the UAA and routing API curl repositories", func() {
+						uaaCurlRepo.RequestReturns("HTTP/1.1 200 OK\r\n\r\n", "uaa", nil)
+						routingAPICurlRepo.RequestReturns("HTTP/1.1 404 Not Found\r\n\r\n", "routing", nil)
+
+						var result plugin_models.CurlResponse_Model
+						err = client.Call("CliRpcCmd.CurlUAA", []string{"GET", "/Users", "", ""}, &result)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result.StatusCode).To(Equal(200))
+						Expect(result.Body).To(Equal("uaa"))
+
+						result = plugin_models.CurlResponse_Model{}
+						err = client.Call("CliRpcCmd.CurlRoutingAPI", []string{"GET", "/v1/tcp_routes", "", ""}, &result)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result.StatusCode).To(Equal(404))
+						Expect(result.Body).To(Equal("routing"))
+
+						Expect(ccCurlRepo.RequestCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("log methods", func() {
+				var (
+					appRepo  *applicationsfakes.FakeRepository
+					logsRepo *logsfakes.FakeRepository
+					date     time.Time
+				)
+
+				BeforeEach(func() {
+					date = time.Date(2016, 4, 4, 11, 39, 20, 0, time.UTC)
+
+					appRepo = new(applicationsfakes.FakeRepository)
+					appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{GUID: "app-guid"}}, nil)
+					logsRepo = new(logsfakes.FakeRepository)
+
+					locator := api.RepositoryLocator{}
+					locator = locator.SetApplicationRepository(appRepo)
+					locator = locator.SetLogsRepository(logsRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				Describe(".GetRecentLogs", func() {
+					It("returns the recent logs of the named app as structured messages", func() {
+						logsRepo.RecentLogsForReturns([]logs.Loggable{
+							testlogs.NewLogMessage("Staging...\n", "app-guid", "STG", "0", logmessage.LogMessage_OUT, date),
+							testlogs.NewLogMessage("oops", "app-guid", "APP", "1", logmessage.LogMessage_ERR, date),
+						}, nil)
+
+						var result []plugin_models.LogMessage_Model
+						err = client.Call("CliRpcCmd.GetRecentLogs", "my-app", &result)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(appRepo.ReadArgsForCall(0)).To(Equal("my-app"))
+						Expect(logsRepo.RecentLogsForArgsForCall(0)).To(Equal("app-guid"))
+
+						Expect(result).To(HaveLen(2))
+						Expect(result[0].Message).To(Equal("Staging..."))
+						Expect(result[0].MessageType).To(Equal("OUT"))
+						Expect(result[0].SourceType).To(Equal("STG"))
+						Expect(result[0].SourceInstance).To(Equal("0"))
+						Expect(result[0].Timestamp.Equal(date)).To(BeTrue())
+						Expect(result[1].MessageType).To(Equal("ERR"))
+					})
+				})
+
+				Describe("streaming logs", func() {
+					var started bool
+
+					BeforeEach(func() {
+						LogStreamPollInterval = 10 * time.Millisecond
+					})
+
+					AfterEach(func() {
+						LogStreamPollInterval = time.Second
+					})
+
+					It("tails the logs of the named app and delivers them until the stream is closed", func() {
+						logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+							logChan <- testlogs.NewLogMessage("Staging...", "app-guid", "STG", "0", logmessage.LogMessage_OUT, date)
+							logChan <- testlogs.NewLogMessage("Staging complete", "app-guid", "STG", "0", logmessage.LogMessage_OUT, date)
+							close(logChan)
+							close(errChan)
+						}
+
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(started).To(BeTrue())
+
+						received := []string{}
+						Eventually(func() bool {
+							var batch plugin_models.TailLogs_Model
+							err := client.Call("CliRpcCmd.NextLogMessages", "", &batch)
+							Expect(err).ToNot(HaveOccurred())
+							for _, msg := range batch.Messages {
+								received = append(received, msg.Message)
+							}
+							return batch.Closed
+						}).Should(BeTrue())
+
+						Expect(received).To(Equal([]string{"Staging...", "Staging complete"}))
+						appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+						Expect(appGUID).To(Equal("app-guid"))
+					})
+
+					It("returns an empty batch when no message arrives in time", func() {
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+
+						var batch plugin_models.TailLogs_Model
+						err = client.Call("CliRpcCmd.NextLogMessages", "", &batch)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(batch.Messages).To(BeEmpty())
+						Expect(batch.Closed).To(BeFalse())
+					})
+
+					It("returns the error that ended the stream", func() {
+						logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+							errChan <- errors.New("connection lost")
+						}
+
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+
+						var batch plugin_models.TailLogs_Model
+						err = client.Call("CliRpcCmd.NextLogMessages", "", &batch)
+						Expect(err).To(MatchError("connection lost"))
+					})
+
+					It("allows only one stream at a time", func() {
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).To(HaveOccurred())
+					})
+
+					It("closes the log connection when the stream is stopped", func() {
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+
+						var stopped bool
+						err = client.Call("CliRpcCmd.StopLogStream", "", &stopped)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(logsRepo.CloseCallCount()).To(Equal(1))
+
+						var batch plugin_models.TailLogs_Model
+						err = client.Call("CliRpcCmd.NextLogMessages", "", &batch)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(batch.Closed).To(BeTrue())
+
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("returns the error when the app cannot be found", func() {
+						appRepo.ReadReturns(models.Application{}, errors.New("app not found"))
+
+						err = client.Call("CliRpcCmd.StartLogStream", "my-app", &started)
+						Expect(err).To(MatchError("app not found"))
+						Expect(logsRepo.TailLogsForCallCount()).To(Equal(0))
+					})
+				})
+			})
+
 		})
 
 		Context("fail", func() {
@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/api/logs"
+	. "github.com/cloudfoundry/cli/cf/i18n"
+	"github.com/cloudfoundry/cli/cf/requirements"
+	"github.com/cloudfoundry/cli/plugin/models"
+)
+
+// LogStreamPollInterval is how long NextLogMessages waits for a message
+// before returning an empty batch
+var LogStreamPollInterval = time.Second
+
+const maxLogBatchSize = 100
+
+// logStream tails the logs of an app for a plugin. net/rpc cannot call back
+// into the plugin, so the plugin polls for the messages with NextLogMessages.
+type logStream struct {
+	repo     logs.Repository
+	messages chan logs.Loggable
+	errs     chan error
+}
+
+func newLogStream(repo logs.Repository, appGUID string) *logStream {
+	stream := &logStream{
+		repo:     repo,
+		messages: make(chan logs.Loggable),
+		errs:     make(chan error),
+	}
+
+	go repo.TailLogsFor(appGUID, func() {}, stream.messages, stream.errs)
+
+	return stream
+}
+
+// next waits up to wait for a message and then returns it along with any
+// others that are already waiting
+func (stream *logStream) next(wait time.Duration) ([]logs.Loggable, bool, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	messages := []logs.Loggable{}
+
+	select {
+	case msg, ok := <-stream.messages:
+		if !ok {
+			return messages, true, nil
+		}
+		messages = append(messages, msg)
+	case err := <-stream.errs:
+		return messages, true, err
+	case <-timer.C:
+		return messages, false, nil
+	}
+
+	for len(messages) < maxLogBatchSize {
+		select {
+		case msg, ok := <-stream.messages:
+			if !ok {
+				return messages, true, nil
+			}
+			messages = append(messages, msg)
+		default:
+			return messages, false, nil
+		}
+	}
+
+	return messages, false, nil
+}
+
+// stop closes the connection to the log server and drains what the
+// repository still sends so that it is not left blocked
+func (stream *logStream) stop() {
+	stream.repo.Close()
+
+	go func() {
+		for range stream.messages {
+		}
+	}()
+	go func() {
+		for range stream.errs {
+		}
+	}()
+}
+
+func (cmd *CliRpcCmd) StartLogStream(appName string, retVal *bool) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil {
+		return errors.New(T("A log stream is already open"))
+	}
+
+	cmd.logStream = newLogStream(cmd.repoLocator.GetLogsRepository(), app.GUID)
+
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) NextLogMessages(_ string, retVal *plugin_models.TailLogs_Model) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStreamMutex.Unlock()
+
+	if stream == nil {
+		retVal.Closed = true
+		return nil
+	}
+
+	messages, closed, err := stream.next(LogStreamPollInterval)
+	if closed {
+		cmd.logStreamMutex.Lock()
+		if cmd.logStream == stream {
+			cmd.logStream = nil
+		}
+		cmd.logStreamMutex.Unlock()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	retVal.Messages = toLogMessageModels(messages)
+	retVal.Closed = closed
+	return nil
+}
+
+func (cmd *CliRpcCmd) StopLogStream(_ string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStream = nil
+	cmd.logStreamMutex.Unlock()
+
+	if stream != nil {
+		stream.stop()
+	}
+
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetRecentLogs(appName string, retVal *[]plugin_models.LogMessage_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	messages, err := cmd.repoLocator.GetLogsRepository().RecentLogsFor(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	*retVal = toLogMessageModels(messages)
+	return nil
+}
+
+func toLogMessageModels(messages []logs.Loggable) []plugin_models.LogMessage_Model {
+	models := []plugin_models.LogMessage_Model{}
+	for _, msg := range messages {
+		models = append(models, plugin_models.LogMessage_Model{
+			Message:        msg.ToSimpleLog(),
+			MessageType:    msg.GetMessageType(),
+			Timestamp:      msg.GetTimestamp(),
+			SourceType:     msg.GetSourceName(),
+			SourceInstance: msg.GetSourceInstance(),
+		})
+	}
+	return models
+}
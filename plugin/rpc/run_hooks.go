@@ -0,0 +1,179 @@
+package rpc
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/cf/trace"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+)
+
+type HookVeto struct {
+	PluginName string
+	Message    string
+}
+
+// PluginsWithHook returns the names of the installed plugins that registered
+// the hook, in alphabetical order
+func PluginsWithHook(hook string, pluginList map[string]pluginconfig.PluginMetadata) []string {
+	names := []string{}
+	for name, metadata := range pluginList {
+		for _, h := range metadata.Hooks {
+			if h.Name == hook {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RedactArgs returns the args of a core command with the values of the
+// arguments and flags that its metadata marks as sensitive hidden
+func RedactArgs(args []string, metadata commandregistry.CommandMetadata) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+
+	position := 0
+	for i := 0; i < len(redacted); i++ {
+		arg := redacted[i]
+		if metadata.SkipFlagParsing || !strings.HasPrefix(arg, "-") || arg == "-" {
+			if containsInt(metadata.SensitiveArgs, position) {
+				redacted[i] = trace.PrivateDataPlaceholder()
+			}
+			position++
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if equals := strings.Index(name, "="); equals >= 0 {
+			if flag := findFlag(metadata.Flags, name[:equals]); flag != nil && flag.IsSensitive() {
+				redacted[i] = arg[:strings.Index(arg, "=")+1] + trace.PrivateDataPlaceholder()
+			}
+			continue
+		}
+
+		flag := findFlag(metadata.Flags, name)
+		if flag == nil || i+1 == len(redacted) {
+			continue
+		}
+		if _, isBool := flag.(*flags.BoolFlag); isBool {
+			continue
+		}
+
+		i++
+		if flag.IsSensitive() {
+			redacted[i] = trace.PrivateDataPlaceholder()
+		}
+	}
+
+	return redacted
+}
+
+func findFlag(cmdFlags map[string]flags.FlagSet, name string) flags.FlagSet {
+	for _, flag := range cmdFlags {
+		if flag.GetName() == name || flag.GetShortName() == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RunHooks runs context.Hook in every plugin that registered it and returns
+// the vetoes of the plugins that asked for the command to be stopped. It stops
+// at the first plugin that cannot be run.
+func RunHooks(rpcService *CliRpcService, context plugin.HookContext, pluginList map[string]pluginconfig.PluginMetadata) ([]HookVeto, error) {
+	vetoes := []HookVeto{}
+
+	names := PluginsWithHook(context.Hook, pluginList)
+	if len(names) == 0 {
+		return vetoes, nil
+	}
+
+	for _, name := range names {
+		veto, err := runHook(rpcService, context, pluginList[name])
+		if err != nil {
+			return vetoes, fmt.Errorf("%s: %s", name, err.Error())
+		}
+
+		if veto != "" {
+			vetoes = append(vetoes, HookVeto{PluginName: name, Message: veto})
+		}
+	}
+
+	return vetoes, nil
+}
+
+// runHook runs context.Hook in a plugin and returns its veto, if any
+func runHook(rpcService *CliRpcService, context plugin.HookContext, metadata pluginconfig.PluginMetadata) (string, error) {
+	env, err := rpcService.StartForPlugin(metadata)
+	if err != nil {
+		return "", err
+	}
+	defer rpcService.Stop()
+
+	rpcService.RpcCmd.setHookContext(context)
+
+	cmd := exec.Command(metadata.Location, rpcService.Port(), "RunHook")
+	cmd.Env = env
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	err = cmd.Run()
+	if err != nil {
+		return "", err
+	}
+
+	return rpcService.RpcCmd.getHookVeto(), nil
+}
+
+// setHookContext sets the context of the hook about to run and clears the
+// veto of the previous one
+func (cmd *CliRpcCmd) setHookContext(context plugin.HookContext) {
+	cmd.hookMutex.Lock()
+	defer cmd.hookMutex.Unlock()
+
+	cmd.hookContext = context
+	cmd.hookVeto = ""
+}
+
+func (cmd *CliRpcCmd) getHookVeto() string {
+	cmd.hookMutex.Lock()
+	defer cmd.hookMutex.Unlock()
+
+	return cmd.hookVeto
+}
+
+func (cmd *CliRpcCmd) GetHookContext(_ string, retVal *plugin.HookContext) error {
+	cmd.hookMutex.Lock()
+	defer cmd.hookMutex.Unlock()
+
+	*retVal = cmd.hookContext
+	return nil
+}
+
+func (cmd *CliRpcCmd) VetoCommand(message string, retVal *bool) error {
+	cmd.hookMutex.Lock()
+	defer cmd.hookMutex.Unlock()
+
+	cmd.hookVeto = message
+	*retVal = true
+	return nil
+}
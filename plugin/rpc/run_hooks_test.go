@@ -0,0 +1,161 @@
+package rpc_test
+
+import (
+	"net/rpc"
+
+	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/commandregistry"
+	"github.com/cloudfoundry/cli/cf/commands/application"
+	"github.com/cloudfoundry/cli/cf/commands/environmentvariablegroup"
+	"github.com/cloudfoundry/cli/cf/commands/service"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/flags"
+	"github.com/cloudfoundry/cli/plugin"
+	. "github.com/cloudfoundry/cli/plugin/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hooks", func() {
+	var pluginList map[string]pluginconfig.PluginMetadata
+
+	BeforeEach(func() {
+		pluginList = map[string]pluginconfig.PluginMetadata{
+			"Notifier": {
+				Location: "/path/to/notifier",
+				Hooks:    []plugin.Hook{{Name: "post-push"}},
+			},
+			"Policy": {
+				Location: "/path/to/policy",
+				Hooks:    []plugin.Hook{{Name: "pre-push"}, {Name: "post-push"}},
+			},
+			"Other": {
+				Location: "/path/to/other",
+			},
+		}
+	})
+
+	Describe("RedactArgs", func() {
+		var loginMetadata commandregistry.CommandMetadata
+
+		BeforeEach(func() {
+			loginMetadata = commandregistry.CommandMetadata{
+				Flags: map[string]flags.FlagSet{
+					"a":                   &flags.StringFlag{ShortName: "a"},
+					"p":                   &flags.StringFlag{ShortName: "p", Sensitive: true},
+					"skip-ssl-validation": &flags.BoolFlag{Name: "skip-ssl-validation"},
+				},
+			}
+		})
+
+		It("hides sensitive arguments", func() {
+			Expect(RedactArgs([]string{"admin", "my-password"}, commandregistry.CommandMetadata{SensitiveArgs: []int{1}})).To(Equal(
+				[]string{"admin", "[PRIVATE DATA HIDDEN]"}))
+		})
+
+		It("hides the values of sensitive flags", func() {
+			Expect(RedactArgs([]string{"-a", "api.example.com", "-p", "my-password", "--skip-ssl-validation"}, loginMetadata)).To(Equal(
+				[]string{"-a", "api.example.com", "-p", "[PRIVATE DATA HIDDEN]", "--skip-ssl-validation"}))
+			Expect(RedactArgs([]string{"-p=my-password"}, loginMetadata)).To(Equal([]string{"-p=[PRIVATE DATA HIDDEN]"}))
+		})
+
+		It("counts arguments after the values of flags", func() {
+			metadata := commandregistry.CommandMetadata{
+				Flags: map[string]flags.FlagSet{
+					"client-credentials": &flags.BoolFlag{Name: "client-credentials"},
+					"x":                  &flags.StringFlag{ShortName: "x"},
+				},
+				SensitiveArgs: []int{1},
+			}
+			Expect(RedactArgs([]string{"--client-credentials", "my-client", "my-secret"}, metadata)).To(Equal(
+				[]string{"--client-credentials", "my-client", "[PRIVATE DATA HIDDEN]"}))
+			Expect(RedactArgs([]string{"-x", "y", "my-client", "my-secret"}, metadata)).To(Equal(
+				[]string{"-x", "y", "my-client", "[PRIVATE DATA HIDDEN]"}))
+		})
+
+		It("counts every argument when the command skips flag parsing", func() {
+			metadata := (&application.SetEnv{}).MetaData()
+			Expect(RedactArgs([]string{"my-app", "NAME", "-value"}, metadata)).To(Equal(
+				[]string{"my-app", "NAME", "[PRIVATE DATA HIDDEN]"}))
+		})
+
+		It("leaves arguments and flags that are not sensitive alone", func() {
+			args := []string{"my-app", "-a", "api.example.com"}
+			Expect(RedactArgs(args, loginMetadata)).To(Equal(args))
+		})
+
+		It("hides the credentials given to core commands", func() {
+			Expect(RedactArgs([]string{"p-mysql", "small", "db", "-c", `{"password":"secret"}`, "-t", "prod"}, (&service.CreateService{}).MetaData())).To(Equal(
+				[]string{"p-mysql", "small", "db", "-c", "[PRIVATE DATA HIDDEN]", "-t", "prod"}))
+			Expect(RedactArgs([]string{"logs", "-p", `{"user":"u"}`, "-l", "syslog://u:p@logs.example.com", "-r", "https://u:p@proxy.example.com"}, (&service.CreateUserProvidedService{}).MetaData())).To(Equal(
+				[]string{"logs", "-p", "[PRIVATE DATA HIDDEN]", "-l", "[PRIVATE DATA HIDDEN]", "-r", "[PRIVATE DATA HIDDEN]"}))
+			Expect(RedactArgs([]string{`{"DB_PASSWORD":"secret"}`}, (&environmentvariablegroup.SetRunningEnvironmentVariableGroup{}).MetaData())).To(Equal(
+				[]string{"[PRIVATE DATA HIDDEN]"}))
+		})
+	})
+
+	Describe("PluginsWithHook", func() {
+		It("returns the plugins that registered the hook in alphabetical order", func() {
+			Expect(PluginsWithHook("post-push", pluginList)).To(Equal([]string{"Notifier", "Policy"}))
+			Expect(PluginsWithHook("pre-push", pluginList)).To(Equal([]string{"Policy"}))
+			Expect(PluginsWithHook("pre-delete", pluginList)).To(BeEmpty())
+		})
+	})
+
+	Describe("RunHooks", func() {
+		var rpcService *CliRpcService
+
+		BeforeEach(func() {
+			var err error
+			rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("does nothing when no plugin registered the hook", func() {
+			vetoes, err := RunHooks(rpcService, plugin.HookContext{Hook: "pre-delete"}, pluginList)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vetoes).To(BeEmpty())
+		})
+
+		It("returns an error naming the plugin that could not be run", func() {
+			_, err := RunHooks(rpcService, plugin.HookContext{Hook: "pre-push"}, pluginList)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("Policy: "))
+		})
+	})
+
+	Describe(".VetoCommand and .GetHookContext", func() {
+		var client *rpc.Client
+
+		BeforeEach(func() {
+			rpcService, err := NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			client.Close()
+		})
+
+		It("accepts a veto", func() {
+			var success bool
+			err := client.Call("CliRpcCmd.VetoCommand", "no change ticket", &success)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeTrue())
+		})
+
+		It("returns the context of the running hook", func() {
+			var context plugin.HookContext
+			err := client.Call("CliRpcCmd.GetHookContext", "", &context)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(context).To(Equal(plugin.HookContext{}))
+		})
+	})
+})
@@ -1,40 +1,132 @@
 package rpc
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	. "github.com/cloudfoundry/cli/cf/i18n"
 )
 
-func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) bool {
+// PluginGracePeriod is how long a plugin gets to exit after it was
+// forwarded SIGINT or SIGTERM, unless it requested more cleanup time
+var PluginGracePeriod = 5 * time.Second
+
+// MaxPluginCleanupTime is the longest cleanup time a plugin can request
+const MaxPluginCleanupTime = 5 * time.Minute
+
+// RunMethodIfExists runs the plugin command named by args[0]. It returns
+// false when no installed plugin provides the command, and otherwise the
+// exit status of the plugin.
+func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) (bool, int) {
 	for _, metadata := range pluginList {
 		for _, command := range metadata.Commands {
-			if command.Name == args[0] || command.Alias == args[0] {
+			if command.Matches(args[0]) {
 				args[0] = command.Name
 
-				rpcService.Start()
+				env, err := rpcService.StartForPlugin(metadata)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err.Error())
+					return true, 1
+				}
 				defer rpcService.Stop()
 
 				pluginArgs := append([]string{rpcService.Port()}, args...)
 
 				cmd := exec.Command(metadata.Location, pluginArgs...)
+				cmd.Env = env
 				cmd.Stdout = os.Stdout
+				cmd.Stderr = os.Stderr
 				cmd.Stdin = os.Stdin
 
-				defer stopPlugin(cmd)
-				err := cmd.Run()
-				if err != nil {
-					os.Exit(1)
-				}
-				return true
+				return true, runPlugin(cmd, rpcService.RpcCmd)
+			}
+		}
+	}
+	return false, 0
+}
+
+// runPlugin runs the plugin and makes sure it gets SIGINT and SIGTERM once,
+// forwarding them where needed. A plugin that has not exited when its
+// cleanup time is up, or when a second signal arrives, is killed.
+func runPlugin(cmd *exec.Cmd, rpcCmd *CliRpcCmd) int {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	setPluginProcessGroup(cmd)
+	err := cmd.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 1
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var deadline <-chan time.Time
+	for {
+		select {
+		case err = <-done:
+			return exitStatus(err)
+		case sig := <-signals:
+			if deadline != nil || forwardSignal(cmd, sig) != nil {
+				cmd.Process.Kill()
+				continue
 			}
+			deadline = time.After(rpcCmd.getCleanupTime())
+		case <-deadline:
+			cmd.Process.Kill()
 		}
 	}
-	return false
 }
 
-func stopPlugin(plugin *exec.Cmd) {
-	plugin.Process.Kill()
-	plugin.Wait()
+// exitStatus returns the exit status of a plugin that ended with err, using
+// the shell convention of 128 plus the signal number for a killed plugin
+func exitStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			if status.Signaled() {
+				return 128 + int(status.Signal())
+			}
+			return status.ExitStatus()
+		}
+	}
+
+	return 1
+}
+
+func (cmd *CliRpcCmd) getCleanupTime() time.Duration {
+	cmd.cleanupTimeMutex.Lock()
+	defer cmd.cleanupTimeMutex.Unlock()
+
+	if cmd.cleanupTime > PluginGracePeriod {
+		return cmd.cleanupTime
+	}
+	return PluginGracePeriod
+}
+
+func (cmd *CliRpcCmd) RequestCleanupTime(cleanupTime time.Duration, retVal *bool) error {
+	if cleanupTime > MaxPluginCleanupTime {
+		*retVal = false
+		return errors.New(T("Plugins can request at most {{.Time}} of cleanup time", map[string]interface{}{"Time": MaxPluginCleanupTime}))
+	}
+
+	cmd.cleanupTimeMutex.Lock()
+	defer cmd.cleanupTimeMutex.Unlock()
+
+	cmd.cleanupTime = cleanupTime
+	*retVal = true
+	return nil
 }
@@ -0,0 +1,101 @@
+package rpc_test
+
+import (
+	"io/ioutil"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/api"
+	"github.com/cloudfoundry/cli/cf/configuration/pluginconfig"
+	"github.com/cloudfoundry/cli/plugin"
+	. "github.com/cloudfoundry/cli/plugin/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Running plugins", func() {
+	var (
+		rpcService        *CliRpcService
+		dir               string
+		writePluginScript func(script string) map[string]pluginconfig.PluginMetadata
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("This uses shell scripts as plugins")
+		}
+
+		var err error
+		rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		dir, err = ioutil.TempDir("", "run-plugin")
+		Expect(err).ToNot(HaveOccurred())
+
+		writePluginScript = func(script string) map[string]pluginconfig.PluginMetadata {
+			location := filepath.Join(dir, "plugin.sh")
+			err := ioutil.WriteFile(location, []byte("#!/bin/sh\n"+script), 0700)
+			Expect(err).ToNot(HaveOccurred())
+
+			return map[string]pluginconfig.PluginMetadata{
+				"Script": {
+					Location: location,
+					Commands: []plugin.Command{{Name: "script", Aliases: []string{"sc"}}},
+				},
+			}
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("RunMethodIfExists", func() {
+		It("returns false when no plugin provides the command", func() {
+			ran, _ := RunMethodIfExists(rpcService, []string{"unknown"}, writePluginScript("exit 0\n"))
+			Expect(ran).To(BeFalse())
+		})
+
+		It("returns the exit status of the plugin", func() {
+			ran, exitStatus := RunMethodIfExists(rpcService, []string{"sc"}, writePluginScript("exit 3\n"))
+			Expect(ran).To(BeTrue())
+			Expect(exitStatus).To(Equal(3))
+		})
+	})
+
+	Describe(".RequestCleanupTime", func() {
+		var client *rpc.Client
+
+		BeforeEach(func() {
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			client.Close()
+			rpcService.Stop()
+		})
+
+		It("accepts cleanup times up to the maximum", func() {
+			var success bool
+			err := client.Call("CliRpcCmd.RequestCleanupTime", 30*time.Second, &success)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeTrue())
+		})
+
+		It("rejects cleanup times longer than the maximum", func() {
+			var success bool
+			err := client.Call("CliRpcCmd.RequestCleanupTime", MaxPluginCleanupTime+time.Second, &success)
+			Expect(err).To(MatchError("Plugins can request at most 5m0s of cleanup time"))
+			Expect(success).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,32 @@
+// +build !windows
+
+package rpc
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// setPluginProcessGroup starts the plugin in its own process group, so that
+// a signal sent to the group of cf reaches it once, forwarded by cf. A plugin
+// that reads from the terminal has to stay in the foreground group of the
+// terminal, which already sends it the Ctrl-C.
+func setPluginProcessGroup(cmd *exec.Cmd) {
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return
+	}
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// forwardSignal sends sig on to the plugin, unless the terminal sent it to
+// the plugin already
+func forwardSignal(cmd *exec.Cmd, sig os.Signal) error {
+	ownGroup := cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid
+	if sig == os.Interrupt && !ownGroup {
+		return nil
+	}
+	return cmd.Process.Signal(sig)
+}
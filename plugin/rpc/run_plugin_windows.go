@@ -0,0 +1,16 @@
+// +build windows
+
+package rpc
+
+import (
+	"os"
+	"os/exec"
+)
+
+func setPluginProcessGroup(cmd *exec.Cmd) {}
+
+// forwardSignal does nothing: Windows sends Ctrl-C to every process of the
+// console, the plugin included, and cannot signal a single process
+func forwardSignal(cmd *exec.Cmd, sig os.Signal) error {
+	return nil
+}
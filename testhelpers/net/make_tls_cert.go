@@ -8,8 +8,10 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"io/ioutil"
 	"math/big"
 	"net"
+	"path/filepath"
 	"time"
 )
 
@@ -17,6 +19,10 @@ func MakeSelfSignedTLSCert() tls.Certificate {
 	return generateCert([]string{"127.0.0.1", "::1"}, time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC), true)
 }
 
+func MakeValidTLSCert() tls.Certificate {
+	return generateCert([]string{"127.0.0.1", "::1"}, time.Now().AddDate(1, 0, 0), true)
+}
+
 func MakeTLSCertWithInvalidHost() tls.Certificate {
 	return generateCert([]string{"example.com"}, time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC), true)
 }
@@ -44,7 +50,7 @@ func generateCert(hosts []string, notAfter time.Time, isAuthorizedToSign bool) t
 		NotAfter:  notAfter,
 
 		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
 		BasicConstraintsValid: true,
 	}
 
@@ -79,3 +85,26 @@ func generateCert(hosts []string, notAfter time.Time, isAuthorizedToSign bool) t
 
 	return cert
 }
+
+// WriteTLSCertFiles writes the certificate and private key of cert to PEM
+// files in dir and returns their paths
+func WriteTLSCertFiles(cert tls.Certificate, dir string) (string, string) {
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	certOut := new(bytes.Buffer)
+	pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	err := ioutil.WriteFile(certFile, certOut.Bytes(), 0600)
+	if err != nil {
+		panic(err)
+	}
+
+	keyOut := new(bytes.Buffer)
+	pem.Encode(keyOut, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(cert.PrivateKey.(*rsa.PrivateKey))})
+	err = ioutil.WriteFile(keyFile, keyOut.Bytes(), 0600)
+	if err != nil {
+		panic(err)
+	}
+
+	return certFile, keyFile
+}
@@ -3,6 +3,7 @@ package rpcserverfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -280,6 +281,177 @@ type FakeHandlers struct {
 	getServiceReturns struct {
 		result1 error
 	}
+	GetRoutesStub        func(args string, retVal *[]plugin_models.GetRoutes_Model) error
+	getRoutesMutex       sync.RWMutex
+	getRoutesArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetRoutes_Model
+	}
+	getRoutesReturns struct {
+		result1 error
+	}
+	GetDomainsStub        func(args string, retVal *[]plugin_models.GetDomains_Model) error
+	getDomainsMutex       sync.RWMutex
+	getDomainsArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetDomains_Model
+	}
+	getDomainsReturns struct {
+		result1 error
+	}
+	GetAppInstancesStub        func(appName string, retVal *[]plugin_models.GetAppInstances_Model) error
+	getAppInstancesMutex       sync.RWMutex
+	getAppInstancesArgsForCall []struct {
+		appName string
+		retVal  *[]plugin_models.GetAppInstances_Model
+	}
+	getAppInstancesReturns struct {
+		result1 error
+	}
+	GetAppEventsStub        func(appName string, retVal *[]plugin_models.GetAppEvents_Model) error
+	getAppEventsMutex       sync.RWMutex
+	getAppEventsArgsForCall []struct {
+		appName string
+		retVal  *[]plugin_models.GetAppEvents_Model
+	}
+	getAppEventsReturns struct {
+		result1 error
+	}
+	GetServiceKeysStub        func(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error
+	getServiceKeysMutex       sync.RWMutex
+	getServiceKeysArgsForCall []struct {
+		serviceInstance string
+		retVal          *[]plugin_models.GetServiceKeys_Model
+	}
+	getServiceKeysReturns struct {
+		result1 error
+	}
+	GetSecurityGroupsStub        func(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetSecurityGroups_Model
+	}
+	getSecurityGroupsReturns struct {
+		result1 error
+	}
+	GetQuotasStub        func(args string, retVal *[]plugin_models.GetQuotas_Model) error
+	getQuotasMutex       sync.RWMutex
+	getQuotasArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetQuotas_Model
+	}
+	getQuotasReturns struct {
+		result1 error
+	}
+	GetSpaceQuotasStub        func(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error
+	getSpaceQuotasMutex       sync.RWMutex
+	getSpaceQuotasArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetSpaceQuotas_Model
+	}
+	getSpaceQuotasReturns struct {
+		result1 error
+	}
+	GetBuildpacksStub        func(args string, retVal *[]plugin_models.GetBuildpacks_Model) error
+	getBuildpacksMutex       sync.RWMutex
+	getBuildpacksArgsForCall []struct {
+		args   string
+		retVal *[]plugin_models.GetBuildpacks_Model
+	}
+	getBuildpacksReturns struct {
+		result1 error
+	}
+	CurlCCStub        func(args []string, retVal *plugin_models.CurlResponse_Model) error
+	curlCCMutex       sync.RWMutex
+	curlCCArgsForCall []struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}
+	curlCCReturns struct {
+		result1 error
+	}
+	CurlUAAStub        func(args []string, retVal *plugin_models.CurlResponse_Model) error
+	curlUAAMutex       sync.RWMutex
+	curlUAAArgsForCall []struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}
+	curlUAAReturns struct {
+		result1 error
+	}
+	CurlRoutingAPIStub        func(args []string, retVal *plugin_models.CurlResponse_Model) error
+	curlRoutingAPIMutex       sync.RWMutex
+	curlRoutingAPIArgsForCall []struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}
+	curlRoutingAPIReturns struct {
+		result1 error
+	}
+	GetRecentLogsStub        func(appName string, retVal *[]plugin_models.LogMessage_Model) error
+	getRecentLogsMutex       sync.RWMutex
+	getRecentLogsArgsForCall []struct {
+		appName string
+		retVal  *[]plugin_models.LogMessage_Model
+	}
+	getRecentLogsReturns struct {
+		result1 error
+	}
+	StartLogStreamStub        func(appName string, retVal *bool) error
+	startLogStreamMutex       sync.RWMutex
+	startLogStreamArgsForCall []struct {
+		appName string
+		retVal  *bool
+	}
+	startLogStreamReturns struct {
+		result1 error
+	}
+	NextLogMessagesStub        func(args string, retVal *plugin_models.TailLogs_Model) error
+	nextLogMessagesMutex       sync.RWMutex
+	nextLogMessagesArgsForCall []struct {
+		args   string
+		retVal *plugin_models.TailLogs_Model
+	}
+	nextLogMessagesReturns struct {
+		result1 error
+	}
+	StopLogStreamStub        func(args string, retVal *bool) error
+	stopLogStreamMutex       sync.RWMutex
+	stopLogStreamArgsForCall []struct {
+		args   string
+		retVal *bool
+	}
+	stopLogStreamReturns struct {
+		result1 error
+	}
+	GetHookContextStub        func(args string, retVal *plugin.HookContext) error
+	getHookContextMutex       sync.RWMutex
+	getHookContextArgsForCall []struct {
+		args   string
+		retVal *plugin.HookContext
+	}
+	getHookContextReturns struct {
+		result1 error
+	}
+	VetoCommandStub        func(message string, retVal *bool) error
+	vetoCommandMutex       sync.RWMutex
+	vetoCommandArgsForCall []struct {
+		message string
+		retVal  *bool
+	}
+	vetoCommandReturns struct {
+		result1 error
+	}
+	RequestCleanupTimeStub        func(cleanupTime time.Duration, retVal *bool) error
+	requestCleanupTimeMutex       sync.RWMutex
+	requestCleanupTimeArgsForCall []struct {
+		cleanupTime time.Duration
+		retVal      *bool
+	}
+	requestCleanupTimeReturns struct {
+		result1 error
+	}
 }
 
 func (fake *FakeHandlers) IsMinCliVersion(args string, retVal *bool) error {
@@ -382,11 +554,16 @@ func (fake *FakeHandlers) DisableTerminalOutputReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) CallCoreCommand(args []string, retVal *bool) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.callCoreCommandMutex.Lock()
 	fake.callCoreCommandArgsForCall = append(fake.callCoreCommandArgsForCall, struct {
 		args   []string
 		retVal *bool
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.callCoreCommandMutex.Unlock()
 	if fake.CallCoreCommandStub != nil {
 		return fake.CallCoreCommandStub(args, retVal)
@@ -1108,11 +1285,16 @@ func (fake *FakeHandlers) GetServicesReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgUsers_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.getOrgUsersMutex.Lock()
 	fake.getOrgUsersArgsForCall = append(fake.getOrgUsersArgsForCall, struct {
 		args   []string
 		retVal *[]plugin_models.GetOrgUsers_Model
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.getOrgUsersMutex.Unlock()
 	if fake.GetOrgUsersStub != nil {
 		return fake.GetOrgUsersStub(args, retVal)
@@ -1141,11 +1323,16 @@ func (fake *FakeHandlers) GetOrgUsersReturns(result1 error) {
 }
 
 func (fake *FakeHandlers) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSpaceUsers_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
 	fake.getSpaceUsersMutex.Lock()
 	fake.getSpaceUsersArgsForCall = append(fake.getSpaceUsersArgsForCall, struct {
 		args   []string
 		retVal *[]plugin_models.GetSpaceUsers_Model
-	}{args, retVal})
+	}{argsCopy, retVal})
 	fake.getSpaceUsersMutex.Unlock()
 	if fake.GetSpaceUsersStub != nil {
 		return fake.GetSpaceUsersStub(args, retVal)
@@ -1272,4 +1459,646 @@ func (fake *FakeHandlers) GetServiceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHandlers) GetRoutes(args string, retVal *[]plugin_models.GetRoutes_Model) error {
+	fake.getRoutesMutex.Lock()
+	fake.getRoutesArgsForCall = append(fake.getRoutesArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetRoutes_Model
+	}{args, retVal})
+	fake.getRoutesMutex.Unlock()
+	if fake.GetRoutesStub != nil {
+		return fake.GetRoutesStub(args, retVal)
+	} else {
+		return fake.getRoutesReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetRoutesCallCount() int {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return len(fake.getRoutesArgsForCall)
+}
+
+func (fake *FakeHandlers) GetRoutesArgsForCall(i int) (string, *[]plugin_models.GetRoutes_Model) {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return fake.getRoutesArgsForCall[i].args, fake.getRoutesArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetRoutesReturns(result1 error) {
+	fake.GetRoutesStub = nil
+	fake.getRoutesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetDomains(args string, retVal *[]plugin_models.GetDomains_Model) error {
+	fake.getDomainsMutex.Lock()
+	fake.getDomainsArgsForCall = append(fake.getDomainsArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetDomains_Model
+	}{args, retVal})
+	fake.getDomainsMutex.Unlock()
+	if fake.GetDomainsStub != nil {
+		return fake.GetDomainsStub(args, retVal)
+	} else {
+		return fake.getDomainsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetDomainsCallCount() int {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return len(fake.getDomainsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetDomainsArgsForCall(i int) (string, *[]plugin_models.GetDomains_Model) {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return fake.getDomainsArgsForCall[i].args, fake.getDomainsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetDomainsReturns(result1 error) {
+	fake.GetDomainsStub = nil
+	fake.getDomainsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetAppInstances(appName string, retVal *[]plugin_models.GetAppInstances_Model) error {
+	fake.getAppInstancesMutex.Lock()
+	fake.getAppInstancesArgsForCall = append(fake.getAppInstancesArgsForCall, struct {
+		appName string
+		retVal  *[]plugin_models.GetAppInstances_Model
+	}{appName, retVal})
+	fake.getAppInstancesMutex.Unlock()
+	if fake.GetAppInstancesStub != nil {
+		return fake.GetAppInstancesStub(appName, retVal)
+	} else {
+		return fake.getAppInstancesReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetAppInstancesCallCount() int {
+	fake.getAppInstancesMutex.RLock()
+	defer fake.getAppInstancesMutex.RUnlock()
+	return len(fake.getAppInstancesArgsForCall)
+}
+
+func (fake *FakeHandlers) GetAppInstancesArgsForCall(i int) (string, *[]plugin_models.GetAppInstances_Model) {
+	fake.getAppInstancesMutex.RLock()
+	defer fake.getAppInstancesMutex.RUnlock()
+	return fake.getAppInstancesArgsForCall[i].appName, fake.getAppInstancesArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetAppInstancesReturns(result1 error) {
+	fake.GetAppInstancesStub = nil
+	fake.getAppInstancesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error {
+	fake.getAppEventsMutex.Lock()
+	fake.getAppEventsArgsForCall = append(fake.getAppEventsArgsForCall, struct {
+		appName string
+		retVal  *[]plugin_models.GetAppEvents_Model
+	}{appName, retVal})
+	fake.getAppEventsMutex.Unlock()
+	if fake.GetAppEventsStub != nil {
+		return fake.GetAppEventsStub(appName, retVal)
+	} else {
+		return fake.getAppEventsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetAppEventsCallCount() int {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return len(fake.getAppEventsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetAppEventsArgsForCall(i int) (string, *[]plugin_models.GetAppEvents_Model) {
+	fake.getAppEventsMutex.RLock()
+	defer fake.getAppEventsMutex.RUnlock()
+	return fake.getAppEventsArgsForCall[i].appName, fake.getAppEventsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetAppEventsReturns(result1 error) {
+	fake.GetAppEventsStub = nil
+	fake.getAppEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	fake.getServiceKeysMutex.Lock()
+	fake.getServiceKeysArgsForCall = append(fake.getServiceKeysArgsForCall, struct {
+		serviceInstance string
+		retVal          *[]plugin_models.GetServiceKeys_Model
+	}{serviceInstance, retVal})
+	fake.getServiceKeysMutex.Unlock()
+	if fake.GetServiceKeysStub != nil {
+		return fake.GetServiceKeysStub(serviceInstance, retVal)
+	} else {
+		return fake.getServiceKeysReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetServiceKeysCallCount() int {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return len(fake.getServiceKeysArgsForCall)
+}
+
+func (fake *FakeHandlers) GetServiceKeysArgsForCall(i int) (string, *[]plugin_models.GetServiceKeys_Model) {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return fake.getServiceKeysArgsForCall[i].serviceInstance, fake.getServiceKeysArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetServiceKeysReturns(result1 error) {
+	fake.GetServiceKeysStub = nil
+	fake.getServiceKeysReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetSecurityGroups(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	fake.getSecurityGroupsMutex.Lock()
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetSecurityGroups_Model
+	}{args, retVal})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub(args, retVal)
+	} else {
+		return fake.getSecurityGroupsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsArgsForCall(i int) (string, *[]plugin_models.GetSecurityGroups_Model) {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return fake.getSecurityGroupsArgsForCall[i].args, fake.getSecurityGroupsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetSecurityGroupsReturns(result1 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetQuotas(args string, retVal *[]plugin_models.GetQuotas_Model) error {
+	fake.getQuotasMutex.Lock()
+	fake.getQuotasArgsForCall = append(fake.getQuotasArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetQuotas_Model
+	}{args, retVal})
+	fake.getQuotasMutex.Unlock()
+	if fake.GetQuotasStub != nil {
+		return fake.GetQuotasStub(args, retVal)
+	} else {
+		return fake.getQuotasReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetQuotasCallCount() int {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return len(fake.getQuotasArgsForCall)
+}
+
+func (fake *FakeHandlers) GetQuotasArgsForCall(i int) (string, *[]plugin_models.GetQuotas_Model) {
+	fake.getQuotasMutex.RLock()
+	defer fake.getQuotasMutex.RUnlock()
+	return fake.getQuotasArgsForCall[i].args, fake.getQuotasArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetQuotasReturns(result1 error) {
+	fake.GetQuotasStub = nil
+	fake.getQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetSpaceQuotas(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error {
+	fake.getSpaceQuotasMutex.Lock()
+	fake.getSpaceQuotasArgsForCall = append(fake.getSpaceQuotasArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetSpaceQuotas_Model
+	}{args, retVal})
+	fake.getSpaceQuotasMutex.Unlock()
+	if fake.GetSpaceQuotasStub != nil {
+		return fake.GetSpaceQuotasStub(args, retVal)
+	} else {
+		return fake.getSpaceQuotasReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasCallCount() int {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return len(fake.getSpaceQuotasArgsForCall)
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasArgsForCall(i int) (string, *[]plugin_models.GetSpaceQuotas_Model) {
+	fake.getSpaceQuotasMutex.RLock()
+	defer fake.getSpaceQuotasMutex.RUnlock()
+	return fake.getSpaceQuotasArgsForCall[i].args, fake.getSpaceQuotasArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetSpaceQuotasReturns(result1 error) {
+	fake.GetSpaceQuotasStub = nil
+	fake.getSpaceQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetBuildpacks(args string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	fake.getBuildpacksMutex.Lock()
+	fake.getBuildpacksArgsForCall = append(fake.getBuildpacksArgsForCall, struct {
+		args   string
+		retVal *[]plugin_models.GetBuildpacks_Model
+	}{args, retVal})
+	fake.getBuildpacksMutex.Unlock()
+	if fake.GetBuildpacksStub != nil {
+		return fake.GetBuildpacksStub(args, retVal)
+	} else {
+		return fake.getBuildpacksReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetBuildpacksCallCount() int {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return len(fake.getBuildpacksArgsForCall)
+}
+
+func (fake *FakeHandlers) GetBuildpacksArgsForCall(i int) (string, *[]plugin_models.GetBuildpacks_Model) {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return fake.getBuildpacksArgsForCall[i].args, fake.getBuildpacksArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetBuildpacksReturns(result1 error) {
+	fake.GetBuildpacksStub = nil
+	fake.getBuildpacksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) CurlCC(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
+	fake.curlCCMutex.Lock()
+	fake.curlCCArgsForCall = append(fake.curlCCArgsForCall, struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}{argsCopy, retVal})
+	fake.curlCCMutex.Unlock()
+	if fake.CurlCCStub != nil {
+		return fake.CurlCCStub(args, retVal)
+	} else {
+		return fake.curlCCReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) CurlCCCallCount() int {
+	fake.curlCCMutex.RLock()
+	defer fake.curlCCMutex.RUnlock()
+	return len(fake.curlCCArgsForCall)
+}
+
+func (fake *FakeHandlers) CurlCCArgsForCall(i int) ([]string, *plugin_models.CurlResponse_Model) {
+	fake.curlCCMutex.RLock()
+	defer fake.curlCCMutex.RUnlock()
+	return fake.curlCCArgsForCall[i].args, fake.curlCCArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) CurlCCReturns(result1 error) {
+	fake.CurlCCStub = nil
+	fake.curlCCReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) CurlUAA(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
+	fake.curlUAAMutex.Lock()
+	fake.curlUAAArgsForCall = append(fake.curlUAAArgsForCall, struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}{argsCopy, retVal})
+	fake.curlUAAMutex.Unlock()
+	if fake.CurlUAAStub != nil {
+		return fake.CurlUAAStub(args, retVal)
+	} else {
+		return fake.curlUAAReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) CurlUAACallCount() int {
+	fake.curlUAAMutex.RLock()
+	defer fake.curlUAAMutex.RUnlock()
+	return len(fake.curlUAAArgsForCall)
+}
+
+func (fake *FakeHandlers) CurlUAAArgsForCall(i int) ([]string, *plugin_models.CurlResponse_Model) {
+	fake.curlUAAMutex.RLock()
+	defer fake.curlUAAMutex.RUnlock()
+	return fake.curlUAAArgsForCall[i].args, fake.curlUAAArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) CurlUAAReturns(result1 error) {
+	fake.CurlUAAStub = nil
+	fake.curlUAAReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) CurlRoutingAPI(args []string, retVal *plugin_models.CurlResponse_Model) error {
+	var argsCopy []string
+	if args != nil {
+		argsCopy = make([]string, len(args))
+		copy(argsCopy, args)
+	}
+	fake.curlRoutingAPIMutex.Lock()
+	fake.curlRoutingAPIArgsForCall = append(fake.curlRoutingAPIArgsForCall, struct {
+		args   []string
+		retVal *plugin_models.CurlResponse_Model
+	}{argsCopy, retVal})
+	fake.curlRoutingAPIMutex.Unlock()
+	if fake.CurlRoutingAPIStub != nil {
+		return fake.CurlRoutingAPIStub(args, retVal)
+	} else {
+		return fake.curlRoutingAPIReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) CurlRoutingAPICallCount() int {
+	fake.curlRoutingAPIMutex.RLock()
+	defer fake.curlRoutingAPIMutex.RUnlock()
+	return len(fake.curlRoutingAPIArgsForCall)
+}
+
+func (fake *FakeHandlers) CurlRoutingAPIArgsForCall(i int) ([]string, *plugin_models.CurlResponse_Model) {
+	fake.curlRoutingAPIMutex.RLock()
+	defer fake.curlRoutingAPIMutex.RUnlock()
+	return fake.curlRoutingAPIArgsForCall[i].args, fake.curlRoutingAPIArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) CurlRoutingAPIReturns(result1 error) {
+	fake.CurlRoutingAPIStub = nil
+	fake.curlRoutingAPIReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetRecentLogs(appName string, retVal *[]plugin_models.LogMessage_Model) error {
+	fake.getRecentLogsMutex.Lock()
+	fake.getRecentLogsArgsForCall = append(fake.getRecentLogsArgsForCall, struct {
+		appName string
+		retVal  *[]plugin_models.LogMessage_Model
+	}{appName, retVal})
+	fake.getRecentLogsMutex.Unlock()
+	if fake.GetRecentLogsStub != nil {
+		return fake.GetRecentLogsStub(appName, retVal)
+	} else {
+		return fake.getRecentLogsReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetRecentLogsCallCount() int {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return len(fake.getRecentLogsArgsForCall)
+}
+
+func (fake *FakeHandlers) GetRecentLogsArgsForCall(i int) (string, *[]plugin_models.LogMessage_Model) {
+	fake.getRecentLogsMutex.RLock()
+	defer fake.getRecentLogsMutex.RUnlock()
+	return fake.getRecentLogsArgsForCall[i].appName, fake.getRecentLogsArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetRecentLogsReturns(result1 error) {
+	fake.GetRecentLogsStub = nil
+	fake.getRecentLogsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StartLogStream(appName string, retVal *bool) error {
+	fake.startLogStreamMutex.Lock()
+	fake.startLogStreamArgsForCall = append(fake.startLogStreamArgsForCall, struct {
+		appName string
+		retVal  *bool
+	}{appName, retVal})
+	fake.startLogStreamMutex.Unlock()
+	if fake.StartLogStreamStub != nil {
+		return fake.StartLogStreamStub(appName, retVal)
+	} else {
+		return fake.startLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StartLogStreamCallCount() int {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return len(fake.startLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StartLogStreamArgsForCall(i int) (string, *bool) {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return fake.startLogStreamArgsForCall[i].appName, fake.startLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StartLogStreamReturns(result1 error) {
+	fake.StartLogStreamStub = nil
+	fake.startLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) NextLogMessages(args string, retVal *plugin_models.TailLogs_Model) error {
+	fake.nextLogMessagesMutex.Lock()
+	fake.nextLogMessagesArgsForCall = append(fake.nextLogMessagesArgsForCall, struct {
+		args   string
+		retVal *plugin_models.TailLogs_Model
+	}{args, retVal})
+	fake.nextLogMessagesMutex.Unlock()
+	if fake.NextLogMessagesStub != nil {
+		return fake.NextLogMessagesStub(args, retVal)
+	} else {
+		return fake.nextLogMessagesReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) NextLogMessagesCallCount() int {
+	fake.nextLogMessagesMutex.RLock()
+	defer fake.nextLogMessagesMutex.RUnlock()
+	return len(fake.nextLogMessagesArgsForCall)
+}
+
+func (fake *FakeHandlers) NextLogMessagesArgsForCall(i int) (string, *plugin_models.TailLogs_Model) {
+	fake.nextLogMessagesMutex.RLock()
+	defer fake.nextLogMessagesMutex.RUnlock()
+	return fake.nextLogMessagesArgsForCall[i].args, fake.nextLogMessagesArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) NextLogMessagesReturns(result1 error) {
+	fake.NextLogMessagesStub = nil
+	fake.nextLogMessagesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StopLogStream(args string, retVal *bool) error {
+	fake.stopLogStreamMutex.Lock()
+	fake.stopLogStreamArgsForCall = append(fake.stopLogStreamArgsForCall, struct {
+		args   string
+		retVal *bool
+	}{args, retVal})
+	fake.stopLogStreamMutex.Unlock()
+	if fake.StopLogStreamStub != nil {
+		return fake.StopLogStreamStub(args, retVal)
+	} else {
+		return fake.stopLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StopLogStreamCallCount() int {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return len(fake.stopLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StopLogStreamArgsForCall(i int) (string, *bool) {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return fake.stopLogStreamArgsForCall[i].args, fake.stopLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StopLogStreamReturns(result1 error) {
+	fake.StopLogStreamStub = nil
+	fake.stopLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetHookContext(args string, retVal *plugin.HookContext) error {
+	fake.getHookContextMutex.Lock()
+	fake.getHookContextArgsForCall = append(fake.getHookContextArgsForCall, struct {
+		args   string
+		retVal *plugin.HookContext
+	}{args, retVal})
+	fake.getHookContextMutex.Unlock()
+	if fake.GetHookContextStub != nil {
+		return fake.GetHookContextStub(args, retVal)
+	} else {
+		return fake.getHookContextReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetHookContextCallCount() int {
+	fake.getHookContextMutex.RLock()
+	defer fake.getHookContextMutex.RUnlock()
+	return len(fake.getHookContextArgsForCall)
+}
+
+func (fake *FakeHandlers) GetHookContextArgsForCall(i int) (string, *plugin.HookContext) {
+	fake.getHookContextMutex.RLock()
+	defer fake.getHookContextMutex.RUnlock()
+	return fake.getHookContextArgsForCall[i].args, fake.getHookContextArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetHookContextReturns(result1 error) {
+	fake.GetHookContextStub = nil
+	fake.getHookContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) VetoCommand(message string, retVal *bool) error {
+	fake.vetoCommandMutex.Lock()
+	fake.vetoCommandArgsForCall = append(fake.vetoCommandArgsForCall, struct {
+		message string
+		retVal  *bool
+	}{message, retVal})
+	fake.vetoCommandMutex.Unlock()
+	if fake.VetoCommandStub != nil {
+		return fake.VetoCommandStub(message, retVal)
+	} else {
+		return fake.vetoCommandReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) VetoCommandCallCount() int {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return len(fake.vetoCommandArgsForCall)
+}
+
+func (fake *FakeHandlers) VetoCommandArgsForCall(i int) (string, *bool) {
+	fake.vetoCommandMutex.RLock()
+	defer fake.vetoCommandMutex.RUnlock()
+	return fake.vetoCommandArgsForCall[i].message, fake.vetoCommandArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) VetoCommandReturns(result1 error) {
+	fake.VetoCommandStub = nil
+	fake.vetoCommandReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) RequestCleanupTime(cleanupTime time.Duration, retVal *bool) error {
+	fake.requestCleanupTimeMutex.Lock()
+	fake.requestCleanupTimeArgsForCall = append(fake.requestCleanupTimeArgsForCall, struct {
+		cleanupTime time.Duration
+		retVal      *bool
+	}{cleanupTime, retVal})
+	fake.requestCleanupTimeMutex.Unlock()
+	if fake.RequestCleanupTimeStub != nil {
+		return fake.RequestCleanupTimeStub(cleanupTime, retVal)
+	} else {
+		return fake.requestCleanupTimeReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) RequestCleanupTimeCallCount() int {
+	fake.requestCleanupTimeMutex.RLock()
+	defer fake.requestCleanupTimeMutex.RUnlock()
+	return len(fake.requestCleanupTimeArgsForCall)
+}
+
+func (fake *FakeHandlers) RequestCleanupTimeArgsForCall(i int) (time.Duration, *bool) {
+	fake.requestCleanupTimeMutex.RLock()
+	defer fake.requestCleanupTimeMutex.RUnlock()
+	return fake.requestCleanupTimeArgsForCall[i].cleanupTime, fake.requestCleanupTimeArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) RequestCleanupTimeReturns(result1 error) {
+	fake.RequestCleanupTimeStub = nil
+	fake.requestCleanupTimeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 var _ rpcserver.Handlers = new(FakeHandlers)
@@ -8,6 +8,7 @@ import (
 	"net/rpc"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/cloudfoundry/cli/plugin"
 	"github.com/cloudfoundry/cli/plugin/models"
@@ -46,6 +47,25 @@ type Handlers interface {
 	GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error
 	GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error
 	GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error
+	GetRoutes(args string, retVal *[]plugin_models.GetRoutes_Model) error
+	GetDomains(args string, retVal *[]plugin_models.GetDomains_Model) error
+	GetAppInstances(appName string, retVal *[]plugin_models.GetAppInstances_Model) error
+	GetAppEvents(appName string, retVal *[]plugin_models.GetAppEvents_Model) error
+	GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error
+	GetSecurityGroups(args string, retVal *[]plugin_models.GetSecurityGroups_Model) error
+	GetQuotas(args string, retVal *[]plugin_models.GetQuotas_Model) error
+	GetSpaceQuotas(args string, retVal *[]plugin_models.GetSpaceQuotas_Model) error
+	GetBuildpacks(args string, retVal *[]plugin_models.GetBuildpacks_Model) error
+	CurlCC(args []string, retVal *plugin_models.CurlResponse_Model) error
+	CurlUAA(args []string, retVal *plugin_models.CurlResponse_Model) error
+	CurlRoutingAPI(args []string, retVal *plugin_models.CurlResponse_Model) error
+	GetRecentLogs(appName string, retVal *[]plugin_models.LogMessage_Model) error
+	StartLogStream(appName string, retVal *bool) error
+	NextLogMessages(args string, retVal *plugin_models.TailLogs_Model) error
+	StopLogStream(args string, retVal *bool) error
+	GetHookContext(args string, retVal *plugin.HookContext) error
+	VetoCommand(message string, retVal *bool) error
+	RequestCleanupTime(cleanupTime time.Duration, retVal *bool) error
 }
 
 type TestServer struct {
@@ -2,57 +2,76 @@ package utils
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 )
 
-//go:generate counterfeiter . Sha1Checksum
+//go:generate counterfeiter . Checksum
 
-type Sha1Checksum interface {
+type Checksum interface {
 	ComputeFileSha1() ([]byte, error)
 	CheckSha1(string) bool
+	ComputeFileSha256() ([]byte, error)
+	CheckSha256(string) bool
 	SetFilePath(string)
 }
 
-type sha1Checksum struct {
+type checksum struct {
 	filepath string
 }
 
-func NewSha1Checksum(filepath string) Sha1Checksum {
-	return &sha1Checksum{
+func NewChecksum(filepath string) Checksum {
+	return &checksum{
 		filepath: filepath,
 	}
 }
 
-func (c *sha1Checksum) ComputeFileSha1() ([]byte, error) {
-	hash := sha1.New()
+func (c *checksum) ComputeFileSha1() ([]byte, error) {
+	return c.computeFileHash(sha1.New())
+}
 
-	f, err := os.Open(c.filepath)
+func (c *checksum) CheckSha1(targetSha1 string) bool {
+	sha1, err := c.ComputeFileSha1()
 	if err != nil {
-		return []byte{}, err
+		return false
 	}
-	defer f.Close()
 
-	if _, err := io.Copy(hash, f); err != nil {
-		return []byte{}, err
+	if fmt.Sprintf("%x", sha1) == targetSha1 {
+		return true
 	}
+	return false
+}
 
-	return hash.Sum(nil), nil
+func (c *checksum) ComputeFileSha256() ([]byte, error) {
+	return c.computeFileHash(sha256.New())
 }
 
-func (c *sha1Checksum) CheckSha1(targetSha1 string) bool {
-	sha1, err := c.ComputeFileSha1()
+func (c *checksum) CheckSha256(targetSha256 string) bool {
+	sha256, err := c.ComputeFileSha256()
 	if err != nil {
 		return false
 	}
 
-	if fmt.Sprintf("%x", sha1) == targetSha1 {
-		return true
+	return fmt.Sprintf("%x", sha256) == targetSha256
+}
+
+func (c *checksum) computeFileHash(hash hash.Hash) ([]byte, error) {
+	f, err := os.Open(c.filepath)
+	if err != nil {
+		return []byte{}, err
 	}
-	return false
+	defer f.Close()
+
+	if _, err := io.Copy(hash, f); err != nil {
+		return []byte{}, err
+	}
+
+	return hash.Sum(nil), nil
 }
 
-func (c *sha1Checksum) SetFilePath(filepath string) {
+func (c *checksum) SetFilePath(filepath string) {
 	c.filepath = filepath
 }
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParsePublicKey reads an RSA or ECDSA public key in PEM format
+func ParsePublicKey(pemData string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return key, nil
+	default:
+		return nil, errors.New("only RSA and ECDSA public keys are supported")
+	}
+}
+
+// PublicKeyFingerprint is the hex encoded SHA-256 of a PEM public key
+func PublicKeyFingerprint(pemData string) (string, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return "", errors.New("no PEM encoded public key found")
+	}
+
+	sum := sha256.Sum256(block.Bytes)
+	return fmt.Sprintf("%x", sum), nil
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// VerifyFileSignature checks a signature of the SHA-256 of a file, such as
+// the ones made by `openssl dgst -sha256 -sign KEY -out FILE.sig FILE`. The
+// signature may be given as is or base64 encoded.
+func VerifyFileSignature(filepath string, signature []byte, publicKeyPEM string) error {
+	key, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return err
+	}
+
+	digest, err := NewChecksum(filepath).ComputeFileSha256()
+	if err != nil {
+		return err
+	}
+
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature))); err == nil {
+		signature = decoded
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature)
+	case *ecdsa.PublicKey:
+		parsed := ecdsaSignature{}
+		rest, err := asn1.Unmarshal(signature, &parsed)
+		if err != nil || len(rest) != 0 || parsed.R == nil || parsed.S == nil {
+			return errors.New("malformed ECDSA signature")
+		}
+		if !ecdsa.Verify(key, digest, parsed.R, parsed.S) {
+			return errors.New("ECDSA verification failure")
+		}
+	}
+
+	return nil
+}
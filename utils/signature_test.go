@@ -0,0 +1,131 @@
+package utils_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+
+	. "github.com/cloudfoundry/cli/utils"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Signatures", func() {
+	var (
+		f      *os.File
+		digest []byte
+	)
+
+	publicKeyPEM := func(key interface{}) string {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	}
+
+	BeforeEach(func() {
+		var err error
+		f, err = ioutil.TempFile("", "signature_test_")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+		f.Write([]byte("abc"))
+
+		sum := sha256.Sum256([]byte("abc"))
+		digest = sum[:]
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(f.Name())
+	})
+
+	Context("with an RSA key", func() {
+		var (
+			privateKey *rsa.PrivateKey
+			signature  []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			privateKey, err = rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+			signature, err = rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a signature of the file", func() {
+			Expect(VerifyFileSignature(f.Name(), signature, publicKeyPEM(&privateKey.PublicKey))).To(Succeed())
+		})
+
+		It("accepts a base64 encoded signature", func() {
+			encoded := []byte(base64.StdEncoding.EncodeToString(signature) + "\n")
+			Expect(VerifyFileSignature(f.Name(), encoded, publicKeyPEM(&privateKey.PublicKey))).To(Succeed())
+		})
+
+		It("rejects a signature made with another key", func() {
+			otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(VerifyFileSignature(f.Name(), signature, publicKeyPEM(&otherKey.PublicKey))).NotTo(Succeed())
+		})
+
+		It("rejects a signature of another file", func() {
+			Expect(ioutil.WriteFile(f.Name(), []byte("abd"), 0600)).To(Succeed())
+
+			Expect(VerifyFileSignature(f.Name(), signature, publicKeyPEM(&privateKey.PublicKey))).NotTo(Succeed())
+		})
+	})
+
+	Context("with an ECDSA key", func() {
+		var (
+			privateKey *ecdsa.PrivateKey
+			signature  []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest)
+			Expect(err).NotTo(HaveOccurred())
+			signature, err = asn1.Marshal(struct{ R, S *big.Int }{r, s})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a signature of the file", func() {
+			Expect(VerifyFileSignature(f.Name(), signature, publicKeyPEM(&privateKey.PublicKey))).To(Succeed())
+		})
+
+		It("rejects a malformed signature", func() {
+			Expect(VerifyFileSignature(f.Name(), []byte("not a signature"), publicKeyPEM(&privateKey.PublicKey))).NotTo(Succeed())
+		})
+	})
+
+	Describe("ParsePublicKey", func() {
+		It("rejects data that is not a PEM public key", func() {
+			_, err := ParsePublicKey("not a key")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("PublicKeyFingerprint", func() {
+		It("is the SHA-256 of the key", func() {
+			privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			fingerprint, err := PublicKeyFingerprint(publicKeyPEM(&privateKey.PublicKey))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fingerprint).To(HaveLen(64))
+		})
+	})
+})